@@ -23,6 +23,11 @@ import (
 // @host		localhost:9099
 // @BasePath	/
 // @schemes	http
+
+// @securityDefinitions.apikey	AdminAPIKey
+// @in							header
+// @name						Authorization
+// @description				Admin API key passed as "Bearer <key>"
 func main() {
 	cli := cmd.NewCLI()
 
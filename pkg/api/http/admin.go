@@ -0,0 +1,163 @@
+package http
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/routers"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CircuitAction defines what to do with the model circuit
+type CircuitAction = string
+
+const (
+	ResetCircuit CircuitAction = "reset" // close the circuit and forget the failure history
+	OpenCircuit  CircuitAction = "open"  // stop sending requests to the model until the circuit is reset
+)
+
+// AdminAuthMiddleware lets through requests that pass the admin API key as a bearer token
+func AdminAuthMiddleware(apiKey fields.Secret) Handler {
+	return func(c *fiber.Ctx) error {
+		token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+
+		if !found || len(apiKey) == 0 || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(schemas.ErrUnauthorized)
+		}
+
+		return c.Next()
+	}
+}
+
+// findCircuit returns the circuit breaker of the router model
+type findCircuit = func(routerID string, modelID string) (*health.CircuitBreaker, error)
+
+// LangRoutersHealthHandler
+//
+//	@id				glide-admin-language-health
+//	@Summary		Language Router Health
+//	@Description	Retrieve health, latency and circuit state of models of each language router
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	schemas.RouterHealthListSchema
+//	@Router			/v1/admin/language/ [GET]
+func LangRoutersHealthHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		configuredRouters := routerManager.GetLangRouters()
+		routerHealth := make([]schemas.RouterHealthSchema, 0, len(configuredRouters))
+
+		for _, router := range configuredRouters {
+			routerHealth = append(routerHealth, router.Health())
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.RouterHealthListSchema{Routers: routerHealth})
+	}
+}
+
+// EmbedRoutersHealthHandler
+//
+//	@id				glide-admin-embedding-health
+//	@Summary		Embedding Router Health
+//	@Description	Retrieve health, latency and circuit state of models of each embedding router
+//	@tags			Admin
+//	@Produce		json
+//	@Success		200	{object}	schemas.RouterHealthListSchema
+//	@Router			/v1/admin/embedding/ [GET]
+func EmbedRoutersHealthHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		configuredRouters := routerManager.GetEmbedRouters()
+		routerHealth := make([]schemas.RouterHealthSchema, 0, len(configuredRouters))
+
+		for _, router := range configuredRouters {
+			routerHealth = append(routerHealth, router.Health())
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.RouterHealthListSchema{Routers: routerHealth})
+	}
+}
+
+// LangCircuitHandler
+//
+//	@id				glide-admin-language-circuit
+//	@Summary		Language Model Circuit
+//	@Description	Reset or force-open the circuit breaker of the language model
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Param			model	path	string	true	"Model ID"
+//	@Param			action	path	string	true	"Circuit Action"	Enums(reset, open)
+//	@Produce		json
+//	@Success		200	{object}	schemas.CircuitSchema
+//	@Security		AdminAPIKey
+//	@Failure		400	{object}	schemas.Error
+//	@Failure		401	{object}	schemas.Error
+//	@Failure		404	{object}	schemas.Error
+//	@Router			/v1/admin/language/{router}/models/{model}/circuit/{action} [POST]
+func LangCircuitHandler(routerManager *routers.RouterManager) Handler {
+	return circuitHandler(func(routerID string, modelID string) (*health.CircuitBreaker, error) {
+		router, err := routerManager.GetLangRouter(routerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return router.Circuit(modelID)
+	})
+}
+
+// EmbedCircuitHandler
+//
+//	@id				glide-admin-embedding-circuit
+//	@Summary		Embedding Model Circuit
+//	@Description	Reset or force-open the circuit breaker of the embedding model
+//	@tags			Admin
+//	@Param			router	path	string	true	"Router ID"
+//	@Param			model	path	string	true	"Model ID"
+//	@Param			action	path	string	true	"Circuit Action"	Enums(reset, open)
+//	@Produce		json
+//	@Success		200	{object}	schemas.CircuitSchema
+//	@Security		AdminAPIKey
+//	@Failure		400	{object}	schemas.Error
+//	@Failure		401	{object}	schemas.Error
+//	@Failure		404	{object}	schemas.Error
+//	@Router			/v1/admin/embedding/{router}/models/{model}/circuit/{action} [POST]
+func EmbedCircuitHandler(routerManager *routers.RouterManager) Handler {
+	return circuitHandler(func(routerID string, modelID string) (*health.CircuitBreaker, error) {
+		router, err := routerManager.GetEmbedRouter(routerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return router.Circuit(modelID)
+	})
+}
+
+func circuitHandler(find findCircuit) Handler {
+	return func(c *fiber.Ctx) error {
+		routerID, modelID := c.Params("router"), c.Params("model")
+
+		circuit, err := find(routerID, modelID)
+		if err != nil {
+			httpErr := schemas.FromErr(err)
+
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		switch c.Params("action") {
+		case ResetCircuit:
+			circuit.Reset()
+		case OpenCircuit:
+			circuit.ForceOpen()
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.ErrUnknownCircuitAction)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.CircuitSchema{
+			RouterID:  routerID,
+			ModelID:   modelID,
+			State:     circuit.State(),
+			OpenUntil: circuit.OpenUntil(),
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/EinStack/glide/pkg/config/fields"
 	"github.com/EinStack/glide/pkg/version"
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,6 +16,15 @@ type ServerConfig struct {
 	WriteTimeout       *time.Duration `yaml:"write_timeout"`
 	IdleTimeout        *time.Duration `yaml:"idle_timeout"`
 	MaxRequestBodySize *int           `yaml:"max_request_body_size"`
+	Admin              AdminConfig    `yaml:"admin"`
+}
+
+// AdminConfig defines the admin API that manages models at runtime (e.g. forces model circuits open).
+//
+//	It's disabled by default as it can take models offline
+type AdminConfig struct {
+	Enabled bool          `yaml:"enabled"`
+	APIKey  fields.Secret `yaml:"api_key" json:"-" validate:"required_if=Enabled true"` // passed as a bearer token
 }
 
 func DefaultServerConfig() *ServerConfig {
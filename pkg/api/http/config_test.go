@@ -11,4 +11,5 @@ func TestHTTPConfig_DefaultConfig(t *testing.T) {
 
 	require.NotNil(t, config.Address())
 	require.NotNil(t, config.ToServer())
+	require.False(t, config.Admin.Enabled)
 }
@@ -1,19 +1,29 @@
 package http
 
 import (
+	"bufio"
 	"context"
 	"sync"
 
 	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/cache"
 	"github.com/EinStack/glide/pkg/routers"
 	"github.com/EinStack/glide/pkg/telemetry"
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
 	"go.uber.org/zap"
 )
 
 type Handler = func(c *fiber.Ctx) error
 
+var openAIStreamDoneMarker = []byte("[DONE]")
+
+// openAIStreamRecovery ends OpenAI-compatible streams on model failures once clients have received any chunks,
+// as the OpenAI streaming protocol has no way to tell that the answer is restarted or continued by another model
+var openAIStreamRecovery = &routers.StreamRecoveryConfig{Mode: routers.NoRecovery}
+
 // Swagger 101:
 // - https://github.com/swaggo/swag/tree/master/example/celler
 
@@ -60,7 +70,7 @@ func LangChatHandler(routerManager *routers.RouterManager) Handler {
 		resp := schemas.GetChatResponse()
 		defer schemas.ReleaseChatResponse(resp)
 
-		resp, err = router.Chat(c.Context(), req)
+		resp, err = router.Chat(withCacheControl(c.Context(), c.Get), req)
 		if err != nil {
 			httpErr := schemas.FromErr(err)
 
@@ -121,6 +131,7 @@ func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.Rout
 		chatStreamC := make(chan *schemas.ChatStreamMessage)
 
 		router, _ := routerManager.GetLangRouter(routerID)
+		ctx := withCacheControl(context.Background(), c.Headers)
 
 		defer close(chatStreamC)
 		defer c.Conn.Close()
@@ -157,7 +168,7 @@ func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.Rout
 			go func(chatRequest schemas.ChatStreamRequest) {
 				defer wg.Done()
 
-				router.ChatStream(context.Background(), &chatRequest, chatStreamC)
+				router.ChatStream(ctx, &chatRequest, chatStreamC)
 			}(chatRequest)
 		}
 
@@ -165,6 +176,280 @@ func LangStreamChatHandler(tel *telemetry.Telemetry, routerManager *routers.Rout
 	})
 }
 
+// LangStreamChatSSEHandler
+//
+//	@id				glide-language-chat-stream-sse
+//	@Summary		Language Chat Stream (SSE)
+//	@Description	Talk to different LLM Stream Chat APIs via a unified endpoint that streams chat chunks back as Server-Sent Events.
+//	@Description	Each chunk is sent as a "chunk" event, errors are sent as "error" events and the stream is terminated by an "end" event that carries the finish reason.
+//	@Description	If the answer is restarted by a fallback model, a "stream_restarted" event is sent before its first chunk, so chunks received this far should be discarded.
+//	@tags			Language
+//	@Param			router	path	string						true	"Router ID"
+//	@Param			payload	body	schemas.ChatStreamRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		text/event-stream
+//	@Success		200	{object}	schemas.ChatStreamMessage
+//	@Failure		400	{object}	schemas.Error
+//	@Failure		404	{object}	schemas.Error
+//	@Router			/v1/language/{router}/chatStream [POST]
+func LangStreamChatSSEHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.ErrUnsupportedMediaType)
+		}
+
+		var chatRequest schemas.ChatStreamRequest
+
+		err := c.BodyParser(&chatRequest)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.NewPayloadParseErr(err))
+		}
+
+		if chatRequest.ChatRequest == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.ErrNoChatMessage)
+		}
+
+		if len(chatRequest.ID) == 0 {
+			// unlike websocket connections, each HTTP request serves exactly one stream,
+			//  so the request ID is optional here
+			chatRequest.ID = uuid.NewString()
+		}
+
+		routerID := c.Params("router")
+
+		router, err := routerManager.GetLangRouter(routerID)
+		if err != nil {
+			httpErr := schemas.FromErr(err)
+
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		setSSEHeaders(c)
+
+		// request headers are not available once the body is being streamed
+		streamCtx := withCacheControl(context.Background(), c.Get)
+
+		c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+			ctx, cancel := context.WithCancel(streamCtx)
+			defer cancel()
+
+			chatStreamC := make(chan *schemas.ChatStreamMessage)
+
+			go func() {
+				defer close(chatStreamC)
+
+				router.ChatStream(ctx, &chatRequest, chatStreamC)
+			}()
+
+			var (
+				finishReason *schemas.FinishReason
+				writeErr     error
+			)
+
+			for chatStreamMsg := range chatStreamC {
+				if writeErr != nil {
+					// the client is gone, just drain the rest of the stream
+					continue
+				}
+
+				event := schemas.ChunkEvent
+
+				switch {
+				case chatStreamMsg.Error != nil:
+					event = schemas.ErrorEvent
+					finishReason = chatStreamMsg.Error.FinishReason
+
+					if finishReason == nil {
+						finishReason = &schemas.ReasonError
+					}
+				case chatStreamMsg.Restart != nil:
+					event = schemas.StreamRestartedEvent
+				case chatStreamMsg.Chunk != nil && chatStreamMsg.Chunk.FinishReason != nil:
+					finishReason = chatStreamMsg.Chunk.FinishReason
+				}
+
+				if writeErr = writeSSEvent(w, event, chatStreamMsg); writeErr != nil {
+					tel.L().Debug(
+						"Streaming chat connection is closed by client",
+						zap.Error(writeErr),
+						zap.String("routerID", routerID),
+					)
+
+					cancel()
+				}
+			}
+
+			if writeErr != nil {
+				return
+			}
+
+			endMsg := schemas.NewChatStreamEnd(chatRequest.ID, routerID, chatRequest.Metadata, finishReason)
+
+			if err := writeSSEvent(w, schemas.EndEvent, endMsg); err != nil {
+				tel.L().Debug(
+					"Failed to send the end of the chat stream",
+					zap.Error(err),
+					zap.String("routerID", routerID),
+				)
+			}
+		}))
+
+		return nil
+	}
+}
+
+// OpenAIChatHandler
+//
+//	@id				glide-openai-chat-completions
+//	@Summary		OpenAI-compatible Chat
+//	@Description	Talk to different LLM Chat APIs via the OpenAI Chat Completions compatible endpoint.
+//	@Description	The model field should reference a router ID or a router & model ID pair separated by slash (e.g. "myrouter/mymodel").
+//	@Description	Streaming responses are sent as Server-Sent Events when the stream field is set.
+//	@tags			OpenAI
+//	@Param			payload	body	schemas.OpenAIChatRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.OpenAIChatCompletion
+//	@Failure		400	{object}	schemas.OpenAIError
+//	@Failure		404	{object}	schemas.OpenAIError
+//	@Router			/v1/chat/completions [POST]
+func OpenAIChatHandler(tel *telemetry.Telemetry, routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return openAIErrResponse(c, &schemas.ErrUnsupportedMediaType)
+		}
+
+		var chatRequest schemas.OpenAIChatRequest
+
+		err := c.BodyParser(&chatRequest)
+		if err != nil {
+			payloadErr := schemas.NewPayloadParseErr(err)
+
+			return openAIErrResponse(c, &payloadErr)
+		}
+
+		if len(chatRequest.Messages) == 0 {
+			return openAIErrResponse(c, &schemas.ErrNoChatMessage)
+		}
+
+		routerID, modelID := chatRequest.RouterModel()
+
+		router, err := routerManager.GetLangRouter(routerID)
+		if err != nil {
+			return openAIErrResponse(c, err)
+		}
+
+		if len(modelID) > 0 {
+			router, err = router.WithModel(modelID)
+			if err != nil {
+				return openAIErrResponse(c, err)
+			}
+		}
+
+		if chatRequest.Stream {
+			return openAIChatStream(c, tel, router, chatRequest.ChatStreamRequest(uuid.NewString()))
+		}
+
+		resp, err := router.Chat(withCacheControl(c.Context(), c.Get), chatRequest.ChatRequest())
+		if err != nil {
+			return openAIErrResponse(c, err)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.NewOpenAIChatCompletion(resp))
+	}
+}
+
+func openAIChatStream(
+	c *fiber.Ctx,
+	tel *telemetry.Telemetry,
+	router *routers.LangRouter,
+	chatRequest *schemas.ChatStreamRequest,
+) error {
+	setSSEHeaders(c)
+
+	// request headers are not available once the body is being streamed
+	streamCtx := withCacheControl(context.Background(), c.Get)
+
+	streamRouter := router.WithStreamRecovery(openAIStreamRecovery)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(streamCtx)
+		defer cancel()
+
+		chatStreamC := make(chan *schemas.ChatStreamMessage)
+
+		go func() {
+			defer close(chatStreamC)
+
+			streamRouter.ChatStream(ctx, chatRequest, chatStreamC)
+		}()
+
+		var (
+			writeErr  error
+			restarted bool
+		)
+
+		for chatStreamMsg := range chatStreamC {
+			if writeErr != nil || restarted {
+				// the client is gone or the stream is over, just drain the rest of the stream
+				continue
+			}
+
+			switch {
+			case chatStreamMsg.Restart != nil:
+				// restarts are not expected without stream recovery, but if it happens,
+				//  the stream is ended rather than mixing the new answer into the chunks received this far
+				restarted = true
+				writeErr = writeSSEvent(w, "", schemas.NewOpenAIError(schemas.ModelUnavailable, "the answer has been restarted"))
+
+				cancel()
+			case chatStreamMsg.Error != nil && chatStreamMsg.Error.FinishReason == nil:
+				// the router is going to serve the stream by another model, while OpenAI SDKs abort on any error event
+				tel.L().Debug(
+					"Model has failed to serve OpenAI-compatible chat stream, falling back",
+					zap.String("routerID", router.ID()),
+					zap.String("errName", chatStreamMsg.Error.Name),
+					zap.String("errMessage", chatStreamMsg.Error.Message),
+				)
+			case chatStreamMsg.Error != nil:
+				writeErr = writeSSEvent(w, "", schemas.NewOpenAIError(chatStreamMsg.Error.Name, chatStreamMsg.Error.Message))
+			default:
+				writeErr = writeSSEvent(w, "", schemas.NewOpenAIChatCompletionChunk(chatStreamMsg))
+			}
+
+			if writeErr != nil {
+				tel.L().Debug(
+					"OpenAI-compatible chat stream is closed by client",
+					zap.Error(writeErr),
+					zap.String("routerID", router.ID()),
+				)
+
+				cancel()
+			}
+		}
+
+		if writeErr != nil {
+			return
+		}
+
+		if err := writeRawSSEvent(w, "", openAIStreamDoneMarker); err != nil {
+			tel.L().Debug(
+				"Failed to send the end of the OpenAI-compatible chat stream",
+				zap.Error(err),
+				zap.String("routerID", router.ID()),
+			)
+		}
+	}))
+
+	return nil
+}
+
+func openAIErrResponse(c *fiber.Ctx, err error) error {
+	httpErr := schemas.FromErr(err)
+
+	return c.Status(httpErr.Status).JSON(schemas.NewOpenAIError(httpErr.Name, httpErr.Message))
+}
+
 // LangRoutersHandler
 //
 //	@id				glide-language-routers
@@ -188,18 +473,106 @@ func LangRoutersHandler(routerManager *routers.RouterManager) Handler {
 	}
 }
 
+// EmbedHandler
+//
+//	@id				glide-embedding-embed
+//	@Summary		Embedding
+//	@Description	Embed texts with different embedding model APIs via unified endpoint
+//	@tags			Embedding
+//	@Param			router	path	string					true	"Router ID"
+//	@Param			payload	body	schemas.EmbedRequest	true	"Request Data"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.EmbedResponse
+//	@Failure		400	{object}	schemas.Error
+//	@Failure		404	{object}	schemas.Error
+//	@Router			/v1/embedding/{router}/embed [POST]
+func EmbedHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		if !c.Is("json") {
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.ErrUnsupportedMediaType)
+		}
+
+		var req schemas.EmbedRequest
+
+		err := c.BodyParser(&req)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(schemas.NewPayloadParseErr(err))
+		}
+
+		router, err := routerManager.GetEmbedRouter(c.Params("router"))
+		if err != nil {
+			httpErr := schemas.FromErr(err)
+
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		resp, err := router.Embed(c.Context(), &req)
+		if err != nil {
+			httpErr := schemas.FromErr(err)
+
+			return c.Status(httpErr.Status).JSON(httpErr)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(resp)
+	}
+}
+
+// EmbedRoutersHandler
+//
+//	@id				glide-embedding-routers
+//	@Summary		Embedding Router List
+//	@Description	Retrieve list of configured active embedding routers and their configurations
+//	@tags			Embedding
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	schemas.RouterListSchema
+//	@Router			/v1/embedding/ [GET]
+func EmbedRoutersHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		configuredRouters := routerManager.GetEmbedRouters()
+		cfgs := make([]interface{}, 0, len(configuredRouters)) // opaque by design
+
+		for _, router := range configuredRouters {
+			cfgs = append(cfgs, router.Config)
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.RouterListSchema{Routers: cfgs})
+	}
+}
+
+// withCacheControl passes cache bypass headers of the request down to routers
+func withCacheControl(ctx context.Context, header func(key string, defaultValue ...string) string) context.Context {
+	return cache.WithControl(ctx, cache.ParseControl(header(cache.CacheControlHeader), header(cache.BypassHeader)))
+}
+
 // HealthHandler
 //
 //	@id			glide-health
 //	@Summary	Gateway Health
-//	@Description
+//	@Description	Check the gateway is up. The status is degraded if any router has no healthy models
 //	@tags		Operations
 //	@Accept		json
 //	@Produce	json
 //	@Success	200	{object}	schemas.HealthSchema
 //	@Router		/v1/health/ [get]
-func HealthHandler(c *fiber.Ctx) error {
-	return c.Status(fiber.StatusOK).JSON(schemas.HealthSchema{Healthy: true})
+func HealthHandler(routerManager *routers.RouterManager) Handler {
+	return func(c *fiber.Ctx) error {
+		// the gateway is still up when some routers are degraded, so it's not restarted by orchestrators,
+		//  but the status lets the ops know something is wrong
+		degradedRouters := routerManager.DegradedRouters()
+
+		status := schemas.HealthStatusOK
+		if len(degradedRouters) > 0 {
+			status = schemas.HealthStatusDegraded
+		}
+
+		return c.Status(fiber.StatusOK).JSON(schemas.HealthSchema{
+			Healthy:         true,
+			Status:          status,
+			DegradedRouters: degradedRouters,
+		})
+	}
 }
 
 func NotFoundHandler(c *fiber.Ctx) error {
@@ -0,0 +1,524 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/cache"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/openai"
+	"github.com/EinStack/glide/pkg/routers"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newOpenAIRouterManager(t *testing.T, baseURL string) *routers.RouterManager {
+	t.Helper()
+
+	modelCfg := providers.DefaultLangModelConfig()
+	modelCfg.ID = "openai"
+	modelCfg.OpenAI = openai.DefaultConfig()
+	modelCfg.OpenAI.BaseURL = baseURL
+
+	routerCfg := routers.DefaultLangRouterConfig()
+	routerCfg.ID = "myrouter"
+	routerCfg.Models = []providers.LangModelConfig{*modelCfg}
+
+	manager, err := routers.NewManager(
+		&routers.Config{LanguageRouters: []routers.LangRouterConfig{routerCfg}},
+		telemetry.NewTelemetryMock(),
+	)
+	require.NoError(t, err)
+
+	return manager
+}
+
+func newOpenAIServer(t *testing.T, respFile string, contentType string) *httptest.Server {
+	t.Helper()
+
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean(respFile))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", contentType)
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	return httptest.NewServer(openAIMock)
+}
+
+func TestLangStreamChatSSEHandler_StreamChunks(t *testing.T) {
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/chat_stream.success.txt", "text/event-stream")
+	defer openAIServer.Close()
+
+	tel := telemetry.NewTelemetryMock()
+	manager := newOpenAIRouterManager(t, openAIServer.URL)
+
+	app := fiber.New()
+	app.Post("/v1/language/:router/chatStream", LangStreamChatSSEHandler(tel, manager))
+
+	reqBody := `{"message": {"role": "user", "content": "What's the capital of the United Kingdom?"}}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/language/myrouter/chatStream", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get(fiber.HeaderContentType))
+
+	rawBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	body := string(rawBody)
+
+	require.Contains(t, body, "event: chunk\n")
+	require.NotContains(t, body, "event: error\n")
+	require.True(t, strings.HasSuffix(body, "\n\n"))
+
+	events := strings.Split(strings.TrimSpace(body), "\n\n")
+	lastEvent := events[len(events)-1]
+
+	require.True(t, strings.HasPrefix(lastEvent, "event: end\n"))
+	require.Contains(t, lastEvent, `"finish_reason":"complete"`)
+}
+
+func TestLangStreamChatSSEHandler_RouterNotFound(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Post("/v1/language/:router/chatStream", LangStreamChatSSEHandler(tel, manager))
+
+	reqBody := `{"message": {"role": "user", "content": "Hello"}}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/language/unknown/chatStream", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestOpenAIChatHandler_Chat(t *testing.T) {
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/chat.success.json", "application/json")
+	defer openAIServer.Close()
+
+	tel := telemetry.NewTelemetryMock()
+	manager := newOpenAIRouterManager(t, openAIServer.URL)
+
+	app := fiber.New()
+	app.Post("/v1/chat/completions", OpenAIChatHandler(tel, manager))
+
+	tests := map[string]string{
+		"router":       "myrouter",
+		"router/model": "myrouter/openai",
+	}
+
+	for name, model := range tests {
+		t.Run(name, func(t *testing.T) {
+			reqBody := `{"model": "` + model + `", "messages": [
+				{"role": "system", "content": "You are a helpful assistant"},
+				{"role": "user", "content": "Hello"}
+			]}`
+			req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewBufferString(reqBody))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req, -1)
+			require.NoError(t, err)
+			require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+			var completion schemas.OpenAIChatCompletion
+
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&completion))
+
+			require.Equal(t, "chat.completion", completion.Object)
+			require.Len(t, completion.Choices, 1)
+			require.Equal(t, "assistant", completion.Choices[0].Message.Role)
+			require.Equal(t, "stop", completion.Choices[0].FinishReason)
+			require.Equal(t, 21, completion.Usage.TotalTokens)
+		})
+	}
+}
+
+func TestOpenAIChatHandler_ModelNotFound(t *testing.T) {
+	tel := telemetry.NewTelemetryMock()
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Post("/v1/chat/completions", OpenAIChatHandler(tel, manager))
+
+	reqBody := `{"model": "myrouter/unknown", "messages": [{"role": "user", "content": "Hello"}]}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	var openAIErr schemas.OpenAIError
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&openAIErr))
+	require.Equal(t, schemas.ModelNotFound, openAIErr.Error.Type)
+}
+
+func TestOpenAIChatHandler_ChatStream(t *testing.T) {
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/chat_stream.success.txt", "text/event-stream")
+	defer openAIServer.Close()
+
+	tel := telemetry.NewTelemetryMock()
+	manager := newOpenAIRouterManager(t, openAIServer.URL)
+
+	app := fiber.New()
+	app.Post("/v1/chat/completions", OpenAIChatHandler(tel, manager))
+
+	reqBody := `{"model": "myrouter", "stream": true, "messages": [{"role": "user", "content": "Hello"}]}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	rawBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSpace(string(rawBody)), "\n\n")
+	require.Greater(t, len(events), 1)
+	require.Equal(t, "data: [DONE]", events[len(events)-1])
+
+	var chunk schemas.OpenAIChatCompletionChunk
+
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(events[0], "data: ")), &chunk))
+	require.Equal(t, "chat.completion.chunk", chunk.Object)
+	require.True(t, strings.HasPrefix(chunk.ID, "chatcmpl-"))
+}
+
+func TestOpenAIChatHandler_ChatStreamFallback(t *testing.T) {
+	// the stream of the first model is disconnected before the first chunk
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer failingServer.Close()
+
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/chat_stream.success.txt", "text/event-stream")
+	defer openAIServer.Close()
+
+	failingModelCfg := providers.DefaultLangModelConfig()
+	failingModelCfg.ID = "failing"
+	failingModelCfg.OpenAI = openai.DefaultConfig()
+	failingModelCfg.OpenAI.BaseURL = failingServer.URL
+
+	modelCfg := providers.DefaultLangModelConfig()
+	modelCfg.ID = "openai"
+	modelCfg.OpenAI = openai.DefaultConfig()
+	modelCfg.OpenAI.BaseURL = openAIServer.URL
+
+	routerCfg := routers.DefaultLangRouterConfig()
+	routerCfg.ID = "myrouter"
+	routerCfg.Models = []providers.LangModelConfig{*failingModelCfg, *modelCfg}
+
+	tel := telemetry.NewTelemetryMock()
+
+	manager, err := routers.NewManager(&routers.Config{LanguageRouters: []routers.LangRouterConfig{routerCfg}}, tel)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Post("/v1/chat/completions", OpenAIChatHandler(tel, manager))
+
+	reqBody := `{"model": "myrouter", "stream": true, "messages": [{"role": "user", "content": "Hello"}]}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	rawBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	events := strings.Split(strings.TrimSpace(string(rawBody)), "\n\n")
+	require.Greater(t, len(events), 1)
+	require.Equal(t, "data: [DONE]", events[len(events)-1])
+
+	// OpenAI SDKs abort on error events, so the failure of the first model is not shown to them
+	for _, event := range events[:len(events)-1] {
+		var chunk schemas.OpenAIChatCompletionChunk
+
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(event, "data: ")), &chunk))
+		require.Equal(t, "chat.completion.chunk", chunk.Object)
+	}
+}
+
+func newOpenAIEmbedRouterManager(t *testing.T, baseURL string) *routers.RouterManager {
+	t.Helper()
+
+	langModelCfg := providers.DefaultLangModelConfig()
+	langModelCfg.ID = "openai"
+	langModelCfg.OpenAI = openai.DefaultConfig()
+
+	langRouterCfg := routers.DefaultLangRouterConfig()
+	langRouterCfg.ID = "myrouter"
+	langRouterCfg.Models = []providers.LangModelConfig{*langModelCfg}
+
+	embedModelCfg := providers.DefaultEmbedModelConfig()
+	embedModelCfg.ID = "openai"
+	embedModelCfg.Dimensions = 3
+	embedModelCfg.OpenAI = openai.DefaultConfig()
+	embedModelCfg.OpenAI.BaseURL = baseURL
+
+	embedRouterCfg := routers.DefaultEmbedRouterConfig()
+	embedRouterCfg.ID = "myembedrouter"
+	embedRouterCfg.Models = []providers.EmbedModelConfig{*embedModelCfg}
+
+	manager, err := routers.NewManager(
+		&routers.Config{
+			LanguageRouters:  []routers.LangRouterConfig{langRouterCfg},
+			EmbeddingRouters: []routers.EmbedRouterConfig{embedRouterCfg},
+		},
+		telemetry.NewTelemetryMock(),
+	)
+	require.NoError(t, err)
+
+	return manager
+}
+
+func TestEmbedHandler_Embed(t *testing.T) {
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/embed.success.json", "application/json")
+	defer openAIServer.Close()
+
+	manager := newOpenAIEmbedRouterManager(t, openAIServer.URL)
+
+	app := fiber.New()
+	app.Post("/v1/embedding/:router/embed", EmbedHandler(manager))
+
+	reqBody := `{"input": ["hello", "goodbye"]}`
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/embedding/myembedrouter/embed", bytes.NewBufferString(reqBody))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var embedResp schemas.EmbedResponse
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&embedResp))
+
+	require.Equal(t, "myembedrouter", embedResp.RouterID)
+	require.Equal(t, "openai", embedResp.ModelID)
+	require.Equal(t, 3, embedResp.Dimensions)
+	require.Len(t, embedResp.Embeddings, 2)
+}
+
+func TestEmbedHandler_NoInput(t *testing.T) {
+	manager := newOpenAIEmbedRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Post("/v1/embedding/:router/embed", EmbedHandler(manager))
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/embedding/myembedrouter/embed", bytes.NewBufferString(`{"input": []}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var apiErr schemas.Error
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&apiErr))
+	require.Equal(t, schemas.NoEmbedInput, apiErr.Name)
+}
+
+func TestLangChatHandler_CacheBypass(t *testing.T) {
+	openAIServer := newOpenAIServer(t, "../../providers/openai/testdata/chat.success.json", "application/json")
+	defer openAIServer.Close()
+
+	modelCfg := providers.DefaultLangModelConfig()
+	modelCfg.ID = "openai"
+	modelCfg.OpenAI = openai.DefaultConfig()
+	modelCfg.OpenAI.BaseURL = openAIServer.URL
+
+	routerCfg := routers.DefaultLangRouterConfig()
+	routerCfg.ID = "myrouter"
+	routerCfg.Models = []providers.LangModelConfig{*modelCfg}
+	routerCfg.Cache = cache.DefaultConfig()
+
+	manager, err := routers.NewManager(
+		&routers.Config{LanguageRouters: []routers.LangRouterConfig{routerCfg}},
+		telemetry.NewTelemetryMock(),
+	)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Post("/v1/language/:router/chat", LangChatHandler(manager))
+
+	chat := func(cacheControl string) *schemas.ChatResponse {
+		reqBody := `{"message": {"role": "user", "content": "Hello"}}`
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/language/myrouter/chat", bytes.NewBufferString(reqBody))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set(cache.CacheControlHeader, cacheControl)
+
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var chatResp schemas.ChatResponse
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&chatResp))
+
+		return &chatResp
+	}
+
+	require.False(t, chat("").Cached)
+	require.True(t, chat("").Cached)
+	require.False(t, chat("no-cache").Cached)
+}
+
+func TestLangCircuitHandler_ForceOpenAndReset(t *testing.T) {
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Post("/v1/admin/language/:router/models/:model/circuit/:action", LangCircuitHandler(manager))
+
+	steps := []struct {
+		action        string
+		expectedState string
+	}{
+		{action: "open", expectedState: "open"},
+		{action: "reset", expectedState: "closed"},
+	}
+
+	for _, step := range steps {
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/admin/language/myrouter/models/openai/circuit/"+step.action, nil)
+
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var circuit schemas.CircuitSchema
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&circuit))
+		require.Equal(t, step.expectedState, circuit.State)
+		require.Nil(t, circuit.OpenUntil)
+	}
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/admin/language/myrouter/models/unknown/circuit/open", nil)
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	req = httptest.NewRequest(fiber.MethodPost, "/v1/admin/language/myrouter/models/openai/circuit/close", nil)
+
+	resp, err = app.Test(req, -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Post(
+		"/v1/admin/language/:router/models/:model/circuit/:action",
+		AdminAuthMiddleware("admin-key"),
+		LangCircuitHandler(manager),
+	)
+
+	tokens := map[string]int{
+		"":                 fiber.StatusUnauthorized,
+		"admin-key":        fiber.StatusUnauthorized,
+		"Bearer wrong-key": fiber.StatusUnauthorized,
+		"Bearer admin-key": fiber.StatusOK,
+	}
+
+	for token, expectedStatus := range tokens {
+		req := httptest.NewRequest(fiber.MethodPost, "/v1/admin/language/myrouter/models/openai/circuit/open", nil)
+		req.Header.Set(fiber.HeaderAuthorization, token)
+
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		require.Equal(t, expectedStatus, resp.StatusCode, token)
+	}
+}
+
+func TestHealthHandler_Degraded(t *testing.T) {
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Get("/v1/health/", HealthHandler(manager))
+
+	checkHealth := func() schemas.HealthSchema {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/health/", nil), -1)
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var health schemas.HealthSchema
+
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+
+		return health
+	}
+
+	health := checkHealth()
+	require.True(t, health.Healthy)
+	require.Equal(t, schemas.HealthStatusOK, health.Status)
+
+	router, err := manager.GetLangRouter("myrouter")
+	require.NoError(t, err)
+
+	circuit, err := router.Circuit("openai")
+	require.NoError(t, err)
+
+	circuit.ForceOpen()
+
+	health = checkHealth()
+	require.True(t, health.Healthy)
+	require.Equal(t, schemas.HealthStatusDegraded, health.Status)
+	require.Equal(t, []string{"myrouter"}, health.DegradedRouters)
+}
+
+func TestLangRoutersHealthHandler(t *testing.T) {
+	manager := newOpenAIRouterManager(t, "http://localhost")
+
+	app := fiber.New()
+	app.Get("/v1/admin/language/", LangRoutersHealthHandler(manager))
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/v1/admin/language/", nil), -1)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var routerList schemas.RouterHealthListSchema
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&routerList))
+	require.Len(t, routerList.Routers, 1)
+
+	router := routerList.Routers[0]
+	require.Equal(t, "myrouter", router.RouterID)
+	require.True(t, router.Healthy)
+	require.Len(t, router.Models, 1)
+
+	model := router.Models[0]
+	require.Equal(t, "openai", model.ModelID)
+	require.Equal(t, "openai", model.Provider)
+	require.True(t, model.Healthy)
+	require.Equal(t, "closed", model.CircuitState)
+	require.InDelta(t, 10.0, model.ErrorBudgetTokens, 0.1)
+	require.NotNil(t, model.ChatLatency)
+	require.False(t, model.ChatLatency.WarmedUp)
+	require.Nil(t, model.EmbedLatency)
+}
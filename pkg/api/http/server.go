@@ -62,10 +62,29 @@ func (srv *Server) Run() error {
 	v1.Get("/language/", LangRoutersHandler(srv.routerManager))
 	v1.Post("/language/:router/chat/", LangChatHandler(srv.routerManager))
 
-	v1.Use("/language/:router/chatStream", LangStreamRouterValidator(srv.routerManager))
-	v1.Get("/language/:router/chatStream", LangStreamChatHandler(srv.telemetry, srv.routerManager))
+	v1.Get(
+		"/language/:router/chatStream",
+		LangStreamRouterValidator(srv.routerManager),
+		LangStreamChatHandler(srv.telemetry, srv.routerManager),
+	)
+	v1.Post("/language/:router/chatStream", LangStreamChatSSEHandler(srv.telemetry, srv.routerManager))
+
+	v1.Post("/chat/completions", OpenAIChatHandler(srv.telemetry, srv.routerManager))
+
+	v1.Get("/embedding/", EmbedRoutersHandler(srv.routerManager))
+	v1.Post("/embedding/:router/embed", EmbedHandler(srv.routerManager))
+
+	v1.Get("/admin/language/", LangRoutersHealthHandler(srv.routerManager))
+	v1.Get("/admin/embedding/", EmbedRoutersHealthHandler(srv.routerManager))
+
+	if srv.config.Admin.Enabled {
+		adminAuth := AdminAuthMiddleware(srv.config.Admin.APIKey)
+
+		v1.Post("/admin/language/:router/models/:model/circuit/:action", adminAuth, LangCircuitHandler(srv.routerManager))
+		v1.Post("/admin/embedding/:router/models/:model/circuit/:action", adminAuth, EmbedCircuitHandler(srv.routerManager))
+	}
 
-	v1.Get("/health/", HealthHandler)
+	v1.Get("/health/", HealthHandler(srv.routerManager))
 
 	srv.server.Use(NotFoundHandler)
 
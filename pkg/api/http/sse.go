@@ -0,0 +1,44 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setSSEHeaders prepares the response to be streamed as Server-Sent Events
+func setSSEHeaders(c *fiber.Ctx) {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set(fiber.HeaderTransferEncoding, "chunked")
+	c.Set("X-Accel-Buffering", "no") // prevents reverse proxies like Nginx from buffering the stream
+}
+
+// writeSSEvent serializes the given payload and writes it as a Server-Sent Event.
+//
+//	The event type is omitted when empty. The writer is flushed, so the event is delivered to the client right away
+func writeSSEvent(w *bufio.Writer, event string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	return writeRawSSEvent(w, event, data)
+}
+
+func writeRawSSEvent(w *bufio.Writer, event string, data []byte) error {
+	if len(event) > 0 {
+		if _, err := fmt.Fprintf(w, "event: %s\n", event); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -2,9 +2,15 @@ package schemas
 
 // ChatRequest defines Glide's Chat Request Schema unified across all language models
 type ChatRequest struct {
-	Message        ChatMessage                     `json:"message" validate:"required"`
-	MessageHistory []ChatMessage                   `json:"message_history,omitempty"`
-	OverrideParams *map[string]ModelParamsOverride `json:"override_params,omitempty"`
+	Message        ChatMessage   `json:"message" validate:"required"`
+	MessageHistory []ChatMessage `json:"message_history,omitempty"`
+	Tools          []Tool        `json:"tools,omitempty" validate:"omitempty,dive"`
+	ToolChoice     *ToolChoice   `json:"tool_choice,omitempty" swaggertype:"string"`
+	// ResponseFormat makes the model answer in JSON, optionally conforming to a JSON Schema
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	// GenerationParams are merged over the default params of the model picked to serve the request
+	GenerationParams *GenerationParams               `json:"params,omitempty"`
+	OverrideParams   *map[string]ModelParamsOverride `json:"override_params,omitempty"`
 }
 
 func (r *ChatRequest) ModelParams(modelNameOrID string) *ModelParamsOverride {
@@ -25,32 +31,49 @@ func (r *ChatRequest) ModelParams(modelNameOrID string) *ModelParamsOverride {
 //	The override is going to be applied if Glide picks the referenced there (it may pick another model to serve a given request)
 type ModelParamsOverride struct {
 	// TODO: should be just string?
-	Message ChatMessage `json:"message,omitempty"`
-	// TODO(185): Add an ability to override model params
+	Message          ChatMessage       `json:"message,omitempty"`
+	GenerationParams *GenerationParams `json:"params,omitempty"`
 }
 
 // ChatParams represents a chat request params that overrides the default model params from configs
 type ChatParams struct {
-	Messages []ChatMessage
-	// TODO(185): set other params
+	Messages   []ChatMessage
+	Tools      []Tool
+	ToolChoice *ToolChoice
+	// ResponseFormat is a JSON format the model must answer in, if requested
+	ResponseFormat *ResponseFormat
+	// GenerationParams are the request generation params with model-specific overrides applied
+	GenerationParams GenerationParams
 }
 
 // Params returns a specific chat request params account for model-specific overrides.
 func (r *ChatRequest) Params(modelID string, modelName string) *ChatParams {
 	params := &ChatParams{
-		Messages: make([]ChatMessage, 0, len(r.MessageHistory)+1),
+		Messages:       make([]ChatMessage, 0, len(r.MessageHistory)+1),
+		Tools:          r.Tools,
+		ToolChoice:     r.ToolChoice,
+		ResponseFormat: r.ResponseFormat,
 	}
 
 	reqMessage := r.Message
+	generationParams := r.GenerationParams
 
-	if override := r.ModelParams(modelName); override != nil {
-		// TODO(185): set other params
-		reqMessage = override.Message
+	// model ID overrides are more specific, so they take precedence over model name ones
+	for _, modelNameOrID := range []string{modelName, modelID} {
+		override := r.ModelParams(modelNameOrID)
+		if override == nil {
+			continue
+		}
+
+		if !override.Message.IsEmpty() {
+			reqMessage = override.Message
+		}
+
+		generationParams = generationParams.Merge(override.GenerationParams)
 	}
 
-	if override := r.ModelParams(modelID); override != nil {
-		// TODO(185): set other params
-		reqMessage = override.Message
+	if generationParams != nil {
+		params.GenerationParams = *generationParams
 	}
 
 	params.Messages = append(params.Messages, r.MessageHistory...)
@@ -62,8 +85,8 @@ func (r *ChatRequest) Params(modelID string, modelName string) *ChatParams {
 func NewChatFromStr(message string) *ChatRequest {
 	return &ChatRequest{
 		Message: ChatMessage{
-			"user",
-			message,
+			Role:    "user",
+			Content: message,
 		},
 	}
 }
@@ -78,6 +101,17 @@ type ChatResponse struct {
 	ModelName     string        `json:"model_name"`
 	Cached        bool          `json:"cached"`
 	ModelResponse ModelResponse `json:"model_response"`
+	FinishReason  *FinishReason `json:"finish_reason,omitempty"`
+	// Attempts lists models the router has tried to serve the request with. The last attempt is the one that served it
+	Attempts []ChatAttempt `json:"attempts,omitempty"`
+}
+
+// ChatAttempt describes one try of the router to serve the request by the model
+type ChatAttempt struct {
+	ModelID    string `json:"model_id"`
+	DurationMs int64  `json:"duration_ms"`
+	TimedOut   bool   `json:"timed_out,omitempty"`
+	Error      string `json:"error,omitempty"`
 }
 
 // ModelResponse is the unified response from the provider.
@@ -95,8 +129,30 @@ type TokenUsage struct {
 
 // ChatMessage is a message in a chat request.
 type ChatMessage struct {
-	// The role of the author of this message. One of system, user, or assistant.
+	// The role of the author of this message. One of system, user, assistant, or tool.
 	Role string `json:"role" validate:"required"`
-	// The content of the message.
-	Content string `json:"content" validate:"required"`
+	// The content of the message. It may be empty for assistant messages that only request tool calls.
+	//  For multimodal messages, it holds the text parts of the content
+	Content string `json:"content" validate:"required_without_all=ToolCalls ContentParts"`
+	// The multimodal content of the message (e.g. text and images). It's passed as a list in the content field.
+	ContentParts []ContentPart `json:"-" validate:"omitempty,dive"`
+	// The tool calls requested by the model (assistant messages only).
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// The ID of the tool call this message is a result of (tool messages only).
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// IsMultimodal checks if the message content is a list of parts rather than a plain text
+func (m *ChatMessage) IsMultimodal() bool {
+	return len(m.ContentParts) > 0
+}
+
+// IsEmpty checks if the message has been left unset (e.g. in an override that redefines params only)
+func (m *ChatMessage) IsEmpty() bool {
+	return len(m.Role) == 0 && len(m.Content) == 0 && !m.IsMultimodal() && !m.HasToolCalls()
+}
+
+// HasToolCalls checks if the model requested any tool calls in the message
+func (m *ChatMessage) HasToolCalls() bool {
+	return len(m.ToolCalls) > 0
 }
@@ -12,26 +12,34 @@ var (
 	ReasonComplete        FinishReason = "complete"
 	ReasonMaxTokens       FinishReason = "max_tokens"
 	ReasonContentFiltered FinishReason = "content_filtered"
+	ReasonToolCalls       FinishReason = "tool_calls"
 	ReasonError           FinishReason = "error"
 	ReasonOther           FinishReason = "other"
 )
 
+var (
+	// Server-Sent Event types used to stream chat messages over plain HTTP
+	ChunkEvent           EventType = "chunk"
+	ErrorEvent           EventType = "error"
+	StreamRestartedEvent EventType = "stream_restarted"
+	EndEvent             EventType = "end"
+)
+
 type StreamRequestID = string
 
 // ChatStreamRequest defines a message that requests a new streaming chat
 type ChatStreamRequest struct {
 	ID StreamRequestID `json:"id" validate:"required"`
 	*ChatRequest
-	OverrideParams *map[string]ModelParamsOverride `json:"override_params,omitempty"`
-	Metadata       *Metadata                       `json:"metadata,omitempty"`
+	Metadata *Metadata `json:"metadata,omitempty"`
 }
 
 func NewChatStreamFromStr(message string) *ChatStreamRequest {
 	return &ChatStreamRequest{
 		ChatRequest: &ChatRequest{
 			Message: ChatMessage{
-				"user",
-				message,
+				Role:    "user",
+				Content: message,
 			},
 		},
 	}
@@ -43,12 +51,13 @@ type ModelChunkResponse struct {
 }
 
 type ChatStreamMessage struct {
-	ID        StreamRequestID  `json:"id"`
-	CreatedAt int              `json:"created_at"`
-	RouterID  string           `json:"router_id"`
-	Metadata  *Metadata        `json:"metadata,omitempty"`
-	Chunk     *ChatStreamChunk `json:"chunk,omitempty"`
-	Error     *ChatStreamError `json:"error,omitempty"`
+	ID        StreamRequestID    `json:"id"`
+	CreatedAt int                `json:"created_at"`
+	RouterID  string             `json:"router_id"`
+	Metadata  *Metadata          `json:"metadata,omitempty"`
+	Chunk     *ChatStreamChunk   `json:"chunk,omitempty"`
+	Error     *ChatStreamError   `json:"error,omitempty"`
+	Restart   *ChatStreamRestart `json:"restart,omitempty"`
 }
 
 // ChatStreamChunk defines a message for a chunk of streaming chat response
@@ -67,6 +76,21 @@ type ChatStreamError struct {
 	FinishReason *FinishReason `json:"finish_reason,omitempty"`
 }
 
+// ChatStreamRestart tells that the answer is restarted by another model,
+// so all chunks received this far should be discarded (e.g. removed from UI)
+type ChatStreamRestart struct {
+	Reason string `json:"reason"`
+}
+
+// ChatStreamEnd defines a terminal message of the streaming chat that is sent when the stream is over
+type ChatStreamEnd struct {
+	ID           StreamRequestID `json:"id"`
+	CreatedAt    int             `json:"created_at"`
+	RouterID     string          `json:"router_id"`
+	Metadata     *Metadata       `json:"metadata,omitempty"`
+	FinishReason *FinishReason   `json:"finish_reason,omitempty"`
+}
+
 func NewChatStreamChunk(
 	reqID StreamRequestID,
 	routerID string,
@@ -102,3 +126,35 @@ func NewChatStreamError(
 		},
 	}
 }
+
+func NewChatStreamRestart(
+	reqID StreamRequestID,
+	routerID string,
+	reqMetadata *Metadata,
+	reason string,
+) *ChatStreamMessage {
+	return &ChatStreamMessage{
+		ID:        reqID,
+		RouterID:  routerID,
+		CreatedAt: int(time.Now().UTC().Unix()),
+		Metadata:  reqMetadata,
+		Restart: &ChatStreamRestart{
+			Reason: reason,
+		},
+	}
+}
+
+func NewChatStreamEnd(
+	reqID StreamRequestID,
+	routerID string,
+	reqMetadata *Metadata,
+	finishReason *FinishReason,
+) *ChatStreamEnd {
+	return &ChatStreamEnd{
+		ID:           reqID,
+		RouterID:     routerID,
+		CreatedAt:    int(time.Now().UTC().Unix()),
+		Metadata:     reqMetadata,
+		FinishReason: finishReason,
+	}
+}
@@ -168,3 +168,50 @@ func TestChatRequest_ModelNameIDOverride(t *testing.T) {
 
 	require.Equal(t, []string{backstory, myModelIDMessage}, ToSlice(params.Messages))
 }
+
+// TestChatRequest_GenerationParamsOverride tests that model-specific generation params are merged over request ones
+// and that overrides without a message keep the request message
+func TestChatRequest_GenerationParamsOverride(t *testing.T) {
+	modelID := "my-openai-model"
+	modelName := "gpt-4"
+
+	temperature := 0.2
+	maxTokens := 100
+	modelNameMaxTokens := 200
+	modelIDTemperature := 0.9
+
+	chatReq := ChatRequest{
+		Message: ChatMessage{
+			Role:    "user",
+			Content: "When did I win an ACMP contest?",
+		},
+		GenerationParams: &GenerationParams{
+			Temperature: &temperature,
+			MaxTokens:   &maxTokens,
+			Stop:        StopWords{"\n"},
+		},
+		OverrideParams: &map[string]ModelParamsOverride{
+			modelName: {
+				GenerationParams: &GenerationParams{MaxTokens: &modelNameMaxTokens},
+			},
+			modelID: {
+				GenerationParams: &GenerationParams{Temperature: &modelIDTemperature},
+			},
+		},
+	}
+
+	params := chatReq.Params(modelID, modelName)
+
+	require.Equal(t, []string{"When did I win an ACMP contest?"}, ToSlice(params.Messages))
+	require.Equal(t, modelIDTemperature, *params.GenerationParams.Temperature)
+	require.Equal(t, modelNameMaxTokens, *params.GenerationParams.MaxTokens)
+	require.Equal(t, StopWords{"\n"}, params.GenerationParams.Stop)
+
+	otherParams := chatReq.Params("other-model", "command-r")
+
+	require.Equal(t, temperature, *otherParams.GenerationParams.Temperature)
+	require.Equal(t, maxTokens, *otherParams.GenerationParams.MaxTokens)
+
+	// the request params are not changed by merging
+	require.Equal(t, temperature, *chatReq.GenerationParams.Temperature)
+}
@@ -0,0 +1,222 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Chat messages may carry multimodal content as a list of parts instead of a plain text.
+//
+//	The schema follows OpenAI's one (e.g. text & image_url parts) extended with documents
+//	Ref: https://platform.openai.com/docs/guides/vision
+
+type ContentPartType = string
+
+var (
+	ContentPartText     ContentPartType = "text"
+	ContentPartImageURL ContentPartType = "image_url"
+	ContentPartDocument ContentPartType = "document"
+)
+
+// Modality is a kind of content a model should understand to process the message
+type Modality = string
+
+var (
+	ModalityText     Modality = "text"
+	ModalityImage    Modality = "image"
+	ModalityDocument Modality = "document"
+)
+
+// ContentPart is a part of the multimodal message content
+type ContentPart struct {
+	Type     ContentPartType `json:"type" validate:"required,oneof=text image_url document"`
+	Text     string          `json:"text,omitempty" validate:"required_if=Type text"`
+	ImageURL *ImageURL       `json:"image_url,omitempty" validate:"required_if=Type image_url"`
+	Document *Document       `json:"document,omitempty" validate:"required_if=Type document"`
+}
+
+// Modality returns the modality of the part
+func (p *ContentPart) Modality() Modality {
+	switch p.Type {
+	case ContentPartImageURL:
+		return ModalityImage
+	case ContentPartDocument:
+		return ModalityDocument
+	default:
+		return ModalityText
+	}
+}
+
+// ImageURL references an image by a public URL or embeds it as a base64-encoded data URL (e.g. data:image/png;base64,...)
+type ImageURL struct {
+	URL string `json:"url" validate:"required"`
+	// Detail is the image fidelity hint (low, high or auto) supported by OpenAI-compatible providers
+	Detail string `json:"detail,omitempty"`
+}
+
+// Data returns the media type and the base64-encoded data of the image if it's embedded as a data URL
+func (i *ImageURL) Data() (string, string, bool) {
+	return ParseDataURL(i.URL)
+}
+
+// Document is a file (e.g. PDF) passed as a public URL or as base64-encoded data
+type Document struct {
+	URL       string `json:"url,omitempty" validate:"required_without=Data"`
+	MediaType string `json:"media_type,omitempty" validate:"required_with=Data"`
+	Data      string `json:"data,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ParseDataURL splits base64-encoded data URLs into the media type and the data
+func ParseDataURL(url string) (string, string, bool) {
+	rest, found := strings.CutPrefix(url, "data:")
+	if !found {
+		return "", "", false
+	}
+
+	meta, data, found := strings.Cut(rest, ",")
+	if !found {
+		return "", "", false
+	}
+
+	mediaType, found := strings.CutSuffix(meta, ";base64")
+	if !found {
+		return "", "", false
+	}
+
+	return mediaType, data, true
+}
+
+// TextContent concatenates text parts of the content
+func TextContent(parts []ContentPart) string {
+	var text strings.Builder
+
+	for _, part := range parts {
+		if part.Type != ContentPartText {
+			continue
+		}
+
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+
+		text.WriteString(part.Text)
+	}
+
+	return text.String()
+}
+
+// TextMessages strips multimodal content from messages for providers that accept text only
+func TextMessages(messages []ChatMessage) []ChatMessage {
+	textMessages := make([]ChatMessage, 0, len(messages))
+
+	for _, message := range messages {
+		message.ContentParts = nil
+		textMessages = append(textMessages, message)
+	}
+
+	return textMessages
+}
+
+// chatMessage helps to (de)serialize chat messages without recursion
+type chatMessage ChatMessage
+
+// MarshalJSON encodes the content as a list of parts if it's multimodal and as a string otherwise
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	if len(m.ContentParts) == 0 {
+		return json.Marshal(chatMessage(m))
+	}
+
+	return json.Marshal(struct {
+		chatMessage
+		Content []ContentPart `json:"content"`
+	}{
+		chatMessage: chatMessage(m),
+		Content:     m.ContentParts,
+	})
+}
+
+// UnmarshalJSON accepts the content as a string or a list of parts.
+//
+//	Text parts are also collected into the Content field, so text-only providers could still serve such messages
+func (m *ChatMessage) UnmarshalJSON(data []byte) error {
+	var msg struct {
+		chatMessage
+		Content json.RawMessage `json:"content"`
+	}
+
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return err
+	}
+
+	*m = ChatMessage(msg.chatMessage)
+
+	rawContent := bytes.TrimSpace(msg.Content)
+
+	switch {
+	case len(rawContent) == 0 || bytes.Equal(rawContent, []byte("null")):
+		return nil
+	case rawContent[0] == '[':
+		if err := json.Unmarshal(rawContent, &m.ContentParts); err != nil {
+			return fmt.Errorf("failed to decode content parts: %w", err)
+		}
+
+		m.Content = TextContent(m.ContentParts)
+
+		return nil
+	default:
+		return json.Unmarshal(rawContent, &m.Content)
+	}
+}
+
+// IsMultimodal checks if any message of the request (including model-specific overrides) has multimodal content
+func (r *ChatRequest) IsMultimodal() bool {
+	if r.Message.IsMultimodal() {
+		return true
+	}
+
+	for _, message := range r.MessageHistory {
+		if message.IsMultimodal() {
+			return true
+		}
+	}
+
+	if r.OverrideParams != nil {
+		for _, override := range *r.OverrideParams {
+			if override.Message.IsMultimodal() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Modalities lists modalities of all messages in the chat
+func (p *ChatParams) Modalities() []Modality {
+	modalities := []Modality{ModalityText}
+
+	for _, message := range p.Messages {
+		for _, part := range message.ContentParts {
+			modality := part.Modality()
+
+			if !containsModality(modalities, modality) {
+				modalities = append(modalities, modality)
+			}
+		}
+	}
+
+	return modalities
+}
+
+func containsModality(modalities []Modality, modality Modality) bool {
+	for _, m := range modalities {
+		if m == modality {
+			return true
+		}
+	}
+
+	return false
+}
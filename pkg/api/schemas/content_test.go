@@ -0,0 +1,87 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatMessage_UnmarshalContent(t *testing.T) {
+	tests := map[string]struct {
+		rawMessage   string
+		content      string
+		contentParts []ContentPart
+	}{
+		"text content": {`{"role": "user", "content": "Hi"}`, "Hi", nil},
+		"null content": {`{"role": "assistant", "content": null}`, "", nil},
+		"no content":   {`{"role": "assistant"}`, "", nil},
+		"content parts": {
+			`{"role": "user", "content": [
+				{"type": "text", "text": "What's in the image?"},
+				{"type": "image_url", "image_url": {"url": "https://example.com/cat.png"}}
+			]}`,
+			"What's in the image?",
+			[]ContentPart{
+				{Type: ContentPartText, Text: "What's in the image?"},
+				{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var message ChatMessage
+
+			require.NoError(t, json.Unmarshal([]byte(tt.rawMessage), &message))
+			require.Equal(t, tt.content, message.Content)
+			require.Equal(t, tt.contentParts, message.ContentParts)
+		})
+	}
+}
+
+func TestChatMessage_MarshalContent(t *testing.T) {
+	message := ChatMessage{Role: "user", Content: "Hi"}
+
+	rawMessage, err := json.Marshal(message)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"role": "user", "content": "Hi"}`, string(rawMessage))
+
+	message = ChatMessage{
+		Role:    "user",
+		Content: "Summarize the document",
+		ContentParts: []ContentPart{
+			{Type: ContentPartText, Text: "Summarize the document"},
+			{Type: ContentPartDocument, Document: &Document{MediaType: "application/pdf", Data: "JVBERi0="}},
+		},
+	}
+
+	rawMessage, err = json.Marshal(message)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"role": "user", "content": [
+		{"type": "text", "text": "Summarize the document"},
+		{"type": "document", "document": {"media_type": "application/pdf", "data": "JVBERi0="}}
+	]}`, string(rawMessage))
+}
+
+func TestChatParams_Modalities(t *testing.T) {
+	params := ChatParams{Messages: []ChatMessage{
+		{Role: "user", Content: "Hi"},
+		{Role: "user", ContentParts: []ContentPart{
+			{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}},
+			{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/dog.png"}},
+		}},
+	}}
+
+	require.Equal(t, []Modality{ModalityText, ModalityImage}, params.Modalities())
+}
+
+func TestParseDataURL(t *testing.T) {
+	mediaType, data, ok := ParseDataURL("data:image/png;base64,iVBORw0KGgo=")
+	require.True(t, ok)
+	require.Equal(t, "image/png", mediaType)
+	require.Equal(t, "iVBORw0KGgo=", data)
+
+	_, _, ok = ParseDataURL("https://example.com/cat.png")
+	require.False(t, ok)
+}
@@ -0,0 +1,82 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// EmbedRequest defines Glide's Embedding Request Schema unified across all embedding models
+type EmbedRequest struct {
+	// Input is a text or a list of texts to embed. Long lists are split into batches the model accepts
+	Input EmbedInput `json:"input" validate:"required" swaggertype:"array,string"`
+}
+
+// Validate checks that there is something to embed
+func (r *EmbedRequest) Validate() error {
+	if len(r.Input) == 0 {
+		return &ErrNoEmbedInput
+	}
+
+	for idx, text := range r.Input {
+		if len(strings.TrimSpace(text)) == 0 {
+			return NewInvalidParamsErr(fmt.Errorf("input #%v is empty", idx))
+		}
+	}
+
+	return nil
+}
+
+// Params returns embedding params to pass to the embedding model
+func (r *EmbedRequest) Params() *EmbedParams {
+	return &EmbedParams{
+		Input: r.Input,
+	}
+}
+
+// EmbedInput holds texts to embed. A single text may be passed as a plain string
+type EmbedInput []string
+
+func (i *EmbedInput) UnmarshalJSON(data []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "\"") {
+		var text string
+
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+
+		*i = EmbedInput{text}
+
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(i))
+}
+
+// EmbedParams represents an embedding request params passed to the embedding model
+type EmbedParams struct {
+	Input []string
+}
+
+// EmbedResponse defines Glide's Embedding Response Schema unified across all embedding models
+type EmbedResponse struct {
+	ID         string          `json:"id,omitempty"`
+	Created    int             `json:"created_at"`
+	RouterID   string          `json:"router_id"`
+	ModelID    string          `json:"model_id"`
+	Provider   string          `json:"provider_id"`
+	ModelName  string          `json:"model_name"`
+	Dimensions int             `json:"dimensions"`
+	Embeddings []Embedding     `json:"embeddings"`
+	TokenUsage EmbedTokenUsage `json:"token_usage"`
+}
+
+// Embedding is a vector of the input text referenced by its index in the request
+type Embedding struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+type EmbedTokenUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+}
@@ -12,10 +12,19 @@ var (
 	UnsupportedMediaType ErrorName = "unsupported_media_type"
 	RouteNotFound        ErrorName = "route_not_found"
 	PayloadParseError    ErrorName = "payload_parse_error"
+	NoChatMessage        ErrorName = "no_chat_message"
 	RouterNotFound       ErrorName = "router_not_found"
+	ModelNotFound        ErrorName = "model_not_found"
 	NoModelConfigured    ErrorName = "no_model_configured"
 	ModelUnavailable     ErrorName = "model_unavailable"
 	AllModelsUnavailable ErrorName = "all_models_unavailable"
+	UnsupportedModality  ErrorName = "unsupported_modality"
+	InvalidParams        ErrorName = "invalid_params"
+	NoEmbedInput         ErrorName = "no_embed_input"
+	RequestTimeout       ErrorName = "request_timeout"
+	ModelRequestFailed   ErrorName = "model_request_failed"
+	UnknownCircuitAction ErrorName = "unknown_circuit_action"
+	Unauthorized         ErrorName = "unauthorized"
 	UnknownError         ErrorName = "unknown_error"
 )
 
@@ -50,14 +59,52 @@ var ErrRouteNotFound = NewError(
 	"requested route is not found or method is not allowed",
 )
 
+var ErrNoChatMessage = NewError(
+	fiber.StatusBadRequest,
+	NoChatMessage,
+	"chat request must contain a message",
+)
+
+var ErrNoEmbedInput = NewError(
+	fiber.StatusBadRequest,
+	NoEmbedInput,
+	"embedding request must contain at least one input text",
+)
+
 var ErrRouterNotFound = NewError(fiber.StatusNotFound, RouterNotFound, "router is not found")
 
+var ErrModelNotFound = NewError(fiber.StatusNotFound, ModelNotFound, "model is not found in the router")
+
+var ErrUnknownCircuitAction = NewError(
+	fiber.StatusBadRequest,
+	UnknownCircuitAction,
+	"circuit action is not known, use \"reset\" or \"open\"",
+)
+
+var ErrUnauthorized = NewError(
+	fiber.StatusUnauthorized,
+	Unauthorized,
+	"admin API key is wrong or not set",
+)
+
 var ErrNoModelAvailable = NewError(
 	503,
 	AllModelsUnavailable,
 	"all providers are unavailable",
 )
 
+var ErrRequestTimeout = NewError(
+	fiber.StatusGatewayTimeout,
+	RequestTimeout,
+	"no model has answered within the request deadline",
+)
+
+var ErrUnsupportedModality = NewError(
+	fiber.StatusUnprocessableEntity,
+	UnsupportedModality,
+	"none of the router models supports the content modalities of the request",
+)
+
 func NewPayloadParseErr(err error) Error {
 	return NewError(
 		fiber.StatusBadRequest,
@@ -66,6 +113,24 @@ func NewPayloadParseErr(err error) Error {
 	)
 }
 
+// NewInvalidParamsErr reports generation params that are out of the accepted ranges
+func NewInvalidParamsErr(err error) *Error {
+	return &Error{
+		Status:  fiber.StatusBadRequest,
+		Name:    InvalidParams,
+		Message: err.Error(),
+	}
+}
+
+// NewModelRequestFailedErr reports the model error the router has not tried to recover from
+func NewModelRequestFailedErr(status int, err error) *Error {
+	return &Error{
+		Status:  status,
+		Name:    ModelRequestFailed,
+		Message: err.Error(),
+	}
+}
+
 func FromErr(err error) Error {
 	if apiErr, ok := err.(*Error); ok {
 		return *apiErr
@@ -1,5 +1,67 @@
 package schemas
 
+import "time"
+
+// HealthStatus tells if the gateway is able to serve requests
+type HealthStatus = string
+
+const (
+	HealthStatusOK       HealthStatus = "ok"
+	HealthStatusDegraded HealthStatus = "degraded" // some routers have no healthy models to serve requests
+)
+
 type HealthSchema struct {
-	Healthy bool `json:"healthy"`
+	Healthy bool         `json:"healthy"`
+	Status  HealthStatus `json:"status"`
+	// DegradedRouters are IDs of routers that have no healthy models
+	DegradedRouters []string `json:"degraded_routers,omitempty"`
+}
+
+// CircuitSchema is the circuit breaker state of the router model
+type CircuitSchema struct {
+	RouterID string `json:"router_id"`
+	ModelID  string `json:"model_id"`
+	State    string `json:"state"`
+	// OpenUntil is when the open circuit lets probe requests through. It's not set if the circuit has been forced open
+	OpenUntil *time.Time `json:"open_until,omitempty"`
+}
+
+// LatencySchema is the moving average of the model latency
+type LatencySchema struct {
+	// Value is in nanoseconds (per response token for chat requests, per input for embedding requests)
+	Value float64 `json:"value"`
+	// WarmedUp tells if the model has served enough requests to estimate the latency
+	WarmedUp bool `json:"warmed_up"`
+}
+
+// ModelHealthSchema is the health state of the router model
+type ModelHealthSchema struct {
+	ModelID   string `json:"model_id"`
+	Provider  string `json:"provider"`
+	ModelName string `json:"model_name"`
+	Healthy   bool   `json:"healthy"`
+	// Unauthorized tells that the model has been failing because of the API key since the last successful request
+	Unauthorized     bool       `json:"unauthorized"`
+	RateLimitResetAt *time.Time `json:"rate_limit_reset_at,omitempty"`
+	// ErrorBudgetTokens is the number of errors the model can make before its circuit is opened
+	ErrorBudgetTokens float64    `json:"error_budget_tokens"`
+	CircuitState      string     `json:"circuit_state"`
+	CircuitOpenUntil  *time.Time `json:"circuit_open_until,omitempty"`
+	Timeouts          uint64     `json:"timeouts"`
+	// Latency is set by the type of the model (language or embedding)
+	ChatLatency       *LatencySchema `json:"chat_latency,omitempty"`
+	ChatStreamLatency *LatencySchema `json:"chat_stream_latency,omitempty"`
+	EmbedLatency      *LatencySchema `json:"embed_latency,omitempty"`
+}
+
+// RouterHealthSchema is the health state of the router and its models
+type RouterHealthSchema struct {
+	RouterID string `json:"router_id"`
+	// Healthy tells if the router has at least one healthy model
+	Healthy bool                `json:"healthy"`
+	Models  []ModelHealthSchema `json:"models"`
+}
+
+type RouterHealthListSchema struct {
+	Routers []RouterHealthSchema `json:"routers"`
 }
@@ -0,0 +1,416 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// schemaValidator checks JSON values against a JSON Schema.
+//
+//	It covers the subset of JSON Schema that is used to define structured outputs:
+//	types, enums & consts, object properties, arrays, string & number bounds, combinators and local references
+//	Ref: https://platform.openai.com/docs/guides/structured-outputs/supported-schemas
+type schemaValidator struct {
+	root map[string]any
+}
+
+func newSchemaValidator(schema map[string]any) *schemaValidator {
+	return &schemaValidator{root: normalizeSchema(schema)}
+}
+
+// Validate checks the decoded JSON value against the root schema
+func (v *schemaValidator) Validate(value any) error {
+	return v.validate(v.root, value, "$", nil)
+}
+
+// validate checks the value against the schema.
+//
+//	refs are references followed at the current path. They are tracked to stop on references that cycle
+//	without going down the value (e.g. {"$ref": "#"}), which would make the validation loop forever
+func (v *schemaValidator) validate(schema map[string]any, value any, path string, refs []string) error {
+	if ref, found := schema["$ref"].(string); found {
+		if slices.Contains(refs, ref) {
+			return fmt.Errorf("%s: schema reference %q cycles", path, ref)
+		}
+
+		refSchema, err := v.resolveRef(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		return v.validate(refSchema, value, path, append(refs, ref))
+	}
+
+	if types, found := schema["type"]; found && !matchesType(types, value) {
+		return fmt.Errorf("%s: expected %v, got %s", path, types, jsonType(value))
+	}
+
+	if enum, found := schema["enum"].([]any); found && !containsValue(enum, value) {
+		return fmt.Errorf("%s: value is not one of %v", path, enum)
+	}
+
+	if constant, found := schema["const"]; found && !reflect.DeepEqual(constant, value) {
+		return fmt.Errorf("%s: value must be %v", path, constant)
+	}
+
+	if err := v.validateCombinators(schema, value, path, refs); err != nil {
+		return err
+	}
+
+	switch typedValue := value.(type) {
+	case map[string]any:
+		return v.validateObject(schema, typedValue, path)
+	case []any:
+		return v.validateArray(schema, typedValue, path)
+	case string:
+		return validateString(schema, typedValue, path)
+	case float64:
+		return validateNumber(schema, typedValue, path)
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateCombinators(schema map[string]any, value any, path string, refs []string) error {
+	if allOf, found := schema["allOf"].([]any); found {
+		for _, subSchema := range allOf {
+			if err := v.validate(asSchema(subSchema), value, path, refs); err != nil {
+				return err
+			}
+		}
+	}
+
+	if anyOf, found := schema["anyOf"].([]any); found {
+		matched := false
+
+		for _, subSchema := range anyOf {
+			if v.validate(asSchema(subSchema), value, path, refs) == nil {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return fmt.Errorf("%s: value doesn't match any of the allowed schemas", path)
+		}
+	}
+
+	if oneOf, found := schema["oneOf"].([]any); found {
+		matches := 0
+
+		for _, subSchema := range oneOf {
+			if v.validate(asSchema(subSchema), value, path, refs) == nil {
+				matches++
+			}
+		}
+
+		if matches != 1 {
+			return fmt.Errorf("%s: value must match exactly one schema, matched %v", path, matches)
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateObject(schema map[string]any, object map[string]any, path string) error {
+	if required, found := schema["required"].([]any); found {
+		for _, name := range required {
+			if _, present := object[fmt.Sprint(name)]; !present {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+
+	for name, propValue := range object {
+		propPath := path + "." + name
+
+		if propSchema, found := properties[name]; found {
+			if err := v.validate(asSchema(propSchema), propValue, propPath, nil); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: additional property %q is not allowed", path, name)
+			}
+		case map[string]any:
+			if err := v.validate(additional, propValue, propPath, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateArray(schema map[string]any, array []any, path string) error {
+	if minItems, found := schemaNumber(schema, "minItems"); found && float64(len(array)) < minItems {
+		return fmt.Errorf("%s: expected at least %v items, got %v", path, minItems, len(array))
+	}
+
+	if maxItems, found := schemaNumber(schema, "maxItems"); found && float64(len(array)) > maxItems {
+		return fmt.Errorf("%s: expected at most %v items, got %v", path, maxItems, len(array))
+	}
+
+	itemSchema, found := schema["items"].(map[string]any)
+	if !found {
+		return nil
+	}
+
+	for idx, item := range array {
+		if err := v.validate(itemSchema, item, fmt.Sprintf("%s[%v]", path, idx), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateString(schema map[string]any, value string, path string) error {
+	length := float64(utf8.RuneCountInString(value))
+
+	if minLength, found := schemaNumber(schema, "minLength"); found && length < minLength {
+		return fmt.Errorf("%s: expected at least %v characters", path, minLength)
+	}
+
+	if maxLength, found := schemaNumber(schema, "maxLength"); found && length > maxLength {
+		return fmt.Errorf("%s: expected at most %v characters", path, maxLength)
+	}
+
+	return nil
+}
+
+func validateNumber(schema map[string]any, value float64, path string) error {
+	if minimum, found := schemaNumber(schema, "minimum"); found && value < minimum {
+		return fmt.Errorf("%s: %v is less than the minimum of %v", path, value, minimum)
+	}
+
+	if maximum, found := schemaNumber(schema, "maximum"); found && value > maximum {
+		return fmt.Errorf("%s: %v is greater than the maximum of %v", path, value, maximum)
+	}
+
+	if minimum, found := schemaNumber(schema, "exclusiveMinimum"); found && value <= minimum {
+		return fmt.Errorf("%s: %v must be greater than %v", path, value, minimum)
+	}
+
+	if maximum, found := schemaNumber(schema, "exclusiveMaximum"); found && value >= maximum {
+		return fmt.Errorf("%s: %v must be less than %v", path, value, maximum)
+	}
+
+	return nil
+}
+
+// CheckRefs makes sure all references of the schema can be resolved and
+// none of them cycles without going down the value, so the schema can be used for validation
+func (v *schemaValidator) CheckRefs() error {
+	return v.checkSchemaRefs(v.root, make(map[string]bool))
+}
+
+// checkSchemaRefs checks references of the schema and all its subschemas.
+//
+//	checkedRefs are references known to be fine (true) or being resolved at the moment (false)
+func (v *schemaValidator) checkSchemaRefs(schema map[string]any, checkedRefs map[string]bool) error {
+	if err := v.checkRefChain(schema, checkedRefs); err != nil {
+		return err
+	}
+
+	subSchemas := make([]any, 0)
+
+	for _, keyword := range []string{"properties", "$defs", "definitions"} {
+		if namedSchemas, found := schema[keyword].(map[string]any); found {
+			for _, subSchema := range namedSchemas {
+				subSchemas = append(subSchemas, subSchema)
+			}
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if listedSchemas, found := schema[keyword].([]any); found {
+			subSchemas = append(subSchemas, listedSchemas...)
+		}
+	}
+
+	subSchemas = append(subSchemas, schema["items"], schema["additionalProperties"])
+
+	for _, subSchema := range subSchemas {
+		if nestedSchema, isSchema := subSchema.(map[string]any); isSchema {
+			if err := v.checkSchemaRefs(nestedSchema, checkedRefs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRefChain follows references & combinators of the schema the same way validate() does for one value
+func (v *schemaValidator) checkRefChain(schema map[string]any, checkedRefs map[string]bool) error {
+	if ref, found := schema["$ref"].(string); found {
+		checked, seen := checkedRefs[ref]
+
+		if seen && checked {
+			return nil
+		}
+
+		if seen {
+			return fmt.Errorf("schema reference %q cycles", ref)
+		}
+
+		refSchema, err := v.resolveRef(ref)
+		if err != nil {
+			return err
+		}
+
+		checkedRefs[ref] = false
+
+		if err := v.checkRefChain(refSchema, checkedRefs); err != nil {
+			return err
+		}
+
+		checkedRefs[ref] = true
+
+		return nil
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		subSchemas, _ := schema[keyword].([]any)
+
+		for _, subSchema := range subSchemas {
+			if err := v.checkRefChain(asSchema(subSchema), checkedRefs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveRef looks up local references like #/$defs/step or #/definitions/step
+func (v *schemaValidator) resolveRef(ref string) (map[string]any, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("only local schema references are supported, got %q", ref)
+	}
+
+	var node any = v.root
+
+	for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+
+		object, isObject := node.(map[string]any)
+		if !isObject {
+			return nil, fmt.Errorf("schema reference %q is not found", ref)
+		}
+
+		child, found := object[segment]
+		if !found {
+			return nil, fmt.Errorf("schema reference %q is not found", ref)
+		}
+
+		node = child
+	}
+
+	return asSchema(node), nil
+}
+
+func matchesType(types any, value any) bool {
+	switch typedTypes := types.(type) {
+	case string:
+		return matchesSingleType(typedTypes, value)
+	case []any:
+		for _, schemaType := range typedTypes {
+			if matchesSingleType(fmt.Sprint(schemaType), value) {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(schemaType string, value any) bool {
+	actualType := jsonType(value)
+
+	if schemaType == "integer" {
+		number, isNumber := value.(float64)
+
+		return isNumber && number == math.Trunc(number)
+	}
+
+	return schemaType == actualType
+}
+
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsValue(values []any, value any) bool {
+	for _, allowedValue := range values {
+		if reflect.DeepEqual(allowedValue, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func schemaNumber(schema map[string]any, keyword string) (float64, bool) {
+	number, found := schema[keyword].(float64)
+
+	return number, found
+}
+
+func asSchema(node any) map[string]any {
+	schema, _ := node.(map[string]any)
+
+	return schema
+}
+
+// normalizeSchema brings the schema to the same shape as decoded JSON (e.g. numbers as float64),
+// so schemas defined in YAML configs are validated the same way as the ones coming in requests
+func normalizeSchema(schema map[string]any) map[string]any {
+	rawSchema, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+
+	normalized := make(map[string]any)
+
+	if err := json.Unmarshal(rawSchema, &normalized); err != nil {
+		return schema
+	}
+
+	return normalized
+}
@@ -0,0 +1,203 @@
+package schemas
+
+import (
+	"strings"
+)
+
+// OpenAI-compatible schemas allow to use stock OpenAI SDKs with Glide.
+// Ref: https://platform.openai.com/docs/api-reference/chat
+
+const (
+	openAIChatCompletionObject      = "chat.completion"
+	openAIChatCompletionChunkObject = "chat.completion.chunk"
+	openAIAssistantRole             = "assistant"
+	openAIChatIDPrefix              = "chatcmpl-"
+	routerModelSeparator            = "/"
+)
+
+// OpenAIChatRequest defines a subset of OpenAI Chat Completions request schema that Glide understands.
+//
+//	The model field references a router ID or a pair of router & model IDs separated by slash (e.g. "myrouter/gpt4")
+type OpenAIChatRequest struct {
+	Model      string        `json:"model" validate:"required"`
+	Messages   []ChatMessage `json:"messages" validate:"required,min=1,dive"`
+	Tools      []Tool        `json:"tools,omitempty" validate:"omitempty,dive"`
+	ToolChoice *ToolChoice   `json:"tool_choice,omitempty" swaggertype:"string"`
+	// ResponseFormat may carry Glide's validate flag in addition to OpenAI fields
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	// generation params are named the same way as in OpenAI API
+	GenerationParams
+}
+
+// RouterModel splits the model field into the router ID and an optional model ID
+func (r *OpenAIChatRequest) RouterModel() (string, string) {
+	routerID, modelID, _ := strings.Cut(r.Model, routerModelSeparator)
+
+	return routerID, modelID
+}
+
+// ChatRequest converts the request into Glide's chat request.
+//
+//	The last message is considered to be the request message, all previous ones form the message history
+func (r *OpenAIChatRequest) ChatRequest() *ChatRequest {
+	lastIdx := len(r.Messages) - 1
+
+	chatReq := &ChatRequest{
+		Message:        r.Messages[lastIdx],
+		MessageHistory: r.Messages[:lastIdx],
+		Tools:          r.Tools,
+		ToolChoice:     r.ToolChoice,
+		ResponseFormat: r.ResponseFormat,
+	}
+
+	if len(r.GenerationParams.Names()) > 0 {
+		chatReq.GenerationParams = &r.GenerationParams
+	}
+
+	return chatReq
+}
+
+// ChatStreamRequest converts the request into Glide's streaming chat request.
+//
+//	The request ID is prefixed the same way OpenAI does it for chat completion IDs
+func (r *OpenAIChatRequest) ChatStreamRequest(reqID string) *ChatStreamRequest {
+	return &ChatStreamRequest{
+		ID:          openAIChatIDPrefix + reqID,
+		ChatRequest: r.ChatRequest(),
+	}
+}
+
+// OpenAIChatCompletion is an OpenAI-compatible chat response
+type OpenAIChatCompletion struct {
+	ID      string             `json:"id"`
+	Object  string             `json:"object"`
+	Created int                `json:"created"`
+	Model   string             `json:"model"`
+	Choices []OpenAIChatChoice `json:"choices"`
+	Usage   OpenAIUsage        `json:"usage"`
+}
+
+type OpenAIChatChoice struct {
+	Index        int         `json:"index"`
+	Message      ChatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+type OpenAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+func NewOpenAIChatCompletion(resp *ChatResponse) *OpenAIChatCompletion {
+	tokenUsage := resp.ModelResponse.TokenUsage
+
+	return &OpenAIChatCompletion{
+		ID:      resp.ID,
+		Object:  openAIChatCompletionObject,
+		Created: resp.Created,
+		Model:   resp.ModelName,
+		Choices: []OpenAIChatChoice{
+			{
+				Index: 0,
+				Message: ChatMessage{
+					Role:      openAIAssistantRole,
+					Content:   resp.ModelResponse.Message.Content,
+					ToolCalls: resp.ModelResponse.Message.ToolCalls,
+				},
+				FinishReason: *toOpenAIFinishReason(resp.FinishReason, true),
+			},
+		},
+		Usage: OpenAIUsage{
+			PromptTokens:     tokenUsage.PromptTokens,
+			CompletionTokens: tokenUsage.ResponseTokens,
+			TotalTokens:      tokenUsage.TotalTokens,
+		},
+	}
+}
+
+// OpenAIChatCompletionChunk is an OpenAI-compatible streaming chat chunk
+type OpenAIChatCompletionChunk struct {
+	ID      string                   `json:"id"`
+	Object  string                   `json:"object"`
+	Created int                      `json:"created"`
+	Model   string                   `json:"model"`
+	Choices []OpenAIChatStreamChoice `json:"choices"`
+}
+
+type OpenAIChatStreamChoice struct {
+	Index        int         `json:"index"`
+	Delta        ChatMessage `json:"delta"`
+	FinishReason *string     `json:"finish_reason"`
+}
+
+func NewOpenAIChatCompletionChunk(msg *ChatStreamMessage) *OpenAIChatCompletionChunk {
+	chunk := msg.Chunk
+
+	return &OpenAIChatCompletionChunk{
+		ID:      msg.ID,
+		Object:  openAIChatCompletionChunkObject,
+		Created: msg.CreatedAt,
+		Model:   chunk.ModelName,
+		Choices: []OpenAIChatStreamChoice{
+			{
+				Index: 0,
+				Delta: ChatMessage{
+					Role:      openAIAssistantRole,
+					Content:   chunk.ModelResponse.Message.Content,
+					ToolCalls: chunk.ModelResponse.Message.ToolCalls,
+				},
+				FinishReason: toOpenAIFinishReason(chunk.FinishReason, false),
+			},
+		},
+	}
+}
+
+// OpenAIError is an OpenAI-compatible error response
+type OpenAIError struct {
+	Error OpenAIErrorDetails `json:"error"`
+}
+
+type OpenAIErrorDetails struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+func NewOpenAIError(name ErrorName, message string) *OpenAIError {
+	return &OpenAIError{
+		Error: OpenAIErrorDetails{
+			Message: message,
+			Type:    name,
+			Code:    &name,
+		},
+	}
+}
+
+// toOpenAIFinishReason maps Glide's finish reasons to OpenAI ones.
+//
+//	When the final reason is required, but the provider didn't report it, the response is considered to be complete
+func toOpenAIFinishReason(reason *FinishReason, final bool) *string {
+	openAIReason := "stop"
+
+	if reason == nil {
+		if final {
+			return &openAIReason
+		}
+
+		return nil
+	}
+
+	switch *reason {
+	case ReasonMaxTokens:
+		openAIReason = "length"
+	case ReasonContentFiltered:
+		openAIReason = "content_filter"
+	case ReasonToolCalls:
+		openAIReason = "tool_calls"
+	}
+
+	return &openAIReason
+}
@@ -0,0 +1,282 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GenerationParam names a provider-neutral generation parameter
+type GenerationParam = string
+
+const (
+	ParamTemperature      GenerationParam = "temperature"
+	ParamTopP             GenerationParam = "top_p"
+	ParamTopK             GenerationParam = "top_k"
+	ParamMaxTokens        GenerationParam = "max_tokens"
+	ParamStop             GenerationParam = "stop"
+	ParamSeed             GenerationParam = "seed"
+	ParamFrequencyPenalty GenerationParam = "frequency_penalty"
+	ParamPresencePenalty  GenerationParam = "presence_penalty"
+)
+
+// GenerationParams defines provider-neutral generation params that can be set per request.
+//
+//	Only params that are set are merged over the provider's default params from the config.
+//	Providers ignore params they don't support (e.g. OpenAI doesn't support top_k)
+type GenerationParams struct {
+	Temperature      *float64  `json:"temperature,omitempty"`
+	TopP             *float64  `json:"top_p,omitempty"`
+	TopK             *int      `json:"top_k,omitempty"`
+	MaxTokens        *int      `json:"max_tokens,omitempty"`
+	Stop             StopWords `json:"stop,omitempty" swaggertype:"array,string"`
+	Seed             *int      `json:"seed,omitempty"`
+	FrequencyPenalty *float64  `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64  `json:"presence_penalty,omitempty"`
+}
+
+// Merge returns a copy of the params with the set override params taking precedence
+func (p *GenerationParams) Merge(override *GenerationParams) *GenerationParams {
+	merged := GenerationParams{}
+
+	if p != nil {
+		merged = *p
+	}
+
+	if override == nil {
+		return &merged
+	}
+
+	if override.Temperature != nil {
+		merged.Temperature = override.Temperature
+	}
+
+	if override.TopP != nil {
+		merged.TopP = override.TopP
+	}
+
+	if override.TopK != nil {
+		merged.TopK = override.TopK
+	}
+
+	if override.MaxTokens != nil {
+		merged.MaxTokens = override.MaxTokens
+	}
+
+	if override.Stop != nil {
+		merged.Stop = override.Stop
+	}
+
+	if override.Seed != nil {
+		merged.Seed = override.Seed
+	}
+
+	if override.FrequencyPenalty != nil {
+		merged.FrequencyPenalty = override.FrequencyPenalty
+	}
+
+	if override.PresencePenalty != nil {
+		merged.PresencePenalty = override.PresencePenalty
+	}
+
+	return &merged
+}
+
+// Names lists params that are set
+func (p *GenerationParams) Names() []GenerationParam {
+	if p == nil {
+		return nil
+	}
+
+	names := make([]GenerationParam, 0, 8)
+
+	if p.Temperature != nil {
+		names = append(names, ParamTemperature)
+	}
+
+	if p.TopP != nil {
+		names = append(names, ParamTopP)
+	}
+
+	if p.TopK != nil {
+		names = append(names, ParamTopK)
+	}
+
+	if p.MaxTokens != nil {
+		names = append(names, ParamMaxTokens)
+	}
+
+	if p.Stop != nil {
+		names = append(names, ParamStop)
+	}
+
+	if p.Seed != nil {
+		names = append(names, ParamSeed)
+	}
+
+	if p.FrequencyPenalty != nil {
+		names = append(names, ParamFrequencyPenalty)
+	}
+
+	if p.PresencePenalty != nil {
+		names = append(names, ParamPresencePenalty)
+	}
+
+	return names
+}
+
+// Validate checks that set params are in ranges accepted by providers
+func (p *GenerationParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Temperature != nil && (*p.Temperature < 0 || *p.Temperature > 2) {
+		return fmt.Errorf("%s must be between 0 and 2, got %v", ParamTemperature, *p.Temperature)
+	}
+
+	if p.TopP != nil && (*p.TopP < 0 || *p.TopP > 1) {
+		return fmt.Errorf("%s must be between 0 and 1, got %v", ParamTopP, *p.TopP)
+	}
+
+	if p.TopK != nil && *p.TopK < 1 {
+		return fmt.Errorf("%s must be positive, got %v", ParamTopK, *p.TopK)
+	}
+
+	if p.MaxTokens != nil && *p.MaxTokens < 1 {
+		return fmt.Errorf("%s must be positive, got %v", ParamMaxTokens, *p.MaxTokens)
+	}
+
+	for _, stopWord := range p.Stop {
+		if len(stopWord) == 0 {
+			return fmt.Errorf("%s must not contain empty sequences", ParamStop)
+		}
+	}
+
+	if p.FrequencyPenalty != nil && (*p.FrequencyPenalty < -2 || *p.FrequencyPenalty > 2) {
+		return fmt.Errorf("%s must be between -2 and 2, got %v", ParamFrequencyPenalty, *p.FrequencyPenalty)
+	}
+
+	if p.PresencePenalty != nil && (*p.PresencePenalty < -2 || *p.PresencePenalty > 2) {
+		return fmt.Errorf("%s must be between -2 and 2, got %v", ParamPresencePenalty, *p.PresencePenalty)
+	}
+
+	return nil
+}
+
+// Unsupported lists set params that are not accepted by the given check
+func (p *GenerationParams) Unsupported(supported func(GenerationParam) bool) []GenerationParam {
+	unsupported := make([]GenerationParam, 0)
+
+	for _, name := range p.Names() {
+		if !supported(name) {
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	return unsupported
+}
+
+// ParamFields points to fields of the provider request that generation params are copied to.
+//
+//	Fields of params the provider doesn't support are left nil
+type ParamFields struct {
+	Temperature      **float64
+	TopP             **float64
+	TopK             *int
+	MaxTokens        *int
+	Stop             *[]string
+	Seed             **int
+	FrequencyPenalty **float64
+	PresencePenalty  **float64
+}
+
+// ApplyTo copies the set params to the provider request fields, so they take precedence over the model defaults.
+//
+//	Float params are kept as pointers, so explicit zeros (e.g. temperature: 0) are sent to the provider
+func (p *GenerationParams) ApplyTo(fields ParamFields) {
+	if p.Temperature != nil && fields.Temperature != nil {
+		*fields.Temperature = p.Temperature
+	}
+
+	if p.TopP != nil && fields.TopP != nil {
+		*fields.TopP = p.TopP
+	}
+
+	if p.TopK != nil && fields.TopK != nil {
+		*fields.TopK = *p.TopK
+	}
+
+	if p.MaxTokens != nil && fields.MaxTokens != nil {
+		*fields.MaxTokens = *p.MaxTokens
+	}
+
+	if p.Stop != nil && fields.Stop != nil {
+		*fields.Stop = p.Stop
+	}
+
+	if p.Seed != nil && fields.Seed != nil {
+		*fields.Seed = p.Seed
+	}
+
+	if p.FrequencyPenalty != nil && fields.FrequencyPenalty != nil {
+		*fields.FrequencyPenalty = p.FrequencyPenalty
+	}
+
+	if p.PresencePenalty != nil && fields.PresencePenalty != nil {
+		*fields.PresencePenalty = p.PresencePenalty
+	}
+}
+
+// DefaultParam turns the param value from the model config into the request field.
+//
+//	Zero values mean the param is not configured, so the provider default is used
+func DefaultParam[T int | float64](value T) *T {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
+
+// StopWords holds stop sequences. A single sequence may be passed as a plain string like OpenAI API allows
+type StopWords []string
+
+func (s *StopWords) UnmarshalJSON(data []byte) error {
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "\"") {
+		var stopWord string
+
+		if err := json.Unmarshal(data, &stopWord); err != nil {
+			return err
+		}
+
+		*s = StopWords{stopWord}
+
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(s))
+}
+
+// ValidateParams checks generation params and the response format of the request and all its model-specific overrides
+func (r *ChatRequest) ValidateParams() error {
+	if err := r.GenerationParams.Validate(); err != nil {
+		return NewInvalidParamsErr(err)
+	}
+
+	if err := r.ResponseFormat.Check(); err != nil {
+		return NewInvalidParamsErr(err)
+	}
+
+	if r.OverrideParams == nil {
+		return nil
+	}
+
+	for modelNameOrID, override := range *r.OverrideParams {
+		if err := override.GenerationParams.Validate(); err != nil {
+			return NewInvalidParamsErr(fmt.Errorf("override for %q: %w", modelNameOrID, err))
+		}
+	}
+
+	return nil
+}
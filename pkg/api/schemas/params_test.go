@@ -0,0 +1,165 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerationParams_Merge(t *testing.T) {
+	temperature := 0.5
+	topK := 40
+	overrideTemperature := 1.0
+
+	params := &GenerationParams{Temperature: &temperature, TopK: &topK}
+	merged := params.Merge(&GenerationParams{Temperature: &overrideTemperature})
+
+	require.Equal(t, overrideTemperature, *merged.Temperature)
+	require.Equal(t, topK, *merged.TopK)
+	require.Nil(t, merged.MaxTokens)
+
+	var noParams *GenerationParams
+
+	require.Equal(t, &GenerationParams{Temperature: &overrideTemperature}, noParams.Merge(&GenerationParams{Temperature: &overrideTemperature}))
+	require.Equal(t, params, params.Merge(nil))
+}
+
+func TestGenerationParams_ApplyTo(t *testing.T) {
+	temperature := 0.0
+	maxTokens := 64
+	defaultTopP := 0.9
+
+	var (
+		reqTemperature *float64
+		reqTopP        = &defaultTopP
+		reqMaxTokens   int
+		reqStop        = []string{"END"}
+	)
+
+	params := &GenerationParams{Temperature: &temperature, MaxTokens: &maxTokens, Seed: &maxTokens}
+
+	params.ApplyTo(ParamFields{
+		Temperature: &reqTemperature,
+		TopP:        &reqTopP,
+		MaxTokens:   &reqMaxTokens,
+		Stop:        &reqStop,
+	})
+
+	require.NotNil(t, reqTemperature)
+	require.Equal(t, 0.0, *reqTemperature)
+	require.Equal(t, defaultTopP, *reqTopP)
+	require.Equal(t, maxTokens, reqMaxTokens)
+	require.Equal(t, []string{"END"}, reqStop)
+
+	require.Nil(t, DefaultParam(0.0))
+	require.Equal(t, 0.7, *DefaultParam(0.7))
+}
+
+func TestGenerationParams_Validate(t *testing.T) {
+	tests := map[string]struct {
+		params string
+		valid  bool
+	}{
+		"empty":                 {params: `{}`, valid: true},
+		"all in range":          {params: `{"temperature": 2, "top_p": 0, "top_k": 1, "max_tokens": 10, "stop": ["\n"], "seed": 42, "frequency_penalty": -2, "presence_penalty": 2}`, valid: true},
+		"temperature too high":  {params: `{"temperature": 2.1}`},
+		"negative temperature":  {params: `{"temperature": -0.1}`},
+		"top_p too high":        {params: `{"top_p": 1.5}`},
+		"zero top_k":            {params: `{"top_k": 0}`},
+		"zero max tokens":       {params: `{"max_tokens": 0}`},
+		"empty stop sequence":   {params: `{"stop": [""]}`},
+		"penalty out of range":  {params: `{"frequency_penalty": 3}`},
+		"presence out of range": {params: `{"presence_penalty": -2.5}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var params GenerationParams
+
+			require.NoError(t, json.Unmarshal([]byte(test.params), &params))
+
+			if test.valid {
+				require.NoError(t, params.Validate())
+			} else {
+				require.Error(t, params.Validate())
+			}
+		})
+	}
+}
+
+func TestGenerationParams_StopAsString(t *testing.T) {
+	var params GenerationParams
+
+	require.NoError(t, json.Unmarshal([]byte(`{"stop": "END"}`), &params))
+	require.Equal(t, StopWords{"END"}, params.Stop)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"stop": ["END", "STOP"]}`), &params))
+	require.Equal(t, StopWords{"END", "STOP"}, params.Stop)
+}
+
+func TestGenerationParams_Unsupported(t *testing.T) {
+	temperature := 0.5
+	topK := 40
+
+	params := &GenerationParams{Temperature: &temperature, TopK: &topK}
+
+	unsupported := params.Unsupported(func(param GenerationParam) bool {
+		return param != ParamTopK
+	})
+
+	require.Equal(t, []GenerationParam{ParamTopK}, unsupported)
+}
+
+func TestChatRequest_ValidateParams(t *testing.T) {
+	var chatReq ChatRequest
+
+	err := json.Unmarshal([]byte(`{
+		"message": {"role": "user", "content": "Hello"},
+		"params": {"temperature": 0.7},
+		"override_params": {"gpt-4": {"params": {"top_p": 2}}}
+	}`), &chatReq)
+	require.NoError(t, err)
+
+	err = chatReq.ValidateParams()
+	require.Error(t, err)
+
+	apiErr := FromErr(err)
+	require.Equal(t, InvalidParams, apiErr.Name)
+	require.Contains(t, apiErr.Message, "gpt-4")
+}
+
+func TestChatStreamRequest_OverrideParams(t *testing.T) {
+	var streamReq ChatStreamRequest
+
+	err := json.Unmarshal([]byte(`{
+		"id": "stream-1",
+		"message": {"role": "user", "content": "Hello"},
+		"override_params": {"gpt-4": {"message": {"role": "user", "content": "Hi"}, "params": {"max_tokens": 10}}}
+	}`), &streamReq)
+	require.NoError(t, err)
+
+	params := streamReq.Params("my-model", "gpt-4")
+
+	require.Equal(t, "Hi", params.Messages[0].Content)
+	require.Equal(t, 10, *params.GenerationParams.MaxTokens)
+}
+
+func TestOpenAIChatRequest_GenerationParams(t *testing.T) {
+	var openAIReq OpenAIChatRequest
+
+	err := json.Unmarshal([]byte(`{
+		"model": "myrouter",
+		"messages": [{"role": "user", "content": "Hello"}],
+		"temperature": 0.3,
+		"max_tokens": 64,
+		"stop": "END"
+	}`), &openAIReq)
+	require.NoError(t, err)
+
+	chatReq := openAIReq.ChatRequest()
+
+	require.Equal(t, 0.3, *chatReq.GenerationParams.Temperature)
+	require.Equal(t, 64, *chatReq.GenerationParams.MaxTokens)
+	require.Equal(t, StopWords{"END"}, chatReq.GenerationParams.Stop)
+}
@@ -0,0 +1,162 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Response format makes models answer in JSON, optionally conforming to a given JSON Schema.
+//
+//	The schema follows OpenAI's one. Providers that support JSON output natively get the format passed in their own way,
+//	others are instructed to follow the format via a system message
+//	Ref: https://platform.openai.com/docs/guides/structured-outputs
+
+type ResponseFormatType = string
+
+var (
+	ResponseFormatText       ResponseFormatType = "text"
+	ResponseFormatJSONObject ResponseFormatType = "json_object"
+	ResponseFormatJSONSchema ResponseFormatType = "json_schema"
+)
+
+var (
+	ErrInvalidResponseFormat  = errors.New("invalid response format")
+	ErrResponseFormatMismatch = errors.New("model response doesn't match the requested format")
+)
+
+// ResponseFormat defines the format the model must answer in
+type ResponseFormat struct {
+	Type       ResponseFormatType `json:"type" yaml:"type" validate:"required,oneof=text json_object json_schema"`
+	JSONSchema *JSONSchemaFormat  `json:"json_schema,omitempty" yaml:"json_schema,omitempty"`
+	// Validate makes Glide check the model response against the format before returning it.
+	//  Responses that don't match the format are treated as model failures, so the next model is tried.
+	//  Streaming chat responses are not validated
+	Validate bool `json:"validate,omitempty" yaml:"validate,omitempty"`
+}
+
+// JSONSchemaFormat describes the JSON Schema the model response must conform to
+type JSONSchemaFormat struct {
+	Name        string         `json:"name" yaml:"name" validate:"required"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      map[string]any `json:"schema,omitempty" yaml:"schema,omitempty" swaggertype:"object"`
+	Strict      *bool          `json:"strict,omitempty" yaml:"strict,omitempty"`
+}
+
+// IsJSON checks if the format requests a JSON response
+func (f *ResponseFormat) IsJSON() bool {
+	return f != nil && (f.Type == ResponseFormatJSONObject || f.Type == ResponseFormatJSONSchema)
+}
+
+// HasSchema checks if the format requests a JSON response conforming to a schema
+func (f *ResponseFormat) HasSchema() bool {
+	return f != nil && f.Type == ResponseFormatJSONSchema && f.JSONSchema != nil && len(f.JSONSchema.Schema) > 0
+}
+
+// Check validates the format itself
+func (f *ResponseFormat) Check() error {
+	if f == nil {
+		return nil
+	}
+
+	switch f.Type {
+	case ResponseFormatText, ResponseFormatJSONObject:
+		return nil
+	case ResponseFormatJSONSchema:
+		if f.JSONSchema == nil || len(f.JSONSchema.Name) == 0 {
+			return fmt.Errorf("%w: json_schema format requires a named schema", ErrInvalidResponseFormat)
+		}
+
+		if !f.HasSchema() {
+			return nil
+		}
+
+		if err := newSchemaValidator(f.JSONSchema.Schema).CheckRefs(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidResponseFormat, err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidResponseFormat, f.Type)
+	}
+}
+
+// Instruction renders a system prompt that asks the model to follow the format.
+//
+//	It's used to emulate the format for providers that don't support it natively
+func (f *ResponseFormat) Instruction() string {
+	if !f.IsJSON() {
+		return ""
+	}
+
+	if !f.HasSchema() {
+		return "Respond with a valid JSON object only. Do not wrap it in a code block or add any text around it."
+	}
+
+	var instruction strings.Builder
+
+	rawSchema, _ := json.Marshal(f.JSONSchema.Schema)
+
+	instruction.WriteString("Respond with a valid JSON object only. Do not wrap it in a code block or add any text around it.\n")
+	instruction.WriteString("The JSON object must conform to the following JSON Schema")
+
+	if len(f.JSONSchema.Description) > 0 {
+		instruction.WriteString(" (" + f.JSONSchema.Description + ")")
+	}
+
+	instruction.WriteString(":\n")
+	instruction.Write(rawSchema)
+
+	return instruction.String()
+}
+
+// ValidateContent checks if the model response content matches the format
+func (f *ResponseFormat) ValidateContent(content string) error {
+	if !f.IsJSON() {
+		return nil
+	}
+
+	var value any
+
+	if err := json.Unmarshal([]byte(content), &value); err != nil {
+		return fmt.Errorf("%w: response is not a valid JSON: %v", ErrResponseFormatMismatch, err)
+	}
+
+	if _, isObject := value.(map[string]any); !isObject {
+		return fmt.Errorf("%w: response is not a JSON object", ErrResponseFormatMismatch)
+	}
+
+	if !f.HasSchema() {
+		return nil
+	}
+
+	if err := newSchemaValidator(f.JSONSchema.Schema).Validate(value); err != nil {
+		return fmt.Errorf("%w: %v", ErrResponseFormatMismatch, err)
+	}
+
+	return nil
+}
+
+// WithFormatInstruction adds a system message with the format instruction to the messages.
+//
+//	The instruction goes after the leading system messages, so it complements the user-defined system prompt
+func WithFormatInstruction(messages []ChatMessage, format *ResponseFormat) []ChatMessage {
+	instruction := format.Instruction()
+	if len(instruction) == 0 {
+		return messages
+	}
+
+	insertIdx := 0
+
+	for insertIdx < len(messages) && messages[insertIdx].Role == "system" {
+		insertIdx++
+	}
+
+	formattedMessages := make([]ChatMessage, 0, len(messages)+1)
+	formattedMessages = append(formattedMessages, messages[:insertIdx]...)
+	formattedMessages = append(formattedMessages, ChatMessage{Role: "system", Content: instruction})
+	formattedMessages = append(formattedMessages, messages[insertIdx:]...)
+
+	return formattedMessages
+}
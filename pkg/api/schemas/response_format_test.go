@@ -0,0 +1,154 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var weatherSchema = `{
+	"type": "object",
+	"properties": {
+		"city": {"type": "string", "minLength": 1},
+		"temperature": {"type": "number", "minimum": -100, "maximum": 100},
+		"unit": {"type": "string", "enum": ["celsius", "fahrenheit"]},
+		"days": {"type": "array", "items": {"$ref": "#/$defs/day"}, "maxItems": 2}
+	},
+	"required": ["city", "temperature", "unit"],
+	"additionalProperties": false,
+	"$defs": {
+		"day": {
+			"type": "object",
+			"properties": {"weekday": {"type": "integer"}, "summary": {"type": ["string", "null"]}},
+			"required": ["weekday"]
+		}
+	}
+}`
+
+func newWeatherFormat(t *testing.T) *ResponseFormat {
+	var schema map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(weatherSchema), &schema))
+
+	return &ResponseFormat{
+		Type:       ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchemaFormat{Name: "weather", Schema: schema},
+		Validate:   true,
+	}
+}
+
+func TestResponseFormat_Check(t *testing.T) {
+	require.NoError(t, (&ResponseFormat{Type: ResponseFormatJSONObject}).Check())
+	require.NoError(t, newWeatherFormat(t).Check())
+
+	require.ErrorIs(t, (&ResponseFormat{Type: ResponseFormatJSONSchema}).Check(), ErrInvalidResponseFormat)
+	require.ErrorIs(t, (&ResponseFormat{Type: "xml"}).Check(), ErrInvalidResponseFormat)
+}
+
+func TestResponseFormat_ValidateContent(t *testing.T) {
+	format := newWeatherFormat(t)
+
+	tests := map[string]struct {
+		content string
+		valid   bool
+	}{
+		"valid":                  {content: `{"city": "London", "temperature": 12.5, "unit": "celsius"}`, valid: true},
+		"valid with refs":        {content: `{"city": "London", "temperature": 12, "unit": "celsius", "days": [{"weekday": 1, "summary": null}]}`, valid: true},
+		"not a json":             {content: "The weather in London is 12C"},
+		"not an object":          {content: `["London"]`},
+		"missing required":       {content: `{"city": "London", "unit": "celsius"}`},
+		"wrong type":             {content: `{"city": "London", "temperature": "12", "unit": "celsius"}`},
+		"not in enum":            {content: `{"city": "London", "temperature": 12, "unit": "kelvin"}`},
+		"additional property":    {content: `{"city": "London", "temperature": 12, "unit": "celsius", "wind": 3}`},
+		"out of range":           {content: `{"city": "London", "temperature": 120, "unit": "celsius"}`},
+		"too short":              {content: `{"city": "", "temperature": 12, "unit": "celsius"}`},
+		"ref type mismatch":      {content: `{"city": "London", "temperature": 12, "unit": "celsius", "days": [{"weekday": 1.5}]}`},
+		"too many items":         {content: `{"city": "London", "temperature": 12, "unit": "celsius", "days": [{"weekday": 1}, {"weekday": 2}, {"weekday": 3}]}`},
+		"wrong type of nullable": {content: `{"city": "London", "temperature": 12, "unit": "celsius", "days": [{"weekday": 1, "summary": 1}]}`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := format.ValidateContent(test.content)
+
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrResponseFormatMismatch)
+			}
+		})
+	}
+}
+
+func newSchemaFormat(t *testing.T, rawSchema string) *ResponseFormat {
+	var schema map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(rawSchema), &schema))
+
+	return &ResponseFormat{
+		Type:       ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchemaFormat{Name: "test", Schema: schema},
+		Validate:   true,
+	}
+}
+
+func TestResponseFormat_SelfReferencingSchema(t *testing.T) {
+	tests := map[string]string{
+		"self reference":       `{"$ref": "#"}`,
+		"reference cycle":      `{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}}`,
+		"combinator cycle":     `{"anyOf": [{"type": "string"}, {"$ref": "#"}]}`,
+		"nested cycle":         `{"type": "object", "properties": {"node": {"$ref": "#/$defs/node"}}, "$defs": {"node": {"allOf": [{"$ref": "#/$defs/node"}]}}}`,
+		"unresolved reference": `{"type": "object", "properties": {"node": {"$ref": "#/$defs/missing"}}}`,
+	}
+
+	for name, rawSchema := range tests {
+		t.Run(name, func(t *testing.T) {
+			format := newSchemaFormat(t, rawSchema)
+
+			require.ErrorIs(t, format.Check(), ErrInvalidResponseFormat)
+			require.ErrorIs(t, format.ValidateContent(`{"node": {"node": {}}}`), ErrResponseFormatMismatch)
+		})
+	}
+}
+
+func TestResponseFormat_RecursiveSchema(t *testing.T) {
+	format := newSchemaFormat(t, `{
+		"type": "object",
+		"properties": {"name": {"type": "string"}, "children": {"type": "array", "items": {"$ref": "#"}}},
+		"required": ["name"]
+	}`)
+
+	require.NoError(t, format.Check())
+	require.NoError(t, format.ValidateContent(`{"name": "root", "children": [{"name": "leaf", "children": []}]}`))
+	require.ErrorIs(t, format.ValidateContent(`{"name": "root", "children": [{"children": []}]}`), ErrResponseFormatMismatch)
+}
+
+func TestResponseFormat_ValidateJSONObject(t *testing.T) {
+	format := &ResponseFormat{Type: ResponseFormatJSONObject}
+
+	require.NoError(t, format.ValidateContent(`{"answer": 42}`))
+	require.ErrorIs(t, format.ValidateContent("42"), ErrResponseFormatMismatch)
+
+	textFormat := &ResponseFormat{Type: ResponseFormatText}
+
+	require.NoError(t, textFormat.ValidateContent("42"))
+}
+
+func TestResponseFormat_WithFormatInstruction(t *testing.T) {
+	messages := []ChatMessage{
+		{Role: "system", Content: "You are a weather bot"},
+		{Role: "user", Content: "What's the weather in London?"},
+	}
+
+	formattedMessages := WithFormatInstruction(messages, newWeatherFormat(t))
+
+	require.Len(t, formattedMessages, 3)
+	require.Equal(t, "You are a weather bot", formattedMessages[0].Content)
+	require.Equal(t, "system", formattedMessages[1].Role)
+	require.Contains(t, formattedMessages[1].Content, `"required":["city","temperature","unit"]`)
+	require.Equal(t, "What's the weather in London?", formattedMessages[2].Content)
+
+	require.Equal(t, messages, WithFormatInstruction(messages, nil))
+	require.Equal(t, messages, WithFormatInstruction(messages, &ResponseFormat{Type: ResponseFormatText}))
+}
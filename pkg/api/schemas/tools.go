@@ -0,0 +1,123 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Tool calling lets models request calls of functions defined by the client.
+//
+//	The schema follows OpenAI's one as the most widespread, so providers translate it into their own formats
+//	Ref: https://platform.openai.com/docs/guides/function-calling
+
+type ToolType = string
+
+var ToolTypeFunction ToolType = "function"
+
+// Tool defines a tool the model may call
+type Tool struct {
+	Type     ToolType     `json:"type" validate:"required,oneof=function"`
+	Function ToolFunction `json:"function" validate:"required"`
+}
+
+// ToolFunction describes a function the model may call
+type ToolFunction struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+	// Parameters is a JSON Schema object describing function arguments
+	Parameters map[string]any `json:"parameters,omitempty" swaggertype:"object"`
+}
+
+// ToolCall is a call of a tool requested by the model.
+//
+//	On chat streaming, tool calls come in deltas that are referenced by the index.
+//	Only the first delta of a tool call carries its ID and function name, the following ones carry chunks of arguments
+type ToolCall struct {
+	Index    *int             `json:"index,omitempty"`
+	ID       string           `json:"id,omitempty"`
+	Type     ToolType         `json:"type,omitempty"`
+	Function ToolCallFunction `json:"function"`
+}
+
+type ToolCallFunction struct {
+	Name string `json:"name,omitempty"`
+	// Arguments is a JSON-encoded object of function arguments
+	Arguments string `json:"arguments"`
+}
+
+// ArgumentsMap decodes function arguments
+func (f *ToolCallFunction) ArgumentsMap() (map[string]any, error) {
+	args := make(map[string]any)
+
+	if len(f.Arguments) == 0 {
+		return args, nil
+	}
+
+	if err := json.Unmarshal([]byte(f.Arguments), &args); err != nil {
+		return nil, fmt.Errorf("failed to decode arguments of the %q tool call: %w", f.Name, err)
+	}
+
+	return args, nil
+}
+
+type ToolChoiceMode = string
+
+var (
+	ToolChoiceAuto     ToolChoiceMode = "auto"
+	ToolChoiceNone     ToolChoiceMode = "none"
+	ToolChoiceRequired ToolChoiceMode = "required"
+	ToolChoiceFunction ToolChoiceMode = "function"
+)
+
+var ErrInvalidToolChoice = errors.New("tool_choice must be one of auto, none, required or a function reference")
+
+// ToolChoice controls which tool (if any) the model calls.
+//
+//	It's encoded as a mode string (e.g. "auto") or as a function reference (e.g. {"type": "function", "function": {"name": "get_weather"}})
+type ToolChoice struct {
+	Mode         ToolChoiceMode
+	FunctionName string
+}
+
+type toolChoiceFunctionRef struct {
+	Type     ToolType `json:"type"`
+	Function struct {
+		Name string `json:"name"`
+	} `json:"function"`
+}
+
+func (c ToolChoice) MarshalJSON() ([]byte, error) {
+	if c.Mode != ToolChoiceFunction {
+		return json.Marshal(c.Mode)
+	}
+
+	ref := toolChoiceFunctionRef{Type: ToolTypeFunction}
+	ref.Function.Name = c.FunctionName
+
+	return json.Marshal(ref)
+}
+
+func (c *ToolChoice) UnmarshalJSON(data []byte) error {
+	var mode string
+
+	if err := json.Unmarshal(data, &mode); err == nil {
+		if mode != ToolChoiceAuto && mode != ToolChoiceNone && mode != ToolChoiceRequired {
+			return ErrInvalidToolChoice
+		}
+
+		*c = ToolChoice{Mode: mode}
+
+		return nil
+	}
+
+	var ref toolChoiceFunctionRef
+
+	if err := json.Unmarshal(data, &ref); err != nil || ref.Type != ToolTypeFunction || len(ref.Function.Name) == 0 {
+		return ErrInvalidToolChoice
+	}
+
+	*c = ToolChoice{Mode: ToolChoiceFunction, FunctionName: ref.Function.Name}
+
+	return nil
+}
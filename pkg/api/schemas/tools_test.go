@@ -0,0 +1,77 @@
+package schemas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolChoice_Unmarshal(t *testing.T) {
+	tests := map[string]struct {
+		rawChoice string
+		expected  ToolChoice
+	}{
+		"auto mode":          {`"auto"`, ToolChoice{Mode: ToolChoiceAuto}},
+		"none mode":          {`"none"`, ToolChoice{Mode: ToolChoiceNone}},
+		"required mode":      {`"required"`, ToolChoice{Mode: ToolChoiceRequired}},
+		"function reference": {`{"type": "function", "function": {"name": "get_weather"}}`, ToolChoice{Mode: ToolChoiceFunction, FunctionName: "get_weather"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var choice ToolChoice
+
+			require.NoError(t, json.Unmarshal([]byte(tt.rawChoice), &choice))
+			require.Equal(t, tt.expected, choice)
+
+			rawChoice, err := json.Marshal(choice)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.rawChoice, string(rawChoice))
+		})
+	}
+}
+
+func TestToolChoice_UnmarshalInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown mode":          `"always"`,
+		"unknown type":          `{"type": "retrieval", "function": {"name": "get_weather"}}`,
+		"missing function name": `{"type": "function", "function": {}}`,
+	}
+
+	for name, rawChoice := range tests {
+		t.Run(name, func(t *testing.T) {
+			var choice ToolChoice
+
+			require.ErrorIs(t, json.Unmarshal([]byte(rawChoice), &choice), ErrInvalidToolChoice)
+		})
+	}
+}
+
+func TestChatRequest_ToolParams(t *testing.T) {
+	rawRequest := `{
+		"message": {"role": "tool", "tool_call_id": "call_1", "content": "15 degrees"},
+		"message_history": [
+			{"role": "user", "content": "What's the weather in Paris?"},
+			{"role": "assistant", "tool_calls": [{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"location\": \"Paris\"}"}}]}
+		],
+		"tools": [{"type": "function", "function": {"name": "get_weather", "parameters": {"type": "object"}}}],
+		"tool_choice": "required"
+	}`
+
+	var chatReq ChatRequest
+
+	require.NoError(t, json.Unmarshal([]byte(rawRequest), &chatReq))
+
+	params := chatReq.Params("my-model", "gpt-4o")
+
+	require.Len(t, params.Tools, 1)
+	require.Equal(t, "get_weather", params.Tools[0].Function.Name)
+	require.Equal(t, &ToolChoice{Mode: ToolChoiceRequired}, params.ToolChoice)
+	require.True(t, params.Messages[1].HasToolCalls())
+	require.Equal(t, "call_1", params.Messages[2].ToolCallID)
+
+	args, err := params.Messages[1].ToolCalls[0].Function.ArgumentsMap()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"location": "Paris"}, args)
+}
@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// Response cache serves identical chat requests without calling models again.
+//
+//	Requests are keyed by the hash of their normalized content and params, so requests that differ only in
+//	request IDs, metadata or insignificant whitespace share the cache entry
+
+var ErrEntryTooLarge = errors.New("cache entry exceeds the max entry size")
+
+// Backend stores serialized cache entries
+type Backend interface {
+	// Get returns the entry by key. A missing or expired entry is reported as a miss, not an error
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+type entryKind = string
+
+const (
+	chatEntry       entryKind = "chat"
+	chatStreamEntry entryKind = "chat_stream"
+)
+
+// ResponseCache caches chat responses and streamed chat chunks in the backend
+type ResponseCache struct {
+	backend      Backend
+	ttl          time.Duration
+	maxEntrySize int
+	keyPrefix    string
+}
+
+func NewResponseCache(backend Backend, ttl time.Duration, maxEntrySize int, keyPrefix string) *ResponseCache {
+	return &ResponseCache{
+		backend:      backend,
+		ttl:          ttl,
+		maxEntrySize: maxEntrySize,
+		keyPrefix:    keyPrefix,
+	}
+}
+
+// ChatKey builds the cache key of the chat request served by the given router
+func (c *ResponseCache) ChatKey(routerID string, req *schemas.ChatRequest) (string, error) {
+	return c.key(routerID, chatEntry, req)
+}
+
+// ChatStreamKey builds the cache key of the streaming chat request served by the given router
+func (c *ResponseCache) ChatStreamKey(routerID string, req *schemas.ChatRequest) (string, error) {
+	return c.key(routerID, chatStreamEntry, req)
+}
+
+// GetChat returns the cached chat response marked as cached or nil if there is no one
+func (c *ResponseCache) GetChat(ctx context.Context, key string) (*schemas.ChatResponse, error) {
+	var resp schemas.ChatResponse
+
+	found, err := c.get(ctx, key, &resp)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	resp.Cached = true
+
+	return &resp, nil
+}
+
+func (c *ResponseCache) SetChat(ctx context.Context, key string, resp *schemas.ChatResponse) error {
+	return c.set(ctx, key, resp)
+}
+
+// GetChatStream returns the cached chunks of the chat stream marked as cached or nil if there are no ones
+func (c *ResponseCache) GetChatStream(ctx context.Context, key string) ([]*schemas.ChatStreamChunk, error) {
+	var chunks []*schemas.ChatStreamChunk
+
+	found, err := c.get(ctx, key, &chunks)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	for _, chunk := range chunks {
+		chunk.Cached = true
+	}
+
+	return chunks, nil
+}
+
+func (c *ResponseCache) SetChatStream(ctx context.Context, key string, chunks []*schemas.ChatStreamChunk) error {
+	return c.set(ctx, key, chunks)
+}
+
+func (c *ResponseCache) get(ctx context.Context, key string, entry any) (bool, error) {
+	rawEntry, found, err := c.backend.Get(ctx, key)
+	if err != nil || !found {
+		return false, err
+	}
+
+	if err := json.Unmarshal(rawEntry, entry); err != nil {
+		return false, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+
+	return true, nil
+}
+
+func (c *ResponseCache) set(ctx context.Context, key string, entry any) error {
+	rawEntry, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if len(rawEntry) > c.maxEntrySize {
+		return ErrEntryTooLarge
+	}
+
+	return c.backend.Set(ctx, key, rawEntry, c.ttl)
+}
+
+// requestFingerprint holds everything that affects the model response
+type requestFingerprint struct {
+	Messages         []schemas.ChatMessage                  `json:"messages"`
+	Tools            []schemas.Tool                         `json:"tools,omitempty"`
+	ToolChoice       *schemas.ToolChoice                    `json:"tool_choice,omitempty"`
+	ResponseFormat   *schemas.ResponseFormat                `json:"response_format,omitempty"`
+	GenerationParams *schemas.GenerationParams              `json:"params,omitempty"`
+	OverrideParams   map[string]schemas.ModelParamsOverride `json:"override_params,omitempty"`
+}
+
+func (c *ResponseCache) key(routerID string, kind entryKind, req *schemas.ChatRequest) (string, error) {
+	hash, err := fingerprintHash(req, true)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{c.keyPrefix, routerID, kind, hash}, ":"), nil
+}
+
+// fingerprintHash hashes the request content. The final message may be left out to find requests that differ in it only
+func fingerprintHash(req *schemas.ChatRequest, withMessage bool) (string, error) {
+	messages := make([]schemas.ChatMessage, 0, len(req.MessageHistory)+1)
+	messages = append(messages, req.MessageHistory...)
+
+	if withMessage {
+		messages = append(messages, req.Message)
+	}
+
+	fingerprint := requestFingerprint{
+		Messages:         normalizeMessages(messages),
+		Tools:            req.Tools,
+		ToolChoice:       req.ToolChoice,
+		ResponseFormat:   req.ResponseFormat,
+		GenerationParams: req.GenerationParams,
+	}
+
+	if req.OverrideParams != nil && len(*req.OverrideParams) > 0 {
+		fingerprint.OverrideParams = make(map[string]schemas.ModelParamsOverride, len(*req.OverrideParams))
+
+		for modelNameOrID, override := range *req.OverrideParams {
+			override.Message = normalizeMessages([]schemas.ChatMessage{override.Message})[0]
+			fingerprint.OverrideParams[modelNameOrID] = override
+		}
+	}
+
+	// maps are encoded with sorted keys, so the encoding is stable
+	rawFingerprint, err := json.Marshal(fingerprint)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request fingerprint: %w", err)
+	}
+
+	hash := sha256.Sum256(rawFingerprint)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// normalizeMessages drops differences that don't change the meaning of messages
+func normalizeMessages(messages []schemas.ChatMessage) []schemas.ChatMessage {
+	normalized := make([]schemas.ChatMessage, 0, len(messages))
+
+	for _, message := range messages {
+		message.Role = strings.ToLower(strings.TrimSpace(message.Role))
+		message.Content = strings.TrimSpace(message.Content)
+
+		normalized = append(normalized, message)
+	}
+
+	return normalized
+}
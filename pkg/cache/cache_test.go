@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCache_NormalizedKeys(t *testing.T) {
+	responseCache := NewResponseCache(NewMemoryBackend(10, 1024), time.Minute, 1024, "glide")
+
+	key, err := responseCache.ChatKey("router", schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+
+	sameReq := schemas.NewChatFromStr("  tell me a dad joke\n")
+	sameReq.Message.Role = "User"
+
+	sameKey, err := responseCache.ChatKey("router", sameReq)
+	require.NoError(t, err)
+	require.Equal(t, key, sameKey)
+
+	temperature := 0.2
+	paramsReq := schemas.NewChatFromStr("tell me a dad joke")
+	paramsReq.GenerationParams = &schemas.GenerationParams{Temperature: &temperature}
+
+	paramsKey, err := responseCache.ChatKey("router", paramsReq)
+	require.NoError(t, err)
+	require.NotEqual(t, key, paramsKey)
+
+	otherRouterKey, err := responseCache.ChatKey("other_router", schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherRouterKey)
+
+	streamKey, err := responseCache.ChatStreamKey("router", schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.NotEqual(t, key, streamKey)
+}
+
+func TestResponseCache_Chat(t *testing.T) {
+	ctx := context.Background()
+	responseCache := NewResponseCache(NewMemoryBackend(10, 1024), time.Minute, 1024, "glide")
+
+	resp, err := responseCache.GetChat(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	require.NoError(t, responseCache.SetChat(ctx, "key", &schemas.ChatResponse{ID: "rsp0001"}))
+
+	resp, err = responseCache.GetChat(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, "rsp0001", resp.ID)
+	require.True(t, resp.Cached)
+}
+
+func TestResponseCache_MaxEntrySize(t *testing.T) {
+	responseCache := NewResponseCache(NewMemoryBackend(10, 1024), time.Minute, 10, "glide")
+
+	err := responseCache.SetChat(context.Background(), "key", &schemas.ChatResponse{ID: "rsp0001"})
+	require.ErrorIs(t, err, ErrEntryTooLarge)
+}
+
+func TestParseControl(t *testing.T) {
+	require.Equal(t, Control{}, ParseControl("", ""))
+	require.Equal(t, Control{NoCache: true}, ParseControl("no-cache", ""))
+	require.Equal(t, Control{NoCache: true, NoStore: true}, ParseControl("No-Cache, no-store", ""))
+	require.Equal(t, Control{NoCache: true, NoStore: true}, ParseControl("", "true"))
+}
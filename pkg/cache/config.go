@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+)
+
+// Config defines the response cache of the router. The cache is used by routers that have this section defined
+type Config struct {
+	Enabled bool            `yaml:"enabled" json:"enabled"`
+	TTL     fields.Duration `yaml:"ttl,omitempty" json:"ttl" swaggertype:"primitive,string"` // how long the response is served from the cache
+	// MaxEntrySize is the max size of one cached response in bytes. Larger responses are not cached
+	MaxEntrySize int           `yaml:"max_entry_size,omitempty" json:"max_entry_size" validate:"gt=0"`
+	KeyPrefix    string        `yaml:"key_prefix,omitempty" json:"key_prefix"`
+	Memory       *MemoryConfig `yaml:"memory,omitempty" json:"memory,omitempty"`
+	Redis        *RedisConfig  `yaml:"redis,omitempty" json:"redis,omitempty"` // the in-memory backend is used unless Redis is configured
+	// Semantic enables serving responses of similar questions in addition to identical requests
+	Semantic *SemanticConfig `yaml:"semantic,omitempty" json:"semantic,omitempty"`
+}
+
+type MemoryConfig struct {
+	MaxEntries int `yaml:"max_entries,omitempty" json:"max_entries" validate:"gt=0"`
+	MaxSize    int `yaml:"max_size,omitempty" json:"max_size" validate:"gt=0"` // the max total size of cached responses in bytes
+}
+
+type RedisConfig struct {
+	Address  string          `yaml:"address" json:"address" validate:"required"`
+	Password fields.Secret   `yaml:"password,omitempty" json:"-"`
+	DB       int             `yaml:"db,omitempty" json:"db"`
+	PoolSize int             `yaml:"pool_size,omitempty" json:"pool_size" validate:"gt=0"` // the max number of idle connections
+	Timeout  fields.Duration `yaml:"timeout,omitempty" json:"timeout" swaggertype:"primitive,string"`
+}
+
+type SemanticConfig struct {
+	EmbeddingRouter string `yaml:"embedding_router" json:"embedding_router" validate:"required"` // the ID of the embedding router
+	// Threshold is the min cosine similarity of messages to serve the cached response
+	Threshold  float64 `yaml:"threshold,omitempty" json:"threshold" validate:"gt=0,lte=1"`
+	MaxEntries int     `yaml:"max_entries,omitempty" json:"max_entries" validate:"gt=0"`
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled:      true,
+		TTL:          fields.Duration(1 * time.Hour),
+		MaxEntrySize: 1 << 20, // 1MB
+		KeyPrefix:    "glide",
+	}
+}
+
+func DefaultMemoryConfig() *MemoryConfig {
+	return &MemoryConfig{
+		MaxEntries: 1000,
+		MaxSize:    64 << 20, // 64MB
+	}
+}
+
+func DefaultRedisConfig() *RedisConfig {
+	return &RedisConfig{
+		Address:  "localhost:6379",
+		PoolSize: 10,
+		Timeout:  fields.Duration(1 * time.Second),
+	}
+}
+
+func DefaultSemanticConfig() *SemanticConfig {
+	return &SemanticConfig{
+		Threshold:  0.95,
+		MaxEntries: 10000,
+	}
+}
+
+// ToCache creates the response cache with the configured backend
+func (c *Config) ToCache() *ResponseCache {
+	return NewResponseCache(c.backend(), time.Duration(c.TTL), c.MaxEntrySize, c.KeyPrefix)
+}
+
+// ToSemanticCache creates the semantic cache that embeds messages with the given embedder
+func (c *Config) ToSemanticCache(embedder Embedder) *SemanticCache {
+	return NewSemanticCache(
+		embedder,
+		NewVectorIndex(c.Semantic.MaxEntries),
+		c.Semantic.Threshold,
+		time.Duration(c.TTL),
+		c.MaxEntrySize,
+	)
+}
+
+func (c *Config) backend() Backend {
+	if c.Redis != nil {
+		return NewRedisBackend(
+			c.Redis.Address,
+			string(c.Redis.Password),
+			c.Redis.DB,
+			c.Redis.PoolSize,
+			time.Duration(c.Redis.Timeout),
+		)
+	}
+
+	memoryConfig := c.Memory
+	if memoryConfig == nil {
+		memoryConfig = DefaultMemoryConfig()
+	}
+
+	return NewMemoryBackend(memoryConfig.MaxEntries, memoryConfig.MaxSize)
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+func (c *MemoryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultMemoryConfig()
+
+	type plain MemoryConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+func (c *RedisConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultRedisConfig()
+
+	type plain RedisConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+func (c *SemanticConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultSemanticConfig()
+
+	type plain SemanticConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"strings"
+)
+
+// Request headers that let clients bypass the response cache
+const (
+	// CacheControlHeader follows HTTP semantics: "no-cache" skips the lookup, "no-store" skips saving the response
+	CacheControlHeader = "Cache-Control"
+	// BypassHeader set to "true" skips both the lookup & saving
+	BypassHeader = "X-Glide-Cache-Bypass"
+)
+
+// Control defines how the request should use the response cache
+type Control struct {
+	NoCache bool // don't serve the request from the cache
+	NoStore bool // don't cache the response
+}
+
+// ParseControl reads the cache control out of request headers
+func ParseControl(cacheControl string, bypass string) Control {
+	control := Control{}
+
+	for _, directive := range strings.Split(cacheControl, ",") {
+		switch strings.ToLower(strings.TrimSpace(directive)) {
+		case "no-cache":
+			control.NoCache = true
+		case "no-store":
+			control.NoStore = true
+		}
+	}
+
+	if strings.EqualFold(strings.TrimSpace(bypass), "true") {
+		control.NoCache = true
+		control.NoStore = true
+	}
+
+	return control
+}
+
+type controlCtxKey struct{}
+
+// WithControl passes the cache control of the request down to routers
+func WithControl(ctx context.Context, control Control) context.Context {
+	return context.WithValue(ctx, controlCtxKey{}, control)
+}
+
+// ControlFromContext returns the cache control of the request. The cache is fully used by default
+func ControlFromContext(ctx context.Context) Control {
+	control, _ := ctx.Value(controlCtxKey{}).(Control)
+
+	return control
+}
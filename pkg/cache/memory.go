@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryBackend keeps cache entries in the process memory.
+//
+//	Entries expire lazily on access. When the number of entries or their total size goes over limits,
+//	the least recently used entries are evicted
+type MemoryBackend struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxSize    int
+	size       int
+	entries    map[string]*list.Element
+	recency    *list.List
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key      string
+	value    []byte
+	expireAt time.Time
+}
+
+func NewMemoryBackend(maxEntries int, maxSize int) *MemoryBackend {
+	return &MemoryBackend{
+		maxEntries: maxEntries,
+		maxSize:    maxSize,
+		entries:    make(map[string]*list.Element, maxEntries),
+		recency:    list.New(),
+		now:        time.Now,
+	}
+}
+
+func (b *MemoryBackend) Get(_ context.Context, key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	element, found := b.entries[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+
+	if b.now().After(entry.expireAt) {
+		b.remove(element)
+
+		return nil, false, nil
+	}
+
+	b.recency.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+func (b *MemoryBackend) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if len(value) > b.maxSize {
+		return ErrEntryTooLarge
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if element, found := b.entries[key]; found {
+		b.remove(element)
+	}
+
+	b.entries[key] = b.recency.PushFront(&memoryEntry{
+		key:      key,
+		value:    value,
+		expireAt: b.now().Add(ttl),
+	})
+	b.size += len(value)
+
+	for len(b.entries) > b.maxEntries || b.size > b.maxSize {
+		b.remove(b.recency.Back())
+	}
+
+	return nil
+}
+
+// Len returns the number of stored entries including expired ones that have not been accessed yet
+func (b *MemoryBackend) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.entries)
+}
+
+func (b *MemoryBackend) remove(element *list.Element) {
+	entry := b.recency.Remove(element).(*memoryEntry)
+
+	delete(b.entries, entry.key)
+	b.size -= len(entry.value)
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBackend_GetSet(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 1024)
+
+	require.NoError(t, backend.Set(ctx, "key", []byte("value"), time.Minute))
+
+	value, found, err := backend.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []byte("value"), value)
+
+	_, found, err = backend.Get(ctx, "unknown")
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestMemoryBackend_Expiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	backend := NewMemoryBackend(10, 1024)
+	backend.now = func() time.Time { return now }
+
+	require.NoError(t, backend.Set(ctx, "key", []byte("value"), time.Minute))
+
+	now = now.Add(2 * time.Minute)
+
+	_, found, err := backend.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, found)
+	require.Equal(t, 0, backend.Len())
+}
+
+func TestMemoryBackend_EvictLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(2, 1024)
+
+	require.NoError(t, backend.Set(ctx, "first", []byte("1"), time.Minute))
+	require.NoError(t, backend.Set(ctx, "second", []byte("2"), time.Minute))
+
+	// make the first entry the most recently used one
+	_, found, _ := backend.Get(ctx, "first")
+	require.True(t, found)
+
+	require.NoError(t, backend.Set(ctx, "third", []byte("3"), time.Minute))
+
+	_, found, _ = backend.Get(ctx, "second")
+	require.False(t, found)
+
+	_, found, _ = backend.Get(ctx, "first")
+	require.True(t, found)
+}
+
+func TestMemoryBackend_SizeLimit(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMemoryBackend(10, 10)
+
+	require.ErrorIs(t, backend.Set(ctx, "large", []byte("more than ten bytes"), time.Minute), ErrEntryTooLarge)
+
+	require.NoError(t, backend.Set(ctx, "first", []byte("123456"), time.Minute))
+	require.NoError(t, backend.Set(ctx, "second", []byte("123456"), time.Minute))
+
+	require.Equal(t, 1, backend.Len())
+
+	_, found, _ := backend.Get(ctx, "second")
+	require.True(t, found)
+}
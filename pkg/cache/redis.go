@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// RedisBackend keeps cache entries in Redis or any server that speaks the Redis protocol (e.g. Valkey, KeyDB, Dragonfly).
+//
+//	It implements the small subset of RESP2 the cache needs (AUTH, SELECT, GET, SET with PX),
+//	so no Redis client library is required
+//	Ref: https://redis.io/docs/latest/develop/reference/protocol-spec/
+type RedisBackend struct {
+	address  string
+	password string
+	db       int
+	timeout  time.Duration
+	idle     chan *redisConn
+}
+
+// RedisError is an error reply of the server
+type RedisError struct {
+	Message string
+}
+
+func (e *RedisError) Error() string {
+	return "redis: " + e.Message
+}
+
+var errUnexpectedReply = errors.New("redis: unexpected reply")
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisBackend(address string, password string, db int, poolSize int, timeout time.Duration) *RedisBackend {
+	return &RedisBackend{
+		address:  address,
+		password: password,
+		db:       db,
+		timeout:  timeout,
+		idle:     make(chan *redisConn, poolSize),
+	}
+}
+
+func (b *RedisBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := b.do(ctx, "GET", []byte(key))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, errUnexpectedReply
+	}
+
+	return value, true, nil
+}
+
+func (b *RedisBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := b.do(ctx, "SET", []byte(key), value, []byte("PX"), []byte(strconv.FormatInt(ttl.Milliseconds(), 10)))
+
+	return err
+}
+
+// Close closes idle connections
+func (b *RedisBackend) Close() error {
+	for {
+		select {
+		case conn := <-b.idle:
+			conn.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (b *RedisBackend) do(ctx context.Context, command string, args ...[]byte) (any, error) {
+	conn, err := b.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, b.timeout, command, args...)
+
+	var redisErr *RedisError
+
+	if err != nil && !errors.As(err, &redisErr) {
+		// the connection state is unknown after network failures
+		conn.conn.Close()
+
+		return nil, err
+	}
+
+	b.release(conn)
+
+	return reply, err
+}
+
+func (b *RedisBackend) acquire(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-b.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: b.timeout}
+
+	netConn, err := dialer.DialContext(ctx, "tcp", b.address)
+	if err != nil {
+		return nil, fmt.Errorf("redis: failed to connect: %w", err)
+	}
+
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if len(b.password) > 0 {
+		if _, err := conn.do(ctx, b.timeout, "AUTH", []byte(b.password)); err != nil {
+			netConn.Close()
+
+			return nil, err
+		}
+	}
+
+	if b.db != 0 {
+		if _, err := conn.do(ctx, b.timeout, "SELECT", []byte(strconv.Itoa(b.db))); err != nil {
+			netConn.Close()
+
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (b *RedisBackend) release(conn *redisConn) {
+	select {
+	case b.idle <- conn:
+	default:
+		// the pool is full
+		conn.conn.Close()
+	}
+}
+
+func (c *redisConn) do(ctx context.Context, timeout time.Duration, command string, args ...[]byte) (any, error) {
+	deadline := time.Now().Add(timeout)
+
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	writer := bufio.NewWriter(c.conn)
+
+	fmt.Fprintf(writer, "*%d\r\n$%d\r\n%s\r\n", len(args)+1, len(command), command)
+
+	for _, arg := range args {
+		fmt.Fprintf(writer, "$%d\r\n", len(arg))
+		writer.Write(arg)          //nolint:errcheck
+		writer.WriteString("\r\n") //nolint:errcheck
+	}
+
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.reader)
+}
+
+// readReply reads one RESP2 reply. Nil bulk strings & arrays are returned as nil
+func readReply(reader *bufio.Reader) (any, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) == 0 {
+		return nil, errUnexpectedReply
+	}
+
+	switch line[0] {
+	case '+':
+		return string(line[1:]), nil
+	case '-':
+		return nil, &RedisError{Message: string(line[1:])}
+	case ':':
+		return strconv.ParseInt(string(line[1:]), 10, 64)
+	case '$':
+		size, err := strconv.Atoi(string(line[1:]))
+		if err != nil || size < 0 {
+			return nil, err
+		}
+
+		value := make([]byte, size+2) // the value is followed by CRLF
+
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+
+		return value[:size], nil
+	case '*':
+		size, err := strconv.Atoi(string(line[1:]))
+		if err != nil || size < 0 {
+			return nil, err
+		}
+
+		items := make([]any, 0, size)
+
+		for i := 0; i < size; i++ {
+			item, err := readReply(reader)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, item)
+		}
+
+		return items, nil
+	default:
+		return nil, errUnexpectedReply
+	}
+}
+
+func readLine(reader *bufio.Reader) ([]byte, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, errUnexpectedReply
+	}
+
+	return line[:len(line)-2], nil
+}
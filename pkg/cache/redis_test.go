@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// redisStandIn is a local stand-in for Redis that speaks enough of RESP2 to serve the cache
+type redisStandIn struct {
+	listener net.Listener
+	password string
+	mu       sync.Mutex
+	values   map[string]string
+	ttls     map[string]string
+	wg       sync.WaitGroup
+}
+
+func newRedisStandIn(t *testing.T, password string) *redisStandIn {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &redisStandIn{
+		listener: listener,
+		password: password,
+		values:   make(map[string]string),
+		ttls:     make(map[string]string),
+	}
+
+	server.wg.Add(1)
+
+	go server.serve()
+
+	t.Cleanup(func() {
+		listener.Close()
+		server.wg.Wait()
+	})
+
+	return server
+}
+
+func (s *redisStandIn) Address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *redisStandIn) TTL(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ttls[key]
+}
+
+func (s *redisStandIn) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+
+		go s.handle(conn)
+	}
+}
+
+func (s *redisStandIn) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	authenticated := len(s.password) == 0
+
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+
+		items, _ := reply.([]any)
+		args := make([]string, 0, len(items))
+
+		for _, item := range items {
+			raw, _ := item.([]byte)
+			args = append(args, string(raw))
+		}
+
+		command := strings.ToUpper(args[0])
+
+		switch {
+		case command == "AUTH":
+			authenticated = args[1] == s.password
+
+			if !authenticated {
+				conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+				continue
+			}
+
+			conn.Write([]byte("+OK\r\n"))
+		case !authenticated:
+			conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+		case command == "GET":
+			s.mu.Lock()
+			value, found := s.values[args[1]]
+			s.mu.Unlock()
+
+			if !found {
+				conn.Write([]byte("$-1\r\n"))
+				continue
+			}
+
+			conn.Write([]byte("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"))
+		case command == "SET":
+			s.mu.Lock()
+			s.values[args[1]] = args[2]
+			s.ttls[args[1]] = args[4]
+			s.mu.Unlock()
+
+			conn.Write([]byte("+OK\r\n"))
+		default:
+			conn.Write([]byte("-ERR unknown command\r\n"))
+		}
+	}
+}
+
+func TestRedisBackend_GetSet(t *testing.T) {
+	ctx := context.Background()
+	server := newRedisStandIn(t, "secret")
+
+	backend := NewRedisBackend(server.Address(), "secret", 0, 2, time.Second)
+	defer backend.Close()
+
+	_, found, err := backend.Get(ctx, "key")
+	require.NoError(t, err)
+	require.False(t, found)
+
+	value := []byte("{\"id\": \"rsp0001\"}\r\nwith a line break")
+
+	require.NoError(t, backend.Set(ctx, "key", value, 90*time.Second))
+	require.Equal(t, "90000", server.TTL("key"))
+
+	cachedValue, found, err := backend.Get(ctx, "key")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, value, cachedValue)
+}
+
+func TestRedisBackend_WrongPassword(t *testing.T) {
+	server := newRedisStandIn(t, "secret")
+
+	backend := NewRedisBackend(server.Address(), "wrong", 0, 2, time.Second)
+	defer backend.Close()
+
+	_, _, err := backend.Get(context.Background(), "key")
+
+	var redisErr *RedisError
+
+	require.ErrorAs(t, err, &redisErr)
+}
+
+func TestRedisBackend_Unavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	address := listener.Addr().String()
+	listener.Close()
+
+	backend := NewRedisBackend(address, "", 0, 2, time.Second)
+
+	_, _, err = backend.Get(context.Background(), "key")
+	require.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// Semantic cache serves chat requests that ask the same thing in different words.
+//
+//	The final user message is embedded and compared with messages of already answered requests.
+//	Only requests with the same history, tools and params are compared, so the answer always fits the conversation
+
+var ErrNoEmbedding = errors.New("embedding router returned no embeddings")
+
+// Embedder turns texts into vectors (e.g. an embedding router)
+type Embedder interface {
+	Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error)
+}
+
+// SemanticCache caches chat responses in the vector index by embeddings of the final user message
+type SemanticCache struct {
+	embedder     Embedder
+	index        *VectorIndex
+	threshold    float64
+	ttl          time.Duration
+	maxEntrySize int
+}
+
+func NewSemanticCache(
+	embedder Embedder,
+	index *VectorIndex,
+	threshold float64,
+	ttl time.Duration,
+	maxEntrySize int,
+) *SemanticCache {
+	return &SemanticCache{
+		embedder:     embedder,
+		index:        index,
+		threshold:    threshold,
+		ttl:          ttl,
+		maxEntrySize: maxEntrySize,
+	}
+}
+
+// Namespace returns the index namespace of the request served by the given router.
+// It's false if the request can't be cached semantically (e.g. the final message is not a user text)
+func (c *SemanticCache) Namespace(routerID string, req *schemas.ChatRequest) (string, bool, error) {
+	message := req.Message
+
+	if message.IsMultimodal() || strings.ToLower(strings.TrimSpace(message.Role)) != "user" ||
+		len(strings.TrimSpace(message.Content)) == 0 {
+		return "", false, nil
+	}
+
+	hash, err := fingerprintHash(req, false)
+	if err != nil {
+		return "", false, err
+	}
+
+	return routerID + ":" + hash, true, nil
+}
+
+// Embed returns the vector of the final message of the request
+func (c *SemanticCache) Embed(ctx context.Context, req *schemas.ChatRequest) ([]float64, error) {
+	embedReq := &schemas.EmbedRequest{
+		Input: schemas.EmbedInput{strings.TrimSpace(req.Message.Content)},
+	}
+
+	resp, err := c.embedder.Embed(ctx, embedReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Embeddings) == 0 {
+		return nil, ErrNoEmbedding
+	}
+
+	return resp.Embeddings[0].Embedding, nil
+}
+
+// GetChat returns the cached response of the most similar message marked as cached or nil if there is no one
+func (c *SemanticCache) GetChat(namespace string, vector []float64) (*schemas.ChatResponse, error) {
+	rawEntry, _, found := c.index.Search(namespace, vector, c.threshold)
+	if !found {
+		return nil, nil
+	}
+
+	var resp schemas.ChatResponse
+
+	if err := json.Unmarshal(rawEntry, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+
+	resp.Cached = true
+
+	return &resp, nil
+}
+
+func (c *SemanticCache) SetChat(namespace string, vector []float64, resp *schemas.ChatResponse) error {
+	rawEntry, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if len(rawEntry) > c.maxEntrySize {
+		return ErrEntryTooLarge
+	}
+
+	c.index.Add(namespace, vector, rawEntry, c.ttl)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/stretchr/testify/require"
+)
+
+type embedderMock struct {
+	vectors map[string][]float64
+}
+
+func (e *embedderMock) Embed(_ context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	return &schemas.EmbedResponse{
+		Embeddings: []schemas.Embedding{{Index: 0, Embedding: e.vectors[req.Input[0]]}},
+	}, nil
+}
+
+func TestVectorIndex_Search(t *testing.T) {
+	index := NewVectorIndex(10)
+
+	index.Add("router", []float64{1, 0}, []byte("east"), time.Minute)
+	index.Add("router", []float64{0, 1}, []byte("north"), time.Minute)
+
+	value, similarity, found := index.Search("router", []float64{0.1, 0.9}, 0.9)
+	require.True(t, found)
+	require.Equal(t, "north", string(value))
+	require.Greater(t, similarity, 0.99)
+
+	_, _, found = index.Search("router", []float64{1, 1}, 0.9)
+	require.False(t, found)
+
+	_, _, found = index.Search("other_router", []float64{0, 1}, 0.9)
+	require.False(t, found)
+}
+
+func TestVectorIndex_Expiration(t *testing.T) {
+	now := time.Now()
+	index := NewVectorIndex(10)
+	index.now = func() time.Time { return now }
+
+	index.Add("router", []float64{1, 0}, []byte("east"), time.Minute)
+
+	now = now.Add(2 * time.Minute)
+
+	_, _, found := index.Search("router", []float64{1, 0}, 0.9)
+	require.False(t, found)
+	require.Equal(t, 0, index.Len())
+}
+
+func TestVectorIndex_Eviction(t *testing.T) {
+	now := time.Now()
+	index := NewVectorIndex(2)
+	index.now = func() time.Time { return now }
+
+	index.Add("router", []float64{1, 0}, []byte("east"), time.Minute)
+
+	now = now.Add(time.Second)
+	index.Add("other_router", []float64{0, 1}, []byte("north"), time.Minute)
+
+	now = now.Add(time.Second)
+	index.Add("router", []float64{0, 1}, []byte("north"), time.Minute)
+
+	require.Equal(t, 2, index.Len())
+
+	_, _, found := index.Search("router", []float64{1, 0}, 0.9)
+	require.False(t, found)
+
+	_, _, found = index.Search("other_router", []float64{0, 1}, 0.9)
+	require.True(t, found)
+}
+
+func TestSemanticCache_Chat(t *testing.T) {
+	ctx := context.Background()
+	embedder := &embedderMock{vectors: map[string][]float64{
+		"how do I reset my password?":     {0.9, 0.1, 0},
+		"how can I reset the password?":   {0.88, 0.12, 0.01},
+		"what is the price of the plan?":  {0, 0.1, 0.9},
+		"how do I reset my password? pls": {0.9, 0.1, 0},
+	}}
+
+	semanticCache := NewSemanticCache(embedder, NewVectorIndex(10), 0.95, time.Minute, 1024)
+
+	req := schemas.NewChatFromStr("how do I reset my password?")
+
+	namespace, cacheable, err := semanticCache.Namespace("router", req)
+	require.NoError(t, err)
+	require.True(t, cacheable)
+
+	vector, err := semanticCache.Embed(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, semanticCache.SetChat(namespace, vector, &schemas.ChatResponse{ID: "rsp0001"}))
+
+	paraphrasedReq := schemas.NewChatFromStr("how can I reset the password?")
+
+	paraphrasedNamespace, _, err := semanticCache.Namespace("router", paraphrasedReq)
+	require.NoError(t, err)
+	require.Equal(t, namespace, paraphrasedNamespace)
+
+	vector, err = semanticCache.Embed(ctx, paraphrasedReq)
+	require.NoError(t, err)
+
+	resp, err := semanticCache.GetChat(namespace, vector)
+	require.NoError(t, err)
+	require.Equal(t, "rsp0001", resp.ID)
+	require.True(t, resp.Cached)
+
+	vector, err = semanticCache.Embed(ctx, schemas.NewChatFromStr("what is the price of the plan?"))
+	require.NoError(t, err)
+
+	resp, err = semanticCache.GetChat(namespace, vector)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+}
+
+func TestSemanticCache_Namespace(t *testing.T) {
+	semanticCache := NewSemanticCache(&embedderMock{}, NewVectorIndex(10), 0.95, time.Minute, 1024)
+
+	namespace, _, err := semanticCache.Namespace("router", schemas.NewChatFromStr("how do I reset my password?"))
+	require.NoError(t, err)
+
+	otherRouterNamespace, _, err := semanticCache.Namespace("other_router", schemas.NewChatFromStr("how do I reset my password?"))
+	require.NoError(t, err)
+	require.NotEqual(t, namespace, otherRouterNamespace)
+
+	// the same question means a different thing in another conversation
+	historyReq := schemas.NewChatFromStr("how do I reset my password?")
+	historyReq.MessageHistory = []schemas.ChatMessage{{Role: "user", Content: "I use the mobile app"}}
+
+	historyNamespace, _, err := semanticCache.Namespace("router", historyReq)
+	require.NoError(t, err)
+	require.NotEqual(t, namespace, historyNamespace)
+
+	assistantReq := schemas.NewChatFromStr("how do I reset my password?")
+	assistantReq.Message.Role = "assistant"
+
+	_, cacheable, err := semanticCache.Namespace("router", assistantReq)
+	require.NoError(t, err)
+	require.False(t, cacheable)
+}
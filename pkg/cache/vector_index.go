@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// VectorIndex is an in-process index of vectors searched by the cosine similarity.
+//
+//	Vectors are split into namespaces and a search never looks outside of the given namespace.
+//	The index does an exact (brute-force) search, which is fast enough for thousands of entries.
+//	When the index is full, the oldest entries are evicted
+type VectorIndex struct {
+	mu         sync.Mutex
+	maxEntries int
+	size       int
+	namespaces map[string][]*vectorEntry
+	now        func() time.Time
+}
+
+type vectorEntry struct {
+	vector   []float64 // normalized to the unit length, so the cosine similarity is a dot product
+	value    []byte
+	addedAt  time.Time
+	expireAt time.Time
+}
+
+func NewVectorIndex(maxEntries int) *VectorIndex {
+	return &VectorIndex{
+		maxEntries: maxEntries,
+		namespaces: make(map[string][]*vectorEntry),
+		now:        time.Now,
+	}
+}
+
+// Add stores the value by its vector in the namespace
+func (i *VectorIndex) Add(namespace string, vector []float64, value []byte, ttl time.Duration) {
+	normalized, ok := normalizeVector(vector)
+	if !ok {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+
+	i.namespaces[namespace] = append(i.namespaces[namespace], &vectorEntry{
+		vector:   normalized,
+		value:    value,
+		addedAt:  now,
+		expireAt: now.Add(ttl),
+	})
+	i.size++
+
+	for i.size > i.maxEntries {
+		i.evictOldest()
+	}
+}
+
+// Search finds the most similar vector in the namespace which similarity is not lower than the threshold
+func (i *VectorIndex) Search(namespace string, vector []float64, threshold float64) ([]byte, float64, bool) {
+	normalized, ok := normalizeVector(vector)
+	if !ok {
+		return nil, 0, false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	now := i.now()
+	entries := i.namespaces[namespace]
+	alive := entries[:0]
+
+	var (
+		bestEntry      *vectorEntry
+		bestSimilarity float64
+	)
+
+	for _, entry := range entries {
+		if now.After(entry.expireAt) {
+			i.size--
+
+			continue
+		}
+
+		alive = append(alive, entry)
+
+		if len(entry.vector) != len(normalized) {
+			continue
+		}
+
+		similarity := dotProduct(entry.vector, normalized)
+
+		if similarity >= threshold && (bestEntry == nil || similarity > bestSimilarity) {
+			bestEntry = entry
+			bestSimilarity = similarity
+		}
+	}
+
+	i.setNamespace(namespace, alive)
+
+	if bestEntry == nil {
+		return nil, 0, false
+	}
+
+	return bestEntry.value, bestSimilarity, true
+}
+
+// Len returns the number of stored entries including expired ones that have not been searched through yet
+func (i *VectorIndex) Len() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.size
+}
+
+func (i *VectorIndex) evictOldest() {
+	var oldestNamespace string
+
+	var oldestEntry *vectorEntry
+
+	// entries are appended in time order, so the first entry is the oldest one in each namespace
+	for namespace, entries := range i.namespaces {
+		if oldestEntry == nil || entries[0].addedAt.Before(oldestEntry.addedAt) {
+			oldestNamespace = namespace
+			oldestEntry = entries[0]
+		}
+	}
+
+	i.setNamespace(oldestNamespace, i.namespaces[oldestNamespace][1:])
+	i.size--
+}
+
+func (i *VectorIndex) setNamespace(namespace string, entries []*vectorEntry) {
+	if len(entries) == 0 {
+		delete(i.namespaces, namespace)
+
+		return
+	}
+
+	i.namespaces[namespace] = entries
+}
+
+func normalizeVector(vector []float64) ([]float64, bool) {
+	norm := math.Sqrt(dotProduct(vector, vector))
+	if norm == 0 {
+		return nil, false
+	}
+
+	normalized := make([]float64, len(vector))
+
+	for idx, value := range vector {
+		normalized[idx] = value / norm
+	}
+
+	return normalized, true
+}
+
+func dotProduct(a []float64, b []float64) float64 {
+	var product float64
+
+	for idx := range a {
+		product += a[idx] * b[idx]
+	}
+
+	return product
+}
@@ -1,6 +1,9 @@
 package fields
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 type Duration time.Duration
 
@@ -8,3 +11,21 @@ type Duration time.Duration
 func (d Duration) MarshalText() ([]byte, error) {
 	return []byte(time.Duration(d).String()), nil
 }
+
+// UnmarshalText parses human-friendly durations (e.g. 500ms, 10m). Plain numbers are treated as nanoseconds
+func (d *Duration) UnmarshalText(text []byte) error {
+	if nanoseconds, err := strconv.ParseInt(string(text), 10, 64); err == nil {
+		*d = Duration(nanoseconds)
+
+		return nil
+	}
+
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(duration)
+
+	return nil
+}
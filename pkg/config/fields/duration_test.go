@@ -0,0 +1,27 @@
+package fields
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration_Unmarshal(t *testing.T) {
+	var config struct {
+		TTL     Duration  `yaml:"ttl"`
+		Timeout *Duration `yaml:"timeout"`
+		Delay   Duration  `yaml:"delay"`
+	}
+
+	err := yaml.Unmarshal([]byte("ttl: 10m\ntimeout: 500ms\ndelay: 1000\n"), &config)
+	require.NoError(t, err)
+
+	require.Equal(t, Duration(10*time.Minute), config.TTL)
+	require.Equal(t, Duration(500*time.Millisecond), *config.Timeout)
+	require.Equal(t, Duration(1000), config.Delay)
+
+	require.Error(t, yaml.Unmarshal([]byte("ttl: ten minutes\n"), &config))
+}
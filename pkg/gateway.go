@@ -30,6 +30,8 @@ type Gateway struct {
 	configProvider *config.Provider
 	// tel holds logger, meter, and tracer
 	tel *telemetry.Telemetry
+	// routerManager holds routers and runs model health checks
+	routerManager *routers.RouterManager
 	// serverManager controls API over different protocols
 	serverManager *api.ServerManager
 	// signalChannel is used to receive termination signals from the OS.
@@ -62,6 +64,7 @@ func NewGateway(configProvider *config.Provider) (*Gateway, error) {
 	return &Gateway{
 		configProvider: configProvider,
 		tel:            tel,
+		routerManager:  routerManager,
 		serverManager:  serverManager,
 		signalC:        make(chan os.Signal, 3), // equal to number of signal types we expect to receive
 		shutdownC:      make(chan struct{}),
@@ -82,6 +85,8 @@ func (gw *Gateway) Run(ctx context.Context) error {
 	}
 
 	gw.configProvider.Start()
+	// models are probed before the API starts serving traffic
+	gw.routerManager.StartHealthChecks(ctx)
 	gw.serverManager.Start() //nolint:contextcheck
 
 	signal.Notify(gw.signalC, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
@@ -118,5 +123,7 @@ func (gw *Gateway) shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown servers: %w", err))
 	}
 
+	gw.routerManager.Shutdown()
+
 	return errs
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
@@ -17,20 +18,44 @@ import (
 
 // ChatRequest is an Anthropic-specific request schema
 type ChatRequest struct {
-	Model         string                `json:"model"`
-	Messages      []schemas.ChatMessage `json:"messages"`
-	System        string                `json:"system,omitempty"`
-	Temperature   float64               `json:"temperature,omitempty"`
-	TopP          float64               `json:"top_p,omitempty"`
-	TopK          int                   `json:"top_k,omitempty"`
-	MaxTokens     int                   `json:"max_tokens,omitempty"`
-	Stream        bool                  `json:"stream,omitempty"`
-	Metadata      *string               `json:"metadata,omitempty"`
-	StopSequences []string              `json:"stop_sequences,omitempty"`
+	Model         string      `json:"model"`
+	Messages      []Message   `json:"messages"`
+	System        string      `json:"system,omitempty"`
+	Temperature   *float64    `json:"temperature,omitempty"`
+	TopP          *float64    `json:"top_p,omitempty"`
+	TopK          int         `json:"top_k,omitempty"`
+	MaxTokens     int         `json:"max_tokens,omitempty"`
+	Stream        bool        `json:"stream,omitempty"`
+	Metadata      *string     `json:"metadata,omitempty"`
+	StopSequences []string    `json:"stop_sequences,omitempty"`
+	Tools         []Tool      `json:"tools,omitempty"`
+	ToolChoice    *ToolChoice `json:"tool_choice,omitempty"`
 }
 
 func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	r.Messages = params.Messages
+	system, messages := NewMessages(params.Messages)
+
+	r.Messages = messages
+
+	if len(system) > 0 {
+		r.System = system
+	}
+
+	// Anthropic has no JSON mode, so the response format is requested via the system prompt
+	if instruction := params.ResponseFormat.Instruction(); len(instruction) > 0 {
+		r.System = strings.TrimSpace(r.System + "\n\n" + instruction)
+	}
+
+	r.Tools = NewTools(params.Tools)
+	r.ToolChoice = NewToolChoice(params.ToolChoice)
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature: &r.Temperature,
+		TopP:        &r.TopP,
+		TopK:        &r.TopK,
+		MaxTokens:   &r.MaxTokens,
+		Stop:        &r.StopSequences,
+	})
 }
 
 // NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
@@ -38,13 +63,13 @@ func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
 		Model:         cfg.ModelName,
 		System:        cfg.DefaultParams.System,
-		Temperature:   cfg.DefaultParams.Temperature,
-		TopP:          cfg.DefaultParams.TopP,
+		Temperature:   schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:          schemas.DefaultParam(cfg.DefaultParams.TopP),
 		TopK:          cfg.DefaultParams.TopK,
 		MaxTokens:     cfg.DefaultParams.MaxTokens,
 		Metadata:      cfg.DefaultParams.Metadata,
 		StopSequences: cfg.DefaultParams.StopSequences,
-		Stream:        false, // unsupported right now
+		Stream:        false,
 	}
 }
 
@@ -117,13 +142,9 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
-	if len(anthropicResponse.Content) == 0 {
-		return nil, clients.ErrEmptyResponse
-	}
-
-	completion := anthropicResponse.Content[0]
+	content, toolCalls := NewToolCalls(anthropicResponse.Content)
 
-	if len(completion.Text) == 0 {
+	if len(content) == 0 && len(toolCalls) == 0 {
 		return nil, clients.ErrEmptyResponse
 	}
 
@@ -139,8 +160,9 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		ModelResponse: schemas.ModelResponse{
 			Metadata: map[string]string{},
 			Message: schemas.ChatMessage{
-				Role:    completion.Type,
-				Content: completion.Text,
+				Role:      "assistant",
+				Content:   content,
+				ToolCalls: toolCalls,
 			},
 			TokenUsage: schemas.TokenUsage{
 				PromptTokens:   usage.InputTokens,
@@ -148,6 +170,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				TotalTokens:    usage.InputTokens + usage.OutputTokens,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(anthropicResponse.StopReason),
 	}
 
 	return &response, nil
@@ -1,17 +1,310 @@
 package anthropic
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/r3labs/sse/v2"
+	"go.uber.org/zap"
 
 	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
+// ChatStream represents Anthropic chat stream for a specific request
+type ChatStream struct {
+	tel                *telemetry.Telemetry
+	client             *http.Client
+	req                *http.Request
+	resp               *http.Response
+	reader             *sse.EventStreamReader
+	messageID          string
+	modelName          string
+	promptTokens       int
+	toolCallIdx        map[int]int
+	streamFinished     bool
+	finishReasonMapper *FinishReasonMapper
+	errMapper          *ErrorMapper
+}
+
+func NewChatStream(
+	tel *telemetry.Telemetry,
+	client *http.Client,
+	req *http.Request,
+	modelName string,
+	finishReasonMapper *FinishReasonMapper,
+	errMapper *ErrorMapper,
+) *ChatStream {
+	return &ChatStream{
+		tel:                tel,
+		client:             client,
+		req:                req,
+		modelName:          modelName,
+		toolCallIdx:        make(map[int]int),
+		finishReasonMapper: finishReasonMapper,
+		errMapper:          errMapper,
+	}
+}
+
+func (s *ChatStream) Open() error {
+	resp, err := s.client.Do(s.req) //nolint:bodyclose
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s.errMapper.Map(resp)
+	}
+
+	s.resp = resp
+	s.reader = sse.NewEventStreamReader(resp.Body, 4096) // TODO: should we expose maxBufferSize?
+
+	return nil
+}
+
+func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) { //nolint:cyclop
+	if s.streamFinished {
+		return nil, io.EOF
+	}
+
+	for {
+		rawEvent, err := s.reader.ReadEvent()
+		if err != nil {
+			s.tel.L().Warn(
+				"Chat stream is unexpectedly disconnected",
+				zap.String("provider", providerName),
+				zap.Error(err),
+			)
+
+			// if err is io.EOF, this still means that the stream is interrupted unexpectedly
+			//  because the normal stream termination is done via the message_stop event
+
+			return nil, clients.ErrProviderUnavailable
+		}
+
+		s.tel.L().Debug(
+			"Raw chat stream chunk",
+			zap.String("provider", providerName),
+			zap.ByteString("rawChunk", rawEvent),
+		)
+
+		event, err := clients.ParseSSEvent(rawEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse chat stream message: %v", err)
+		}
+
+		if !event.HasContent() || len(event.Data) == 0 {
+			s.tel.L().Debug(
+				"Received an empty message in chat stream, skipping it",
+				zap.String("provider", providerName),
+				zap.Any("msg", event),
+			)
+
+			continue
+		}
+
+		var streamEvent StreamEvent
+
+		err = json.Unmarshal(event.Data, &streamEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chat stream chunk: %v", err)
+		}
+
+		switch streamEvent.Type {
+		case MessageStartEvent:
+			if streamEvent.Message != nil {
+				s.messageID = streamEvent.Message.ID
+				s.modelName = streamEvent.Message.Model
+				s.promptTokens = streamEvent.Message.Usage.InputTokens
+			}
+
+			continue
+		case ContentBlockStartEvent:
+			if streamEvent.ContentBlock == nil || streamEvent.ContentBlock.Type != ToolUseContent {
+				continue
+			}
+
+			// tool calls are indexed separately from content blocks as text blocks may be interleaved with them
+			toolCallIdx := len(s.toolCallIdx)
+			s.toolCallIdx[streamEvent.Index] = toolCallIdx
+
+			return s.toolCallChunk(schemas.ToolCall{
+				Index: &toolCallIdx,
+				ID:    streamEvent.ContentBlock.ID,
+				Type:  schemas.ToolTypeFunction,
+				Function: schemas.ToolCallFunction{
+					Name: streamEvent.ContentBlock.Name,
+				},
+			}), nil
+		case ContentBlockDeltaEvent:
+			if streamEvent.Delta == nil {
+				continue
+			}
+
+			if streamEvent.Delta.Type == InputJSONDelta {
+				toolCallIdx, found := s.toolCallIdx[streamEvent.Index]
+				if !found {
+					continue
+				}
+
+				return s.toolCallChunk(schemas.ToolCall{
+					Index: &toolCallIdx,
+					Function: schemas.ToolCallFunction{
+						Arguments: streamEvent.Delta.PartialJSON,
+					},
+				}), nil
+			}
+
+			// TODO: use objectpool here
+			return &schemas.ChatStreamChunk{
+				Cached:    false,
+				Provider:  providerName,
+				ModelName: s.modelName,
+				ModelResponse: schemas.ModelChunkResponse{
+					Metadata: &schemas.Metadata{
+						"response_id": s.messageID,
+					},
+					Message: schemas.ChatMessage{
+						Role:    "assistant",
+						Content: streamEvent.Delta.Text,
+					},
+				},
+			}, nil
+		case MessageDeltaEvent:
+			var stopReason *string
+
+			if streamEvent.Delta != nil {
+				stopReason = streamEvent.Delta.StopReason
+			}
+
+			metadata := schemas.Metadata{
+				"response_id": s.messageID,
+			}
+
+			if streamEvent.Usage != nil {
+				metadata["prompt_tokens"] = s.promptTokens
+				metadata["response_tokens"] = streamEvent.Usage.OutputTokens
+				metadata["total_tokens"] = s.promptTokens + streamEvent.Usage.OutputTokens
+			}
+
+			// TODO: use objectpool here
+			return &schemas.ChatStreamChunk{
+				Cached:    false,
+				Provider:  providerName,
+				ModelName: s.modelName,
+				ModelResponse: schemas.ModelChunkResponse{
+					Metadata: &metadata,
+					Message: schemas.ChatMessage{
+						Role:    "assistant",
+						Content: "",
+					},
+				},
+				FinishReason: s.finishReasonMapper.Map(stopReason),
+			}, nil
+		case MessageStopEvent:
+			s.streamFinished = true
+
+			return nil, io.EOF
+		case ErrorEvent:
+			if streamEvent.Error == nil {
+				return nil, clients.ErrProviderUnavailable
+			}
+
+			return nil, s.errMapper.MapStreamErr(streamEvent.Error)
+		default:
+			// content_block_stop, ping and other events don't carry any generated content
+			s.tel.L().Debug(
+				"Unsupported stream event type, skipping it",
+				zap.String("provider", providerName),
+				zap.String("eventType", streamEvent.Type),
+			)
+
+			continue
+		}
+	}
+}
+
+func (s *ChatStream) toolCallChunk(toolCall schemas.ToolCall) *schemas.ChatStreamChunk {
+	return &schemas.ChatStreamChunk{
+		Cached:    false,
+		Provider:  providerName,
+		ModelName: s.modelName,
+		ModelResponse: schemas.ModelChunkResponse{
+			Metadata: &schemas.Metadata{
+				"response_id": s.messageID,
+			},
+			Message: schemas.ChatMessage{
+				Role:      "assistant",
+				ToolCalls: []schemas.ToolCall{toolCall},
+			},
+		},
+	}
+}
+
+func (s *ChatStream) Close() error {
+	if s.resp != nil {
+		return s.resp.Body.Close()
+	}
+
+	return nil
+}
+
 func (c *Client) SupportChatStream() bool {
-	return false
+	return true
+}
+
+func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	// Create a new chat request
+	httpRequest, err := c.makeStreamReq(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChatStream(
+		c.tel,
+		c.httpClient,
+		httpRequest,
+		c.config.ModelName,
+		c.finishReasonMapper,
+		c.errMapper,
+	), nil
 }
 
-func (c *Client) ChatStream(_ context.Context, _ *schemas.ChatParams) (clients.ChatStream, error) {
-	return nil, clients.ErrChatStreamNotImplemented
+func (c *Client) makeStreamReq(ctx context.Context, params *schemas.ChatParams) (*http.Request, error) {
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate
+	chatReq.ApplyParams(params)
+
+	chatReq.Stream = true
+
+	rawPayload, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal anthropic chat stream request payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.chatURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create anthropic stream chat request: %w", err)
+	}
+
+	request.Header.Set("x-api-key", string(c.config.APIKey)) // must be in lower case
+	request.Header.Set("anthropic-version", c.apiVersion)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Cache-Control", "no-cache")
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("Connection", "keep-alive")
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.tel.L().Debug(
+		"Anthropic stream chat request",
+		zap.String("chat_url", c.chatURL),
+		zap.Any("payload", chatReq),
+	)
+
+	return request, nil
 }
@@ -0,0 +1,257 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newAnthropicStreamServer(t *testing.T, streamFile string) *httptest.Server {
+	t.Helper()
+
+	anthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		if data["stream"] != true {
+			t.Errorf("stream flag is expected to be set in the payload (%q)", string(rawPayload))
+		}
+
+		chatResponse, err := os.ReadFile(filepath.Clean(streamFile))
+		if err != nil {
+			t.Errorf("error reading anthropic chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	return httptest.NewServer(anthropicMock)
+}
+
+func TestAnthropicClient_ChatStreamSupported(t *testing.T) {
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportChatStream())
+}
+
+func TestAnthropicClient_ChatStreamRequest(t *testing.T) {
+	anthropicServer := newAnthropicStreamServer(t, "./testdata/chat_stream.success.txt")
+	defer anthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = anthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var (
+		content   string
+		lastChunk *schemas.ChatStreamChunk
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.NotNil(t, chunk)
+
+		content += chunk.ModelResponse.Message.Content
+		lastChunk = chunk
+	}
+
+	require.Equal(t, "The capital of the United Kingdom is London.", content)
+	require.NotNil(t, lastChunk)
+	require.Equal(t, "claude-3-haiku-20240307", lastChunk.ModelName)
+	require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+	require.Equal(t, 37, (*lastChunk.ModelResponse.Metadata)["total_tokens"])
+}
+
+func TestAnthropicClient_ChatStreamRequestInterrupted(t *testing.T) {
+	tests := map[string]struct {
+		streamFile  string
+		expectedErr error
+	}{
+		"stream without message stop event": {"./testdata/chat_stream.nodone.txt", clients.ErrProviderUnavailable},
+		"stream with overloaded error":      {"./testdata/chat_stream.overloaded.txt", &clients.RateLimitError{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			anthropicServer := newAnthropicStreamServer(t, tc.streamFile)
+			defer anthropicServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = anthropicServer.URL
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the capital of the United Kingdom?",
+			}}}
+
+			stream, err := client.ChatStream(ctx, &chatParams)
+			require.NoError(t, err)
+
+			err = stream.Open()
+			require.NoError(t, err)
+
+			for {
+				chunk, err := stream.Recv()
+				if err != nil {
+					require.IsType(t, tc.expectedErr, err)
+					return
+				}
+
+				require.NotNil(t, chunk)
+			}
+		})
+	}
+}
+
+func TestAnthropicClient_ChatStreamRateLimit(t *testing.T) {
+	anthropicMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Anthropic reports the cooldown delay in seconds
+		w.Header().Set("Retry-After", "30")
+
+		http.Error(w, `{"type": "error", "error": {"type": "rate_limit_error", "message": "Rate limited"}}`, http.StatusTooManyRequests)
+	})
+
+	anthropicServer := httptest.NewServer(anthropicMock)
+	defer anthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = anthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+
+	var rateLimitErr *clients.RateLimitError
+
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Equal(t, 30*time.Second, rateLimitErr.UntilReset())
+}
+
+func TestAnthropicClient_ChatStreamToolUse(t *testing.T) {
+	anthropicServer := newAnthropicStreamServer(t, "./testdata/chat_stream.tool_use.txt")
+	defer anthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = anthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the weather in London?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var (
+		toolCalls []schemas.ToolCall
+		lastChunk *schemas.ChatStreamChunk
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		toolCalls = append(toolCalls, chunk.ModelResponse.Message.ToolCalls...)
+		lastChunk = chunk
+	}
+
+	require.Len(t, toolCalls, 4)
+	require.Equal(t, "toolu_01T1x1fJ34qAmk2tNTrN7Up6", toolCalls[0].ID)
+	require.Equal(t, "get_weather", toolCalls[0].Function.Name)
+
+	arguments := ""
+
+	for _, toolCall := range toolCalls {
+		require.Equal(t, 0, *toolCall.Index)
+
+		arguments += toolCall.Function.Arguments
+	}
+
+	require.JSONEq(t, `{"location": "London, UK"}`, arguments)
+	require.Equal(t, &schemas.ReasonToolCalls, lastChunk.FinishReason)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -21,6 +23,7 @@ type Client struct {
 	apiVersion          string
 	chatRequestTemplate *ChatRequest
 	errMapper           *ErrorMapper
+	finishReasonMapper  *FinishReasonMapper
 	config              *Config
 	httpClient          *http.Client
 	tel                 *telemetry.Telemetry
@@ -40,6 +43,7 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
 		errMapper:           NewErrorMapper(tel),
+		finishReasonMapper:  NewFinishReasonMapper(tel),
 		httpClient: &http.Client{
 			Timeout: time.Duration(*clientConfig.Timeout),
 			Transport: &http.Transport{
@@ -60,3 +64,19 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage || modality == schemas.ModalityDocument
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamTopK,
+		schemas.ParamMaxTokens, schemas.ParamStop:
+		return true
+	default:
+		return false
+	}
+}
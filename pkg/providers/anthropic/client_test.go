@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
@@ -67,6 +68,127 @@ func TestAnthropicClient_ChatRequest(t *testing.T) {
 	require.Equal(t, "msg_013Zva2CMHLNnXjNJJKqJ2EF", response.ID)
 }
 
+func TestAnthropicClient_ChatRequestGenerationParams(t *testing.T) {
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, 0.7, data["temperature"])
+		require.Equal(t, 20.0, data["top_k"])
+		require.Equal(t, 1024.0, data["max_tokens"])
+		require.Equal(t, []interface{}{"Human:"}, data["stop_sequences"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading anthropic chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	temperature := 0.7
+	topK := 20
+	maxTokens := 1024
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{
+			Role:    "user",
+			Content: "What's the biggest animal?",
+		}},
+		GenerationParams: schemas.GenerationParams{
+			Temperature: &temperature,
+			TopK:        &topK,
+			MaxTokens:   &maxTokens,
+			Stop:        schemas.StopWords{"Human:"},
+		},
+	}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
+
+func TestAnthropicClient_ChatRequestResponseFormat(t *testing.T) {
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		system := data["system"].(string)
+
+		require.True(t, strings.HasPrefix(system, "You are a geography teacher"))
+		require.Contains(t, system, `{"required":["capital"],"type":"object"}`)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading anthropic chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{
+			{Role: "system", Content: "You are a geography teacher"},
+			{Role: "user", Content: "What's the capital of the United Kingdom?"},
+		},
+		ResponseFormat: &schemas.ResponseFormat{
+			Type: schemas.ResponseFormatJSONSchema,
+			JSONSchema: &schemas.JSONSchemaFormat{
+				Name:   "capital",
+				Schema: map[string]any{"type": "object", "required": []any{"capital"}},
+			},
+		},
+	}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
+
 func TestAnthropicClient_BadChatRequest(t *testing.T) {
 	// Anthropic Messages API: https://docs.anthropic.com/claude/reference/messages_post
 	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -102,3 +224,145 @@ func TestAnthropicClient_BadChatRequest(t *testing.T) {
 	// Assert that the response is nil
 	require.Nil(t, response)
 }
+
+func TestAnthropicClient_ChatRequestToolUse(t *testing.T) {
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, "You are a weather assistant.", data.System)
+		require.Len(t, data.Messages, 3)
+		require.Equal(t, ToolUseContent, data.Messages[1].Content[0].Type)
+		require.JSONEq(t, `{"location": "Paris"}`, string(data.Messages[1].Content[0].Input))
+		require.Equal(t, "user", data.Messages[2].Role)
+		require.Equal(t, ToolResultContent, data.Messages[2].Content[0].Type)
+		require.Equal(t, "toolu_01", data.Messages[2].Content[0].ToolUseID)
+		require.Len(t, data.Tools, 1)
+		require.Equal(t, "get_weather", data.Tools[0].Name)
+		require.Equal(t, &ToolChoice{Type: ToolChoiceAny}, data.ToolChoice)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.tool_use.json"))
+		if err != nil {
+			t.Errorf("error reading anthropic chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{
+			{Role: "system", Content: "You are a weather assistant."},
+			{Role: "user", Content: "What's the weather in Paris?"},
+			{Role: "assistant", ToolCalls: []schemas.ToolCall{{
+				ID:       "toolu_01",
+				Type:     schemas.ToolTypeFunction,
+				Function: schemas.ToolCallFunction{Name: "get_weather", Arguments: `{"location": "Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "toolu_01", Content: "15 degrees, cloudy"},
+		},
+		Tools: []schemas.Tool{{
+			Type: schemas.ToolTypeFunction,
+			Function: schemas.ToolFunction{
+				Name:       "get_weather",
+				Parameters: map[string]any{"type": "object", "properties": map[string]any{"location": map[string]any{"type": "string"}}},
+			},
+		}},
+		ToolChoice: &schemas.ToolChoice{Mode: schemas.ToolChoiceRequired},
+	}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	message := response.ModelResponse.Message
+
+	require.Equal(t, &schemas.ReasonToolCalls, response.FinishReason)
+	require.Equal(t, "Let me check the weather in London.", message.Content)
+	require.Len(t, message.ToolCalls, 1)
+	require.Equal(t, "toolu_01A09q90qw90lq917835lq9", message.ToolCalls[0].ID)
+	require.Equal(t, "get_weather", message.ToolCalls[0].Function.Name)
+	require.JSONEq(t, `{"location": "London, UK"}`, message.ToolCalls[0].Function.Arguments)
+}
+
+func TestAnthropicClient_ChatRequestMultimodal(t *testing.T) {
+	AnthropicMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		blocks := data.Messages[0].Content
+
+		require.Len(t, blocks, 4)
+		require.Equal(t, ContentBlock{Type: TextContent, Text: "Compare these"}, blocks[0])
+		require.Equal(t, &Source{Type: Base64Source, MediaType: "image/png", Data: "iVBORw0KGgo="}, blocks[1].Source)
+		require.Equal(t, &Source{Type: URLSource, URL: "https://example.com/cat.jpg"}, blocks[2].Source)
+		require.Equal(t, DocumentContent, blocks[3].Type)
+		require.Equal(t, &Source{Type: Base64Source, MediaType: "application/pdf", Data: "JVBERi0="}, blocks[3].Source)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading anthropic chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	AnthropicServer := httptest.NewServer(AnthropicMock)
+	defer AnthropicServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = AnthropicServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportModality(schemas.ModalityDocument))
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role: "user",
+		ContentParts: []schemas.ContentPart{
+			{Type: schemas.ContentPartText, Text: "Compare these"},
+			{Type: schemas.ContentPartImageURL, ImageURL: &schemas.ImageURL{URL: "data:image/png;base64,iVBORw0KGgo="}},
+			{Type: schemas.ContentPartImageURL, ImageURL: &schemas.ImageURL{URL: "https://example.com/cat.jpg"}},
+			{Type: schemas.ContentPartDocument, Document: &schemas.Document{MediaType: "application/pdf", Data: "JVBERi0="}},
+		},
+	}}}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
@@ -0,0 +1,44 @@
+package anthropic
+
+import (
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// NewContentBlocks maps the message content to Anthropic content blocks
+// Ref: https://docs.anthropic.com/en/docs/build-with-claude/vision
+func NewContentBlocks(message *schemas.ChatMessage) []ContentBlock {
+	if !message.IsMultimodal() {
+		return []ContentBlock{{Type: TextContent, Text: message.Content}}
+	}
+
+	blocks := make([]ContentBlock, 0, len(message.ContentParts))
+
+	for _, part := range message.ContentParts {
+		switch part.Type {
+		case schemas.ContentPartImageURL:
+			blocks = append(blocks, ContentBlock{Type: ImageContent, Source: newImageSource(part.ImageURL)})
+		case schemas.ContentPartDocument:
+			blocks = append(blocks, ContentBlock{Type: DocumentContent, Source: newDocumentSource(part.Document)})
+		default:
+			blocks = append(blocks, ContentBlock{Type: TextContent, Text: part.Text})
+		}
+	}
+
+	return blocks
+}
+
+func newImageSource(image *schemas.ImageURL) *Source {
+	if mediaType, data, ok := image.Data(); ok {
+		return &Source{Type: Base64Source, MediaType: mediaType, Data: data}
+	}
+
+	return &Source{Type: URLSource, URL: image.URL}
+}
+
+func newDocumentSource(document *schemas.Document) *Source {
+	if len(document.Data) > 0 {
+		return &Source{Type: Base64Source, MediaType: document.MediaType, Data: document.Data}
+	}
+
+	return &Source{Type: URLSource, URL: document.URL}
+}
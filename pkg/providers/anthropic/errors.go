@@ -1,10 +1,8 @@
 package anthropic
 
 import (
-	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	"github.com/EinStack/glide/pkg/telemetry"
 
@@ -12,6 +10,17 @@ import (
 	"go.uber.org/zap"
 )
 
+// StatusOverloaded is returned when Anthropic API is temporarily overloaded
+const StatusOverloaded = 529
+
+var (
+	// Reference: https://docs.anthropic.com/en/api/errors
+
+	RateLimitErrorType      = "rate_limit_error"
+	OverloadedErrorType     = "overloaded_error"
+	AuthenticationErrorType = "authentication_error"
+)
+
 type ErrorMapper struct {
 	tel *telemetry.Telemetry
 }
@@ -36,22 +45,38 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 	)
 
 	if resp.StatusCode == http.StatusTooManyRequests {
-		// Read the value of the "Retry-After" header to get the cooldown delay
-		retryAfter := resp.Header.Get("Retry-After")
-
-		// Parse the value to get the duration
-		cooldownDelay, err := time.ParseDuration(retryAfter)
-		if err != nil {
-			return fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-		}
+		// Anthropic reports the cooldown delay in seconds. The default one is used when it's not reported
+		return clients.NewRateLimitError(clients.ResetDelayFromHeaders(resp.Header, "Retry-After"))
+	}
 
-		return clients.NewRateLimitError(&cooldownDelay)
+	if resp.StatusCode == StatusOverloaded {
+		// overloaded responses don't come with any cooldown hints
+		return clients.NewRateLimitError(nil)
 	}
 
 	if resp.StatusCode == http.StatusUnauthorized {
 		return clients.ErrUnauthorized
 	}
 
-	// Server & client errors result in the same error to keep gateway resilient
-	return clients.ErrProviderUnavailable
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	return clients.NewResponseError(resp.StatusCode, bodyBytes)
+}
+
+// MapStreamErr maps error events that may occur in the middle of the chat stream
+func (m *ErrorMapper) MapStreamErr(streamErr *StreamError) error {
+	m.tel.Logger.Error(
+		"anthropic chat stream failed",
+		zap.String("error_type", streamErr.Type),
+		zap.String("error_message", streamErr.Message),
+	)
+
+	switch streamErr.Type {
+	case RateLimitErrorType, OverloadedErrorType:
+		return clients.NewRateLimitError(nil)
+	case AuthenticationErrorType:
+		return clients.ErrUnauthorized
+	default:
+		return clients.ErrProviderUnavailable
+	}
 }
@@ -0,0 +1,53 @@
+package anthropic
+
+import (
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"go.uber.org/zap"
+)
+
+var (
+	// Reference: https://docs.anthropic.com/en/api/messages (see stop_reason)
+
+	EndTurnReason      = "end_turn"
+	StopSequenceReason = "stop_sequence"
+	MaxTokensReason    = "max_tokens"
+	ToolUseReason      = "tool_use"
+)
+
+func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
+	return &FinishReasonMapper{
+		tel: tel,
+	}
+}
+
+type FinishReasonMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func (m *FinishReasonMapper) Map(finishReason *string) *schemas.FinishReason {
+	if finishReason == nil || len(*finishReason) == 0 {
+		return nil
+	}
+
+	var reason *schemas.FinishReason
+
+	switch *finishReason {
+	case EndTurnReason, StopSequenceReason:
+		reason = &schemas.ReasonComplete
+	case MaxTokensReason:
+		reason = &schemas.ReasonMaxTokens
+	case ToolUseReason:
+		reason = &schemas.ReasonToolCalls
+	default:
+		m.tel.Logger.Warn(
+			"Unknown finish reason, other is going to used",
+			zap.String("provider", providerName),
+			zap.String("unknown_reason", *finishReason),
+		)
+
+		reason = &schemas.ReasonOther
+	}
+
+	return reason
+}
@@ -1,8 +1,68 @@
 package anthropic
 
+import "encoding/json"
+
+// Content block types
+var (
+	TextContent       = "text"
+	ToolUseContent    = "tool_use"
+	ToolResultContent = "tool_result"
+	ImageContent      = "image"
+	DocumentContent   = "document"
+)
+
+// Content source types
+var (
+	Base64Source = "base64"
+	URLSource    = "url"
+)
+
+// Content is a content block of the chat response. Tool use blocks carry the tool call instead of text
 type Content struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text"`
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty"`
+}
+
+// Message is a message in the Anthropic chat request
+type Message struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+}
+
+// ContentBlock is a content block of the request message (text, tool use or tool result)
+type ContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	Source    *Source         `json:"source,omitempty"`
+}
+
+// Source is a source of image or document content blocks
+type Source struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// Tool is a tool definition the model may use
+// Ref: https://docs.anthropic.com/en/docs/build-with-claude/tool-use
+type Tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type ToolChoice struct {
 	Type string `json:"type"`
-	Text string `json:"text"`
+	Name string `json:"name,omitempty"`
 }
 
 type Usage struct {
@@ -17,7 +77,53 @@ type ChatCompletion struct {
 	Model        string    `json:"model"`
 	Role         string    `json:"role"`
 	Content      []Content `json:"content"`
-	StopReason   string    `json:"stop_reason"`
-	StopSequence string    `json:"stop_sequence"`
+	StopReason   *string   `json:"stop_reason"`
+	StopSequence *string   `json:"stop_sequence"`
 	Usage        Usage     `json:"usage"`
 }
+
+// Anthropic streams chat responses as typed Server-Sent Events
+// Ref: https://docs.anthropic.com/en/api/messages-streaming
+type StreamEventType = string
+
+var (
+	MessageStartEvent      StreamEventType = "message_start"
+	ContentBlockStartEvent StreamEventType = "content_block_start"
+	ContentBlockDeltaEvent StreamEventType = "content_block_delta"
+	ContentBlockStopEvent  StreamEventType = "content_block_stop"
+	MessageDeltaEvent      StreamEventType = "message_delta"
+	MessageStopEvent       StreamEventType = "message_stop"
+	PingEvent              StreamEventType = "ping"
+	ErrorEvent             StreamEventType = "error"
+)
+
+// StreamEvent is a union of all Anthropic chat stream events
+type StreamEvent struct {
+	Type         StreamEventType `json:"type"`
+	Index        int             `json:"index"`
+	Message      *ChatCompletion `json:"message,omitempty"`
+	ContentBlock *Content        `json:"content_block,omitempty"`
+	Delta        *StreamDelta    `json:"delta,omitempty"`
+	Usage        *Usage          `json:"usage,omitempty"`
+	Error        *StreamError    `json:"error,omitempty"`
+}
+
+// Content block delta types
+var (
+	TextDelta      = "text_delta"
+	InputJSONDelta = "input_json_delta"
+)
+
+// StreamDelta holds either a delta of a content block (text or tool input) or a message-level delta
+type StreamDelta struct {
+	Type         string  `json:"type"`
+	Text         string  `json:"text"`
+	PartialJSON  string  `json:"partial_json"`
+	StopReason   *string `json:"stop_reason"`
+	StopSequence *string `json:"stop_sequence"`
+}
+
+type StreamError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
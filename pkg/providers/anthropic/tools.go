@@ -0,0 +1,159 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// Anthropic tool choice types
+var (
+	ToolChoiceAuto = "auto"
+	ToolChoiceAny  = "any"
+	ToolChoiceTool = "tool"
+	ToolChoiceNone = "none"
+)
+
+// emptyToolInput is used when the tool is called without arguments as Anthropic requires the input to be an object
+var emptyToolInput = json.RawMessage("{}")
+
+// emptyInputSchema is used for tools without parameters as Anthropic requires the input schema to be defined
+var emptyInputSchema = map[string]any{"type": "object", "properties": map[string]any{}}
+
+// NewTools maps Glide's tool definitions to Anthropic ones
+func NewTools(tools []schemas.Tool) []Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	anthropicTools := make([]Tool, 0, len(tools))
+
+	for _, tool := range tools {
+		inputSchema := tool.Function.Parameters
+		if inputSchema == nil {
+			inputSchema = emptyInputSchema
+		}
+
+		anthropicTools = append(anthropicTools, Tool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: inputSchema,
+		})
+	}
+
+	return anthropicTools
+}
+
+// NewToolChoice maps Glide's tool choice to Anthropic one
+func NewToolChoice(choice *schemas.ToolChoice) *ToolChoice {
+	if choice == nil {
+		return nil
+	}
+
+	switch choice.Mode {
+	case schemas.ToolChoiceNone:
+		return &ToolChoice{Type: ToolChoiceNone}
+	case schemas.ToolChoiceRequired:
+		return &ToolChoice{Type: ToolChoiceAny}
+	case schemas.ToolChoiceFunction:
+		return &ToolChoice{Type: ToolChoiceTool, Name: choice.FunctionName}
+	default:
+		return &ToolChoice{Type: ToolChoiceAuto}
+	}
+}
+
+// NewMessages maps Glide's chat messages to Anthropic ones.
+//
+//	System messages are moved to the system prompt as Anthropic doesn't accept them in the message list.
+//	Tool results are sent as user messages, so consecutive results are grouped into one message
+func NewMessages(messages []schemas.ChatMessage) (string, []Message) {
+	systemPrompts := make([]string, 0, 1)
+	anthropicMessages := make([]Message, 0, len(messages))
+
+	for _, message := range messages {
+		switch message.Role {
+		case "system":
+			systemPrompts = append(systemPrompts, message.Content)
+		case "tool":
+			resultBlock := ContentBlock{
+				Type:      ToolResultContent,
+				ToolUseID: message.ToolCallID,
+				Content:   message.Content,
+			}
+
+			lastIdx := len(anthropicMessages) - 1
+
+			if lastIdx >= 0 && isToolResultMessage(&anthropicMessages[lastIdx]) {
+				anthropicMessages[lastIdx].Content = append(anthropicMessages[lastIdx].Content, resultBlock)
+
+				continue
+			}
+
+			anthropicMessages = append(anthropicMessages, Message{Role: "user", Content: []ContentBlock{resultBlock}})
+		case "assistant":
+			blocks := make([]ContentBlock, 0, len(message.ToolCalls)+1)
+
+			if len(message.Content) > 0 {
+				blocks = append(blocks, ContentBlock{Type: TextContent, Text: message.Content})
+			}
+
+			for _, toolCall := range message.ToolCalls {
+				input := json.RawMessage(toolCall.Function.Arguments)
+				if len(strings.TrimSpace(toolCall.Function.Arguments)) == 0 {
+					input = emptyToolInput
+				}
+
+				blocks = append(blocks, ContentBlock{
+					Type:  ToolUseContent,
+					ID:    toolCall.ID,
+					Name:  toolCall.Function.Name,
+					Input: input,
+				})
+			}
+
+			anthropicMessages = append(anthropicMessages, Message{Role: "assistant", Content: blocks})
+		default:
+			anthropicMessages = append(anthropicMessages, Message{
+				Role:    "user",
+				Content: NewContentBlocks(&message),
+			})
+		}
+	}
+
+	return strings.Join(systemPrompts, "\n"), anthropicMessages
+}
+
+func isToolResultMessage(message *Message) bool {
+	return message.Role == "user" && len(message.Content) > 0 && message.Content[0].Type == ToolResultContent
+}
+
+// NewToolCalls collects text and tool calls from response content blocks
+func NewToolCalls(content []Content) (string, []schemas.ToolCall) {
+	var text strings.Builder
+
+	var toolCalls []schemas.ToolCall
+
+	for _, block := range content {
+		switch block.Type {
+		case ToolUseContent:
+			arguments := string(block.Input)
+			if len(arguments) == 0 {
+				arguments = string(emptyToolInput)
+			}
+
+			toolCalls = append(toolCalls, schemas.ToolCall{
+				ID:   block.ID,
+				Type: schemas.ToolTypeFunction,
+				Function: schemas.ToolCallFunction{
+					Name:      block.Name,
+					Arguments: arguments,
+				},
+			})
+		default:
+			text.WriteString(block.Text)
+		}
+	}
+
+	return text.String(), toolCalls
+}
@@ -20,14 +20,14 @@ import (
 // NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
 func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
-		Temperature:      cfg.DefaultParams.Temperature,
-		TopP:             cfg.DefaultParams.TopP,
+		Temperature:      schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:             schemas.DefaultParam(cfg.DefaultParams.TopP),
 		MaxTokens:        cfg.DefaultParams.MaxTokens,
 		N:                cfg.DefaultParams.N,
 		StopWords:        cfg.DefaultParams.StopWords,
 		Stream:           false,
-		FrequencyPenalty: cfg.DefaultParams.FrequencyPenalty,
-		PresencePenalty:  cfg.DefaultParams.PresencePenalty,
+		FrequencyPenalty: schemas.DefaultParam(float64(cfg.DefaultParams.FrequencyPenalty)),
+		PresencePenalty:  schemas.DefaultParam(float64(cfg.DefaultParams.PresencePenalty)),
 		LogitBias:        cfg.DefaultParams.LogitBias,
 		User:             cfg.DefaultParams.User,
 		Seed:             cfg.DefaultParams.Seed,
@@ -105,7 +105,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 
 	modelChoice := chatCompletion.Choices[0]
 
-	if len(modelChoice.Message.Content) == 0 {
+	if len(modelChoice.Message.Content) == 0 && !modelChoice.Message.HasToolCalls() {
 		return nil, clients.ErrEmptyResponse
 	}
 
@@ -119,8 +119,9 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		ModelResponse: schemas.ModelResponse{
 			Metadata: map[string]string{},
 			Message: schemas.ChatMessage{
-				Role:    modelChoice.Message.Role,
-				Content: modelChoice.Message.Content,
+				Role:      modelChoice.Message.Role,
+				Content:   modelChoice.Message.Content,
+				ToolCalls: modelChoice.Message.ToolCalls,
 			},
 			TokenUsage: schemas.TokenUsage{
 				PromptTokens:   chatCompletion.Usage.PromptTokens,
@@ -128,6 +129,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				TotalTokens:    chatCompletion.Usage.TotalTokens,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(modelChoice.FinishReason),
 	}
 
 	return &response, nil
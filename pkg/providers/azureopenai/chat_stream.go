@@ -68,8 +68,6 @@ func (s *ChatStream) Open() error {
 
 // Recv receives a chat stream chunk from the ChatStream and returns a ChatStreamChunk object.
 func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
-	var completionChunk ChatCompletionChunk
-
 	for {
 		rawEvent, err := s.reader.ReadEvent()
 		if err != nil {
@@ -116,11 +114,24 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 			continue
 		}
 
+		// a new chunk is decoded into a fresh struct, so tool call deltas don't leak into following chunks
+		var completionChunk ChatCompletionChunk
+
 		err = json.Unmarshal(event.Data, &completionChunk)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal AzureOpenAI chat stream chunk: %v", err)
 		}
 
+		if len(completionChunk.Choices) == 0 {
+			s.tel.L().Debug(
+				"Received a chunk without choices in chat stream, skipping it",
+				zap.String("provider", providerName),
+				zap.ByteString("chunk", event.Data),
+			)
+
+			continue
+		}
+
 		responseChunk := completionChunk.Choices[0]
 
 		// TODO: use objectpool here
@@ -134,8 +145,9 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 					"system_fingerprint": completionChunk.SystemFingerprint,
 				},
 				Message: schemas.ChatMessage{
-					Role:    responseChunk.Delta.Role,
-					Content: responseChunk.Delta.Content,
+					Role:      responseChunk.Delta.Role,
+					Content:   responseChunk.Delta.Content,
+					ToolCalls: responseChunk.Delta.ToolCalls,
 				},
 			},
 			FinishReason: s.finishReasonMapper.Map(responseChunk.FinishReason),
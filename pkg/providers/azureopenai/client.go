@@ -10,6 +10,8 @@ import (
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -20,6 +22,7 @@ const (
 type Client struct {
 	baseURL             string // The name of your Azure OpenAI Resource (e.g https://glide-test.openai.azure.com/)
 	chatURL             string
+	embedURL            string
 	chatRequestTemplate *ChatRequest
 	finishReasonMapper  *openai.FinishReasonMapper
 	errMapper           *ErrorMapper
@@ -37,9 +40,17 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		providerConfig.APIVersion,
 	)
 
+	embedURL := fmt.Sprintf(
+		"%s/openai/deployments/%s/embeddings?api-version=%s",
+		providerConfig.BaseURL,
+		providerConfig.ModelName,
+		providerConfig.APIVersion,
+	)
+
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		embedURL:            embedURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
 		finishReasonMapper:  openai.NewFinishReasonMapper(tel),
@@ -64,3 +75,19 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens, schemas.ParamStop,
+		schemas.ParamSeed, schemas.ParamFrequencyPenalty, schemas.ParamPresencePenalty:
+		return true
+	default:
+		return false
+	}
+}
@@ -1,25 +1,27 @@
 package azureopenai
 
 import (
+	"github.com/EinStack/glide/pkg/api/schemas"
 	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers/openai"
 )
 
 // Params defines OpenAI-specific model params with the specific validation of values
 // TODO: Add validations
 type Params struct {
-	Temperature      float64          `yaml:"temperature,omitempty" json:"temperature"`
-	TopP             float64          `yaml:"top_p,omitempty" json:"top_p"`
-	MaxTokens        int              `yaml:"max_tokens,omitempty" json:"max_tokens"`
-	N                int              `yaml:"n,omitempty" json:"n"`
-	StopWords        []string         `yaml:"stop,omitempty" json:"stop"`
-	FrequencyPenalty int              `yaml:"frequency_penalty,omitempty" json:"frequency_penalty"`
-	PresencePenalty  int              `yaml:"presence_penalty,omitempty" json:"presence_penalty"`
-	LogitBias        *map[int]float64 `yaml:"logit_bias,omitempty" json:"logit_bias"`
-	User             *string          `yaml:"user,omitempty" json:"user"`
-	Seed             *int             `yaml:"seed,omitempty" json:"seed"`
-	Tools            []string         `yaml:"tools,omitempty" json:"tools"`
-	ToolChoice       interface{}      `yaml:"tool_choice,omitempty" json:"tool_choice"`
-	ResponseFormat   interface{}      `yaml:"response_format,omitempty" json:"response_format"` // TODO: should this be a part of the chat request API?
+	Temperature      float64                `yaml:"temperature,omitempty" json:"temperature"`
+	TopP             float64                `yaml:"top_p,omitempty" json:"top_p"`
+	MaxTokens        int                    `yaml:"max_tokens,omitempty" json:"max_tokens"`
+	N                int                    `yaml:"n,omitempty" json:"n"`
+	StopWords        []string               `yaml:"stop,omitempty" json:"stop"`
+	FrequencyPenalty int                    `yaml:"frequency_penalty,omitempty" json:"frequency_penalty"`
+	PresencePenalty  int                    `yaml:"presence_penalty,omitempty" json:"presence_penalty"`
+	LogitBias        *map[int]float64       `yaml:"logit_bias,omitempty" json:"logit_bias"`
+	User             *string                `yaml:"user,omitempty" json:"user"`
+	Seed             *int                   `yaml:"seed,omitempty" json:"seed"`
+	Tools            []schemas.Tool         `yaml:"tools,omitempty" json:"tools"`
+	ToolChoice       interface{}            `yaml:"tool_choice,omitempty" json:"tool_choice"`
+	ResponseFormat   *openai.ResponseFormat `yaml:"response_format,omitempty" json:"response_format"`
 }
 
 func DefaultParams() Params {
@@ -29,7 +31,7 @@ func DefaultParams() Params {
 		MaxTokens:   100,
 		N:           1,
 		StopWords:   []string{},
-		Tools:       []string{},
+		Tools:       []schemas.Tool{},
 	}
 }
 
@@ -0,0 +1,81 @@
+package azureopenai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/providers/openai"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// maxEmbedBatchSize is the max number of inputs Azure OpenAI accepts in one embedding request
+const maxEmbedBatchSize = 2048
+
+// MaxEmbedBatchSize returns the max number of texts that can be embedded in one request
+func (c *Client) MaxEmbedBatchSize() int {
+	return maxEmbedBatchSize
+}
+
+// Embed sends an embedding request to the specified Azure OpenAI deployment.
+// The deployment defines the model, so it's not passed in the payload
+func (c *Client) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	rawPayload, err := json.Marshal(openai.EmbedRequest{Input: params.Input, EncodingFormat: "float"})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal azure openai embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create azure openai embed request: %w", err)
+	}
+
+	req.Header.Set("api-key", string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	c.tel.Logger.Debug(
+		"azure openai embed request",
+		zap.String("embed_url", c.embedURL),
+		zap.Int("inputs", len(params.Input)),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send azure openai embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read azure openai embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var embedResponse openai.EmbedResponse
+
+	err = json.Unmarshal(bodyBytes, &embedResponse)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse azure openai embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(embedResponse.Data) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	return openai.NewEmbedResponse(providerName, &embedResponse), nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/providers/openai"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +53,10 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 		return clients.ErrUnauthorized
 	}
 
-	// Server & client errors result in the same error to keep gateway resilient
-	return clients.ErrProviderUnavailable
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	providerErr := clients.NewResponseError(resp.StatusCode, bodyBytes)
+	providerErr.ContentFiltered = openai.IsContentFilterErr(bodyBytes)
+
+	return providerErr
 }
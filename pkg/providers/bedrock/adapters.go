@@ -0,0 +1,91 @@
+package bedrock
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// ModelFamily defines a family of foundation models hosted on Bedrock.
+//
+//	Each family comes with its own request & response schemas
+//	Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters.html
+type ModelFamily = string
+
+const (
+	TitanFamily   ModelFamily = "amazon"
+	ClaudeFamily  ModelFamily = "anthropic"
+	LlamaFamily   ModelFamily = "meta"
+	MistralFamily ModelFamily = "mistral"
+	CommandFamily ModelFamily = "cohere"
+)
+
+// crossRegionPrefixes are prepended to model IDs of cross-region inference profiles (e.g. us.anthropic.claude-3-haiku-20240307-v1:0)
+var crossRegionPrefixes = []string{"us", "eu", "apac"}
+
+var ErrModelFamilyNotSupported = errors.New("bedrock model family is not supported")
+
+// ModelResult is a family-agnostic representation of the model response
+type ModelResult struct {
+	Content        string
+	FinishReason   *string
+	PromptTokens   int
+	ResponseTokens int
+}
+
+// ModelChunk is a family-agnostic representation of the model response chunk
+type ModelChunk struct {
+	Content      string
+	FinishReason *string
+}
+
+// Adapter converts Glide's chat params into the family-specific request and the family-specific responses back
+type Adapter interface {
+	ChatRequest(params *schemas.ChatParams) any
+	ChatResponse(rawResponse []byte) (*ModelResult, error)
+	ChatStreamChunk(rawChunk []byte) (*ModelChunk, error)
+}
+
+// WithFormatInstruction requests the response format via the format instruction as Bedrock models have no common JSON mode
+func WithFormatInstruction(params *schemas.ChatParams) *schemas.ChatParams {
+	if !params.ResponseFormat.IsJSON() {
+		return params
+	}
+
+	formattedParams := *params
+	formattedParams.Messages = schemas.WithFormatInstruction(params.Messages, params.ResponseFormat)
+
+	return &formattedParams
+}
+
+// ModelFamilyOf resolves the model family from the Bedrock model ID (e.g. meta.llama3-8b-instruct-v1:0)
+func ModelFamilyOf(modelID string) ModelFamily {
+	parts := strings.Split(modelID, ".")
+
+	if len(parts) > 2 && slices.Contains(crossRegionPrefixes, parts[0]) {
+		return parts[1]
+	}
+
+	return parts[0]
+}
+
+// NewAdapter picks the adapter based on the model family
+func NewAdapter(modelID string, params *Params) (Adapter, error) {
+	switch ModelFamilyOf(modelID) {
+	case TitanFamily:
+		return NewTitanAdapter(params), nil
+	case ClaudeFamily:
+		return NewClaudeAdapter(params), nil
+	case LlamaFamily:
+		return NewLlamaAdapter(modelID, params), nil
+	case MistralFamily:
+		return NewMistralAdapter(params), nil
+	case CommandFamily:
+		return NewCommandAdapter(params), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrModelFamilyNotSupported, modelID)
+	}
+}
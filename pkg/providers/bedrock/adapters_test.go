@@ -0,0 +1,130 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/stretchr/testify/require"
+)
+
+var conversation = &schemas.ChatParams{Messages: []schemas.ChatMessage{
+	{Role: "system", Content: "You are a helpful assistant"},
+	{Role: "user", Content: "Hi"},
+	{Role: "assistant", Content: "Hello!"},
+	{Role: "user", Content: "What's the capital of the United Kingdom?"},
+}}
+
+func TestModelFamilyOf(t *testing.T) {
+	tests := map[string]ModelFamily{
+		"amazon.titan-text-express-v1":              TitanFamily,
+		"anthropic.claude-3-haiku-20240307-v1:0":    ClaudeFamily,
+		"us.anthropic.claude-3-haiku-20240307-v1:0": ClaudeFamily,
+		"meta.llama3-8b-instruct-v1:0":              LlamaFamily,
+		"mistral.mistral-7b-instruct-v0:2":          MistralFamily,
+		"cohere.command-r-v1:0":                     CommandFamily,
+	}
+
+	for modelID, family := range tests {
+		require.Equal(t, family, ModelFamilyOf(modelID), modelID)
+	}
+}
+
+func TestClaudeAdapter_ChatRequest(t *testing.T) {
+	params := DefaultParams()
+	req := NewClaudeAdapter(&params).ChatRequest(conversation).(*ClaudeChatRequest)
+
+	require.Equal(t, "You are a helpful assistant", req.System)
+	require.Len(t, req.Messages, 3)
+	require.Equal(t, "user", req.Messages[0].Role)
+}
+
+func TestLlamaAdapter_ChatRequest(t *testing.T) {
+	params := DefaultParams()
+
+	llama3Req := NewLlamaAdapter("meta.llama3-8b-instruct-v1:0", &params).ChatRequest(conversation).(*LlamaChatRequest)
+	require.Equal(
+		t,
+		"<|begin_of_text|>"+
+			"<|start_header_id|>system<|end_header_id|>\n\nYou are a helpful assistant<|eot_id|>"+
+			"<|start_header_id|>user<|end_header_id|>\n\nHi<|eot_id|>"+
+			"<|start_header_id|>assistant<|end_header_id|>\n\nHello!<|eot_id|>"+
+			"<|start_header_id|>user<|end_header_id|>\n\nWhat's the capital of the United Kingdom?<|eot_id|>"+
+			"<|start_header_id|>assistant<|end_header_id|>\n\n",
+		llama3Req.Prompt,
+	)
+
+	llama2Req := NewLlamaAdapter("meta.llama2-13b-chat-v1", &params).ChatRequest(conversation).(*LlamaChatRequest)
+	require.Equal(
+		t,
+		"<s>[INST] <<SYS>>\nYou are a helpful assistant\n<</SYS>>\n\nHi [/INST] Hello! </s>"+
+			"<s>[INST] What's the capital of the United Kingdom? [/INST]",
+		llama2Req.Prompt,
+	)
+}
+
+func TestMistralAdapter_ChatRequest(t *testing.T) {
+	params := DefaultParams()
+	req := NewMistralAdapter(&params).ChatRequest(conversation).(*MistralChatRequest)
+
+	require.Equal(
+		t,
+		"<s>[INST] You are a helpful assistant\n\nHi [/INST] Hello!</s>[INST] What's the capital of the United Kingdom? [/INST]",
+		req.Prompt,
+	)
+}
+
+func TestCommandAdapter_ChatRequest(t *testing.T) {
+	params := DefaultParams()
+	req := NewCommandAdapter(&params).ChatRequest(conversation).(*CommandChatRequest)
+
+	require.Equal(t, "What's the capital of the United Kingdom?", req.Message)
+	require.Equal(t, "You are a helpful assistant", req.Preamble)
+	require.Equal(t, []CommandChatMessage{{Role: "USER", Message: "Hi"}, {Role: "CHATBOT", Message: "Hello!"}}, req.ChatHistory)
+}
+
+func TestTitanAdapter_ChatRequest(t *testing.T) {
+	params := DefaultParams()
+	req := NewTitanAdapter(&params).ChatRequest(conversation).(*TitanChatRequest)
+
+	require.Equal(
+		t,
+		"You are a helpful assistant\nUser: Hi\nBot: Hello!\nUser: What's the capital of the United Kingdom?\nBot:",
+		req.InputText,
+	)
+}
+
+func TestClaudeAdapter_ChatRequestGenerationParams(t *testing.T) {
+	params := DefaultParams()
+	adapter := NewClaudeAdapter(&params)
+
+	rawReq, err := json.Marshal(adapter.ChatRequest(conversation))
+	require.NoError(t, err)
+
+	var req map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(rawReq, &req))
+
+	// the temperature is not configured, so the model default is used
+	require.NotContains(t, req, "temperature")
+	require.Equal(t, 1.0, req["top_p"])
+
+	temperature := 0.0
+	topP := 0.5
+
+	rawReq, err = json.Marshal(adapter.ChatRequest(&schemas.ChatParams{
+		Messages: conversation.Messages,
+		GenerationParams: schemas.GenerationParams{
+			Temperature: &temperature,
+			TopP:        &topP,
+		},
+	}))
+	require.NoError(t, err)
+
+	req = map[string]interface{}{}
+
+	require.NoError(t, json.Unmarshal(rawReq, &req))
+	require.Equal(t, 0.0, req["temperature"])
+	require.Equal(t, 0.5, req["top_p"])
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/EinStack/glide/pkg/api/schemas"
@@ -13,51 +14,25 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 )
 
-// ChatRequest is a Bedrock-specific request schema
-type ChatRequest struct {
-	Messages             string               `json:"inputText"`
-	TextGenerationConfig TextGenerationConfig `json:"textGenerationConfig"`
-}
-
-func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	// message history not yet supported for AWS models
-	// TODO: do something about lack of message history. Maybe just concatenate all messages?
-	// 	in any case, this is not a way to go to ignore message history
-	message := params.Messages[len(params.Messages)-1]
-
-	r.Messages = fmt.Sprintf("Role: %s, Content: %s", message.Role, message.Content)
-}
-
-type TextGenerationConfig struct {
-	Temperature   float64  `json:"temperature"`
-	TopP          float64  `json:"topP"`
-	MaxTokenCount int      `json:"maxTokenCount"`
-	StopSequences []string `json:"stopSequences,omitempty"`
-}
-
-// NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
-func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
-	return &ChatRequest{
-		TextGenerationConfig: TextGenerationConfig{
-			MaxTokenCount: cfg.DefaultParams.MaxTokens,
-			StopSequences: cfg.DefaultParams.StopSequence,
-			Temperature:   cfg.DefaultParams.Temperature,
-			TopP:          cfg.DefaultParams.TopP,
-		},
-	}
-}
+// Bedrock reports token usage in response headers regardless of the model family
+const (
+	InputTokenCountHeader  = "X-Amzn-Bedrock-Input-Token-Count"
+	OutputTokenCountHeader = "X-Amzn-Bedrock-Output-Token-Count"
+)
 
 // Chat sends a chat request to the specified bedrock model.
 func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error) {
 	// Create a new chat request
 	// TODO: consider using objectpool to optimize memory allocation
-	chatReq := *c.chatRequestTemplate // hoping to get a copy of the template
-	chatReq.ApplyParams(params)
+	chatReq := c.adapter.ChatRequest(WithFormatInstruction(params))
 
-	chatResponse, err := c.doChatRequest(ctx, &chatReq)
+	chatResponse, err := c.doChatRequest(ctx, chatReq)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +40,7 @@ func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas
 	return chatResponse, nil
 }
 
-func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*schemas.ChatResponse, error) {
+func (c *Client) doChatRequest(ctx context.Context, payload any) (*schemas.ChatResponse, error) {
 	rawPayload, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("unable to marshal chat request payload: %w", err)
@@ -74,28 +49,26 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	result, err := c.bedrockClient.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
 		ModelId:     aws.String(c.config.ModelName),
 		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
 		Body:        rawPayload,
 	})
 	if err != nil {
-		c.telemetry.Logger.Error("Error: Couldn't invoke model. Here's why: %v\n", zap.Error(err))
-		return nil, err
+		return nil, c.errMapper.Map(err)
 	}
 
-	var bedrockCompletion ChatCompletion
-
-	err = json.Unmarshal(result.Body, &bedrockCompletion)
+	modelResult, err := c.adapter.ChatResponse(result.Body)
 	if err != nil {
 		c.telemetry.Logger.Error("failed to parse bedrock chat response", zap.Error(err))
 
 		return nil, err
 	}
 
-	modelResult := bedrockCompletion.Results[0]
-
-	if len(modelResult.OutputText) == 0 {
+	if len(modelResult.Content) == 0 {
 		return nil, ErrEmptyResponse
 	}
 
+	applyHeaderTokenCounts(result.ResultMetadata, modelResult)
+
 	response := schemas.ChatResponse{
 		ID:        uuid.NewString(),
 		Created:   int(time.Now().Unix()),
@@ -105,16 +78,34 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		ModelResponse: schemas.ModelResponse{
 			Message: schemas.ChatMessage{
 				Role:    "assistant",
-				Content: modelResult.OutputText,
+				Content: modelResult.Content,
 			},
 			TokenUsage: schemas.TokenUsage{
-				// TODO: what would happen if there is a few responses? We need to sum that up
-				PromptTokens:   modelResult.TokenCount,
-				ResponseTokens: -1,
-				TotalTokens:    modelResult.TokenCount,
+				PromptTokens:   modelResult.PromptTokens,
+				ResponseTokens: modelResult.ResponseTokens,
+				TotalTokens:    modelResult.PromptTokens + modelResult.ResponseTokens,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(modelResult.FinishReason),
 	}
 
 	return &response, nil
 }
+
+// applyHeaderTokenCounts fills token counts from the response headers.
+//
+//	Some model families (e.g. Mistral, Cohere) don't include them into the response body
+func applyHeaderTokenCounts(metadata middleware.Metadata, modelResult *ModelResult) {
+	rawResp, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response)
+	if !ok {
+		return
+	}
+
+	if count, err := strconv.Atoi(rawResp.Header.Get(InputTokenCountHeader)); err == nil {
+		modelResult.PromptTokens = count
+	}
+
+	if count, err := strconv.Atoi(rawResp.Header.Get(OutputTokenCountHeader)); err == nil {
+		modelResult.ResponseTokens = count
+	}
+}
@@ -2,16 +2,227 @@ package bedrock
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 
+	"go.uber.org/zap"
+
 	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 )
 
+// ChatStream represents bedrock chat stream for a specific request.
+//
+//	Bedrock streams chunks via AWS event stream encoding, so the stream is read via AWS SDK.
+//	Chunk payloads are specific to the model family, so they are parsed by the family adapter
+type ChatStream struct {
+	ctx                context.Context
+	bedrockClient      *bedrockruntime.Client
+	input              *bedrockruntime.InvokeModelWithResponseStreamInput
+	modelName          string
+	eventStream        *bedrockruntime.InvokeModelWithResponseStreamEventStream
+	streamFinished     bool
+	finishReason       *string
+	adapter            Adapter
+	errMapper          *ErrorMapper
+	finishReasonMapper *FinishReasonMapper
+	tel                *telemetry.Telemetry
+}
+
+func NewChatStream(
+	ctx context.Context,
+	tel *telemetry.Telemetry,
+	bedrockClient *bedrockruntime.Client,
+	input *bedrockruntime.InvokeModelWithResponseStreamInput,
+	modelName string,
+	adapter Adapter,
+	errMapper *ErrorMapper,
+	finishReasonMapper *FinishReasonMapper,
+) *ChatStream {
+	return &ChatStream{
+		ctx:                ctx,
+		tel:                tel,
+		bedrockClient:      bedrockClient,
+		input:              input,
+		modelName:          modelName,
+		adapter:            adapter,
+		errMapper:          errMapper,
+		streamFinished:     false,
+		finishReasonMapper: finishReasonMapper,
+	}
+}
+
+func (s *ChatStream) Open() error {
+	output, err := s.bedrockClient.InvokeModelWithResponseStream(s.ctx, s.input)
+	if err != nil {
+		return s.errMapper.Map(err)
+	}
+
+	s.eventStream = output.GetStream()
+
+	return nil
+}
+
+func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
+	if s.streamFinished {
+		return nil, io.EOF
+	}
+
+	for {
+		event, ok := <-s.eventStream.Events()
+		if !ok {
+			if err := s.eventStream.Err(); err != nil {
+				return nil, s.errMapper.Map(err)
+			}
+
+			if s.finishReason != nil {
+				// the stream is over, but invocation metrics were not reported
+				s.streamFinished = true
+
+				return s.newChunk("", s.finishReasonMapper.Map(s.finishReason)), nil
+			}
+
+			s.tel.L().Warn(
+				"Chat stream is unexpectedly disconnected",
+				zap.String("provider", providerName),
+			)
+
+			// the event stream was closed before the completion reason was received
+			return nil, clients.ErrProviderUnavailable
+		}
+
+		chunkEvent, ok := event.(*types.ResponseStreamMemberChunk)
+		if !ok {
+			s.tel.L().Debug(
+				"Unsupported stream event type, skipping it",
+				zap.String("provider", providerName),
+				zap.Any("event", event),
+			)
+
+			continue
+		}
+
+		rawChunk := chunkEvent.Value.Bytes
+
+		s.tel.L().Debug(
+			"Raw chat stream chunk",
+			zap.String("provider", providerName),
+			zap.ByteString("rawChunk", rawChunk),
+		)
+
+		modelChunk, err := s.adapter.ChatStreamChunk(rawChunk)
+		if err != nil {
+			return nil, err
+		}
+
+		if modelChunk.FinishReason != nil {
+			s.finishReason = modelChunk.FinishReason
+		}
+
+		var chunkMetrics StreamChunkMetrics
+
+		err = json.Unmarshal(rawChunk, &chunkMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chat stream chunk: %v", err)
+		}
+
+		if metrics := chunkMetrics.InvocationMetrics; metrics != nil {
+			// invocation metrics come with the very last chunk of the stream
+			s.streamFinished = true
+
+			chunk := s.newChunk(modelChunk.Content, s.finishReasonMapper.Map(s.finishReason))
+			chunk.ModelResponse.Metadata = &schemas.Metadata{
+				"prompt_tokens":   metrics.InputTokenCount,
+				"response_tokens": metrics.OutputTokenCount,
+				"total_tokens":    metrics.InputTokenCount + metrics.OutputTokenCount,
+			}
+
+			return chunk, nil
+		}
+
+		if len(modelChunk.Content) == 0 {
+			continue
+		}
+
+		return s.newChunk(modelChunk.Content, nil), nil
+	}
+}
+
+func (s *ChatStream) newChunk(content string, finishReason *schemas.FinishReason) *schemas.ChatStreamChunk {
+	// TODO: use objectpool here
+	return &schemas.ChatStreamChunk{
+		Cached:    false,
+		Provider:  providerName,
+		ModelName: s.modelName,
+		ModelResponse: schemas.ModelChunkResponse{
+			Message: schemas.ChatMessage{
+				Role:    "assistant",
+				Content: content,
+			},
+		},
+		FinishReason: finishReason,
+	}
+}
+
+func (s *ChatStream) Close() error {
+	if s.eventStream != nil {
+		return s.eventStream.Close()
+	}
+
+	return nil
+}
+
 func (c *Client) SupportChatStream() bool {
-	return false
+	return true
+}
+
+func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	// Create a new chat request
+	input, err := c.makeStreamReq(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChatStream(
+		ctx,
+		c.telemetry,
+		c.bedrockClient,
+		input,
+		c.config.ModelName,
+		c.adapter,
+		c.errMapper,
+		c.finishReasonMapper,
+	), nil
 }
 
-func (c *Client) ChatStream(_ context.Context, _ *schemas.ChatParams) (clients.ChatStream, error) {
-	return nil, clients.ErrChatStreamNotImplemented
+func (c *Client) makeStreamReq(params *schemas.ChatParams) (*bedrockruntime.InvokeModelWithResponseStreamInput, error) {
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := c.adapter.ChatRequest(WithFormatInstruction(params))
+
+	rawPayload, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal bedrock chat stream request payload: %w", err)
+	}
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.telemetry.L().Debug(
+		"Stream chat request",
+		zap.String("modelID", c.config.ModelName),
+		zap.Any("payload", chatReq),
+	)
+
+	return &bedrockruntime.InvokeModelWithResponseStreamInput{
+		ModelId:     aws.String(c.config.ModelName),
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+		Body:        rawPayload,
+	}, nil
 }
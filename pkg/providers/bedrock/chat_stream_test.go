@@ -0,0 +1,210 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/stretchr/testify/require"
+)
+
+// newBedrockStreamServer fakes InvokeModelWithResponseStream API by encoding each line of the given file as a chunk event.
+//
+//	The stream is finished with the given exception, if any
+func newBedrockStreamServer(t *testing.T, streamFile string, exceptionType string) *httptest.Server {
+	t.Helper()
+
+	bedrockMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		rawChunks, err := os.ReadFile(filepath.Clean(streamFile))
+		if err != nil {
+			t.Errorf("error reading bedrock chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+
+		encoder := eventstream.NewEncoder()
+		scanner := bufio.NewScanner(bytes.NewReader(rawChunks))
+
+		for scanner.Scan() {
+			payload, _ := json.Marshal(map[string][]byte{"bytes": scanner.Bytes()})
+
+			err = encoder.Encode(w, eventstream.Message{
+				Headers: eventstream.Headers{
+					{Name: ":message-type", Value: eventstream.StringValue("event")},
+					{Name: ":event-type", Value: eventstream.StringValue("chunk")},
+					{Name: ":content-type", Value: eventstream.StringValue("application/json")},
+				},
+				Payload: payload,
+			})
+			if err != nil {
+				t.Errorf("error on sending chat stream chunk: %v", err)
+			}
+		}
+
+		if len(exceptionType) > 0 {
+			err = encoder.Encode(w, eventstream.Message{
+				Headers: eventstream.Headers{
+					{Name: ":message-type", Value: eventstream.StringValue("exception")},
+					{Name: ":exception-type", Value: eventstream.StringValue(exceptionType)},
+					{Name: ":content-type", Value: eventstream.StringValue("application/json")},
+				},
+				Payload: []byte(`{"message":"Too many requests, please wait before trying again."}`),
+			})
+			if err != nil {
+				t.Errorf("error on sending chat stream exception: %v", err)
+			}
+		}
+	})
+
+	return httptest.NewServer(bedrockMock)
+}
+
+func newBedrockStream(t *testing.T, baseURL string, modelName string) clients.ChatStream {
+	t.Helper()
+
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = baseURL
+	providerCfg.ModelName = modelName
+	providerCfg.AccessKey = "abc"
+	providerCfg.SecretKey = "def"
+	providerCfg.AWSRegion = "us-west-2"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(context.Background(), &chatParams)
+	require.NoError(t, err)
+
+	return stream
+}
+
+func TestBedrock_ChatStreamSupported(t *testing.T) {
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportChatStream())
+}
+
+func TestBedrock_ChatStreamRequest(t *testing.T) {
+	tests := map[string]struct {
+		modelName  string
+		streamFile string
+	}{
+		"titan": {
+			modelName:  "amazon.titan-text-express-v1",
+			streamFile: "./testdata/chat_stream.success.txt",
+		},
+		"claude": {
+			modelName:  "anthropic.claude-3-haiku-20240307-v1:0",
+			streamFile: "./testdata/chat_stream.claude.success.txt",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bedrockServer := newBedrockStreamServer(t, test.streamFile, "")
+			defer bedrockServer.Close()
+
+			stream := newBedrockStream(t, bedrockServer.URL, test.modelName)
+
+			err := stream.Open()
+			require.NoError(t, err)
+
+			defer stream.Close()
+
+			var content string
+
+			var lastChunk *schemas.ChatStreamChunk
+
+			for {
+				chunk, err := stream.Recv()
+
+				if err == io.EOF {
+					break
+				}
+
+				require.NoError(t, err)
+				require.NotNil(t, chunk)
+
+				content += chunk.ModelResponse.Message.Content
+				lastChunk = chunk
+			}
+
+			require.Equal(t, "The capital of the United Kingdom is London.", content)
+			require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+			require.Equal(t, 21, (*lastChunk.ModelResponse.Metadata)["total_tokens"])
+		})
+	}
+}
+
+func TestBedrock_ChatStreamRequestInterrupted(t *testing.T) {
+	bedrockServer := newBedrockStreamServer(t, "./testdata/chat_stream.nodone.txt", "")
+	defer bedrockServer.Close()
+
+	stream := newBedrockStream(t, bedrockServer.URL, "amazon.titan-text-express-v1")
+
+	err := stream.Open()
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	for range 2 {
+		chunk, err := stream.Recv()
+
+		require.NoError(t, err)
+		require.NotNil(t, chunk)
+	}
+
+	chunk, err := stream.Recv()
+
+	require.ErrorIs(t, err, clients.ErrProviderUnavailable)
+	require.Nil(t, chunk)
+}
+
+func TestBedrock_ChatStreamThrottled(t *testing.T) {
+	bedrockServer := newBedrockStreamServer(t, "./testdata/chat_stream.nodone.txt", "throttlingException")
+	defer bedrockServer.Close()
+
+	stream := newBedrockStream(t, bedrockServer.URL, "amazon.titan-text-express-v1")
+
+	err := stream.Open()
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	for range 2 {
+		_, err = stream.Recv()
+		require.NoError(t, err)
+	}
+
+	chunk, err := stream.Recv()
+
+	var rateLimitErr *clients.RateLimitError
+
+	require.ErrorAs(t, err, &rateLimitErr)
+	require.Nil(t, chunk)
+}
@@ -0,0 +1,128 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBedrockClient_ChatModelFamilies(t *testing.T) {
+	tests := map[string]struct {
+		modelName    string
+		responseFile string
+		payloadField string
+	}{
+		"titan": {
+			modelName:    "amazon.titan-text-express-v1",
+			responseFile: "./testdata/chat.titan.success.json",
+			payloadField: "inputText",
+		},
+		"claude": {
+			modelName:    "anthropic.claude-3-haiku-20240307-v1:0",
+			responseFile: "./testdata/chat.claude.success.json",
+			payloadField: "anthropic_version",
+		},
+		"llama": {
+			modelName:    "meta.llama3-8b-instruct-v1:0",
+			responseFile: "./testdata/chat.llama.success.json",
+			payloadField: "max_gen_len",
+		},
+		"mistral": {
+			modelName:    "mistral.mistral-7b-instruct-v0:2",
+			responseFile: "./testdata/chat.mistral.success.json",
+			payloadField: "prompt",
+		},
+		"command": {
+			modelName:    "cohere.command-r-v1:0",
+			responseFile: "./testdata/chat.command.success.json",
+			payloadField: "chat_history",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bedrockMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rawPayload, _ := io.ReadAll(r.Body)
+
+				var data map[string]interface{}
+				// Parse the JSON body
+				err := json.Unmarshal(rawPayload, &data)
+				if err != nil {
+					t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+				}
+
+				if _, ok := data[test.payloadField]; !ok {
+					t.Errorf("payload is expected to contain %q field: %q", test.payloadField, string(rawPayload))
+				}
+
+				chatResponse, err := os.ReadFile(filepath.Clean(test.responseFile))
+				if err != nil {
+					t.Errorf("error reading bedrock chat mock response: %v", err)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(InputTokenCountHeader, "12")
+				w.Header().Set(OutputTokenCountHeader, "9")
+
+				_, err = w.Write(chatResponse)
+				if err != nil {
+					t.Errorf("error on sending chat response: %v", err)
+				}
+			})
+
+			bedrockServer := httptest.NewServer(bedrockMock)
+			defer bedrockServer.Close()
+
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = bedrockServer.URL
+			providerCfg.ModelName = test.modelName
+			providerCfg.AccessKey = "abc"
+			providerCfg.SecretKey = "def"
+			providerCfg.AWSRegion = "us-west-2"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{
+				{Role: "system", Content: "You are a helpful assistant"},
+				{Role: "user", Content: "Hi"},
+				{Role: "assistant", Content: "Hello! How can I help you?"},
+				{Role: "user", Content: "What's the capital of the United Kingdom?"},
+			}}
+
+			response, err := client.Chat(context.Background(), &chatParams)
+			require.NoError(t, err)
+
+			require.Equal(t, "The capital of the United Kingdom is London.", response.ModelResponse.Message.Content)
+			require.Equal(t, &schemas.ReasonComplete, response.FinishReason)
+			require.Equal(t, 12, response.ModelResponse.TokenUsage.PromptTokens)
+			require.Equal(t, 9, response.ModelResponse.TokenUsage.ResponseTokens)
+			require.Equal(t, 21, response.ModelResponse.TokenUsage.TotalTokens)
+		})
+	}
+}
+
+func TestBedrockClient_UnsupportedModelFamily(t *testing.T) {
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.ModelName = "stability.stable-diffusion-xl-v1"
+
+	_, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.ErrorIs(t, err, ErrModelFamilyNotSupported)
+}
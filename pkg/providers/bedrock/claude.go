@@ -0,0 +1,109 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers/anthropic"
+)
+
+// claudeAPIVersion is required by Bedrock to invoke Claude models via the Messages API
+const claudeAPIVersion = "bedrock-2023-05-31"
+
+// ClaudeChatRequest is an Anthropic Claude Messages API request schema.
+//
+//	Claude responses and stream events are the same as in Anthropic API
+//	Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-anthropic-claude-messages.html
+type ClaudeChatRequest struct {
+	AnthropicVersion string                `json:"anthropic_version"`
+	System           string                `json:"system,omitempty"`
+	Messages         []schemas.ChatMessage `json:"messages"`
+	MaxTokens        int                   `json:"max_tokens"`
+	Temperature      *float64              `json:"temperature,omitempty"`
+	TopP             *float64              `json:"top_p,omitempty"`
+	StopSequences    []string              `json:"stop_sequences,omitempty"`
+}
+
+type ClaudeAdapter struct {
+	params *Params
+}
+
+func NewClaudeAdapter(params *Params) *ClaudeAdapter {
+	return &ClaudeAdapter{
+		params: params,
+	}
+}
+
+// ChatRequest moves system messages to the top-level system prompt as Claude doesn't accept them in the message list
+func (a *ClaudeAdapter) ChatRequest(params *schemas.ChatParams) any {
+	modelParams := a.params.mergeParams(&params.GenerationParams)
+
+	systemPrompts := make([]string, 0, 1)
+	messages := make([]schemas.ChatMessage, 0, len(params.Messages))
+
+	for _, message := range schemas.TextMessages(params.Messages) {
+		if message.Role == "system" {
+			systemPrompts = append(systemPrompts, message.Content)
+
+			continue
+		}
+
+		messages = append(messages, message)
+	}
+
+	return &ClaudeChatRequest{
+		AnthropicVersion: claudeAPIVersion,
+		System:           strings.Join(systemPrompts, "\n"),
+		Messages:         messages,
+		MaxTokens:        modelParams.MaxTokens,
+		Temperature:      modelParams.Temperature,
+		TopP:             modelParams.TopP,
+		StopSequences:    modelParams.Stop,
+	}
+}
+
+func (a *ClaudeAdapter) ChatResponse(rawResponse []byte) (*ModelResult, error) {
+	var completion anthropic.ChatCompletion
+
+	err := json.Unmarshal(rawResponse, &completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse claude chat response: %w", err)
+	}
+
+	if len(completion.Content) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &ModelResult{
+		Content:        completion.Content[0].Text,
+		FinishReason:   completion.StopReason,
+		PromptTokens:   completion.Usage.InputTokens,
+		ResponseTokens: completion.Usage.OutputTokens,
+	}, nil
+}
+
+func (a *ClaudeAdapter) ChatStreamChunk(rawChunk []byte) (*ModelChunk, error) {
+	var event anthropic.StreamEvent
+
+	err := json.Unmarshal(rawChunk, &event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse claude chat stream event: %w", err)
+	}
+
+	chunk := &ModelChunk{}
+
+	switch event.Type {
+	case anthropic.ContentBlockDeltaEvent:
+		if event.Delta != nil {
+			chunk.Content = event.Delta.Text
+		}
+	case anthropic.MessageDeltaEvent:
+		if event.Delta != nil {
+			chunk.FinishReason = event.Delta.StopReason
+		}
+	}
+
+	return chunk, nil
+}
@@ -11,9 +11,12 @@ import (
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -27,13 +30,15 @@ var (
 
 // Client is a client for accessing OpenAI API
 type Client struct {
-	baseURL             string
-	bedrockClient       *bedrockruntime.Client
-	chatURL             string
-	chatRequestTemplate *ChatRequest
-	config              *Config
-	httpClient          *http.Client
-	telemetry           *telemetry.Telemetry
+	baseURL            string
+	bedrockClient      *bedrockruntime.Client
+	chatURL            string
+	adapter            Adapter
+	errMapper          *ErrorMapper
+	finishReasonMapper *FinishReasonMapper
+	config             *Config
+	httpClient         *http.Client
+	telemetry          *telemetry.Telemetry
 }
 
 // NewClient creates a new OpenAI client for the OpenAI API.
@@ -43,19 +48,30 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	adapter, err := NewAdapter(providerConfig.ModelName, providerConfig.DefaultParams)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg, _ := config.LoadDefaultConfig(context.TODO(), // Is this the right context?
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(providerConfig.AccessKey, providerConfig.SecretKey, "")),
 		config.WithRegion(providerConfig.AWSRegion),
 	)
 
-	bedrockClient := bedrockruntime.NewFromConfig(cfg)
+	bedrockClient := bedrockruntime.NewFromConfig(cfg, func(o *bedrockruntime.Options) {
+		if len(providerConfig.BaseURL) > 0 {
+			o.BaseEndpoint = aws.String(providerConfig.BaseURL)
+		}
+	})
 
 	c := &Client{
-		baseURL:             providerConfig.BaseURL,
-		bedrockClient:       bedrockClient,
-		chatURL:             chatURL,
-		config:              providerConfig,
-		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		baseURL:            providerConfig.BaseURL,
+		bedrockClient:      bedrockClient,
+		chatURL:            chatURL,
+		config:             providerConfig,
+		adapter:            adapter,
+		errMapper:          NewErrorMapper(tel),
+		finishReasonMapper: NewFinishReasonMapper(tel),
 		httpClient: &http.Client{
 			Timeout: time.Duration(*clientConfig.Timeout),
 			Transport: &http.Transport{
@@ -76,3 +92,21 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens:
+		return true
+	case schemas.ParamStop:
+		// Llama models don't accept stop sequences
+		return ModelFamilyOf(c.config.ModelName) != LlamaFamily
+	default:
+		return false
+	}
+}
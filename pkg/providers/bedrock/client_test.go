@@ -3,7 +3,6 @@ package bedrock
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -20,8 +19,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// TODO: Need to fix this test
-
 func TestBedrockClient_ChatRequest(t *testing.T) {
 	bedrockMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		rawPayload, _ := io.ReadAll(r.Body)
@@ -33,7 +30,7 @@ func TestBedrockClient_ChatRequest(t *testing.T) {
 			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
 		}
 
-		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.titan.success.json"))
 		if err != nil {
 			t.Errorf("error reading bedrock chat mock response: %v", err)
 		}
@@ -67,10 +64,8 @@ func TestBedrockClient_ChatRequest(t *testing.T) {
 	}}}
 
 	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
 
-	responseString := fmt.Sprintf("%+v", response)
-	// errString := fmt.Sprintf("%+v", err)
-	fmt.Println(responseString)
-
-	println(response, err)
+	require.Equal(t, "assistant", response.ModelResponse.Message.Role)
+	require.Equal(t, "The capital of the United Kingdom is London.", response.ModelResponse.Message.Content)
 }
@@ -0,0 +1,116 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// CommandChatRequest is a Cohere Command R request schema
+// Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-cohere-command-r-plus.html
+type CommandChatRequest struct {
+	Message       string               `json:"message"`
+	ChatHistory   []CommandChatMessage `json:"chat_history,omitempty"`
+	Preamble      string               `json:"preamble,omitempty"`
+	MaxTokens     int                  `json:"max_tokens"`
+	Temperature   *float64             `json:"temperature,omitempty"`
+	P             *float64             `json:"p,omitempty"`
+	StopSequences []string             `json:"stop_sequences,omitempty"`
+}
+
+type CommandChatMessage struct {
+	Role    string `json:"role"`
+	Message string `json:"message"`
+}
+
+// CommandChatCompletion is a Cohere Command R response schema.
+//
+//	Token counts are not included, so they are taken from the response headers
+type CommandChatCompletion struct {
+	ResponseID   string  `json:"response_id"`
+	GenerationID string  `json:"generation_id"`
+	Text         string  `json:"text"`
+	FinishReason *string `json:"finish_reason"`
+}
+
+// CommandChatCompletionChunk represents a payload of the chunk event in the Cohere Command R response stream
+type CommandChatCompletionChunk struct {
+	EventType    string  `json:"event_type"`
+	IsFinished   bool    `json:"is_finished"`
+	Text         string  `json:"text"`
+	FinishReason *string `json:"finish_reason"`
+}
+
+type CommandAdapter struct {
+	params *Params
+}
+
+func NewCommandAdapter(params *Params) *CommandAdapter {
+	return &CommandAdapter{
+		params: params,
+	}
+}
+
+// ChatRequest sends the last message separately from the chat history as Cohere API expects it
+func (a *CommandAdapter) ChatRequest(params *schemas.ChatParams) any {
+	modelParams := a.params.mergeParams(&params.GenerationParams)
+
+	lastIdx := len(params.Messages) - 1
+
+	preamble := ""
+	history := make([]CommandChatMessage, 0, lastIdx)
+
+	for _, message := range params.Messages[:lastIdx] {
+		switch message.Role {
+		case "system":
+			preamble += message.Content
+		case "assistant":
+			history = append(history, CommandChatMessage{Role: "CHATBOT", Message: message.Content})
+		default:
+			history = append(history, CommandChatMessage{Role: "USER", Message: message.Content})
+		}
+	}
+
+	return &CommandChatRequest{
+		Message:       params.Messages[lastIdx].Content,
+		ChatHistory:   history,
+		Preamble:      preamble,
+		MaxTokens:     modelParams.MaxTokens,
+		Temperature:   modelParams.Temperature,
+		P:             modelParams.TopP,
+		StopSequences: modelParams.Stop,
+	}
+}
+
+func (a *CommandAdapter) ChatResponse(rawResponse []byte) (*ModelResult, error) {
+	var completion CommandChatCompletion
+
+	err := json.Unmarshal(rawResponse, &completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command chat response: %w", err)
+	}
+
+	return &ModelResult{
+		Content:      completion.Text,
+		FinishReason: completion.FinishReason,
+	}, nil
+}
+
+func (a *CommandAdapter) ChatStreamChunk(rawChunk []byte) (*ModelChunk, error) {
+	var chunk CommandChatCompletionChunk
+
+	err := json.Unmarshal(rawChunk, &chunk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse command chat stream chunk: %w", err)
+	}
+
+	if chunk.IsFinished {
+		// the stream-end event repeats the whole response text
+		return &ModelChunk{FinishReason: chunk.FinishReason}, nil
+	}
+
+	return &ModelChunk{
+		Content: chunk.Text,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package bedrock
 
 import (
+	"github.com/EinStack/glide/pkg/api/schemas"
 	"github.com/EinStack/glide/pkg/config/fields"
 )
 
@@ -30,6 +31,33 @@ func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return unmarshal((*plain)(p))
 }
 
+// requestParams are generation params of the model request. Float params are pointers, so unset ones are not sent
+type requestParams struct {
+	Temperature *float64
+	TopP        *float64
+	MaxTokens   int
+	Stop        []string
+}
+
+// mergeParams merges the request generation params over the configured ones the same way other providers do
+func (p *Params) mergeParams(genParams *schemas.GenerationParams) *requestParams {
+	params := &requestParams{
+		Temperature: schemas.DefaultParam(p.Temperature),
+		TopP:        schemas.DefaultParam(p.TopP),
+		MaxTokens:   p.MaxTokens,
+		Stop:        p.StopSequence,
+	}
+
+	genParams.ApplyTo(schemas.ParamFields{
+		Temperature: &params.Temperature,
+		TopP:        &params.TopP,
+		MaxTokens:   &params.MaxTokens,
+		Stop:        &params.Stop,
+	})
+
+	return params
+}
+
 type Config struct {
 	BaseURL       string        `yaml:"base_url" json:"base_url" validate:"required"`
 	ChatEndpoint  string        `yaml:"chat_endpoint" json:"chat_endpoint" validate:"required"`
@@ -0,0 +1,139 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// Embedding models are available in Titan & Cohere families only. Titan embeds one text per invocation
+// Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-embed.html
+const (
+	titanEmbedBatchSize  = 1
+	cohereEmbedBatchSize = 96
+	cohereEmbedInputType = "search_document"
+)
+
+type TitanEmbedRequest struct {
+	InputText string `json:"inputText"`
+}
+
+type TitanEmbedResponse struct {
+	Embedding           []float64 `json:"embedding"`
+	InputTextTokenCount int       `json:"inputTextTokenCount"`
+}
+
+type CohereEmbedRequest struct {
+	Texts     []string `json:"texts"`
+	InputType string   `json:"input_type"`
+}
+
+type CohereEmbedResponse struct {
+	ID         string      `json:"id"`
+	Embeddings [][]float64 `json:"embeddings"`
+}
+
+// MaxEmbedBatchSize returns the max number of texts that can be embedded in one invocation of the model
+func (c *Client) MaxEmbedBatchSize() int {
+	if ModelFamilyOf(c.config.ModelName) == CommandFamily {
+		return cohereEmbedBatchSize
+	}
+
+	return titanEmbedBatchSize
+}
+
+// Embed invokes the specified bedrock embedding model
+func (c *Client) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	var payload any
+
+	switch ModelFamilyOf(c.config.ModelName) {
+	case TitanFamily:
+		if len(params.Input) != titanEmbedBatchSize {
+			return nil, fmt.Errorf("titan embedding models accept one text per request, got %v", len(params.Input))
+		}
+
+		payload = TitanEmbedRequest{InputText: params.Input[0]}
+	case CommandFamily:
+		payload = CohereEmbedRequest{Texts: params.Input, InputType: cohereEmbedInputType}
+	default:
+		return nil, fmt.Errorf("%w: %v doesn't provide embedding models", ErrModelFamilyNotSupported, c.config.ModelName)
+	}
+
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal embed request payload: %w", err)
+	}
+
+	result, err := c.bedrockClient.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(c.config.ModelName),
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+		Body:        rawPayload,
+	})
+	if err != nil {
+		return nil, c.errMapper.Map(err)
+	}
+
+	response, err := c.embedResponse(result.Body)
+	if err != nil {
+		c.telemetry.Logger.Error("failed to parse bedrock embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(response.Embeddings) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	modelResult := &ModelResult{PromptTokens: response.TokenUsage.PromptTokens}
+	applyHeaderTokenCounts(result.ResultMetadata, modelResult)
+
+	response.TokenUsage.PromptTokens = modelResult.PromptTokens
+
+	return response, nil
+}
+
+func (c *Client) embedResponse(rawResponse []byte) (*schemas.EmbedResponse, error) {
+	response := &schemas.EmbedResponse{
+		ID:        uuid.NewString(),
+		Created:   int(time.Now().Unix()),
+		Provider:  providerName,
+		ModelName: c.config.ModelName,
+	}
+
+	if ModelFamilyOf(c.config.ModelName) == TitanFamily {
+		var titanResponse TitanEmbedResponse
+
+		if err := json.Unmarshal(rawResponse, &titanResponse); err != nil {
+			return nil, err
+		}
+
+		if len(titanResponse.Embedding) > 0 {
+			response.Embeddings = []schemas.Embedding{{Index: 0, Embedding: titanResponse.Embedding}}
+		}
+
+		response.TokenUsage.PromptTokens = titanResponse.InputTextTokenCount
+
+		return response, nil
+	}
+
+	var cohereResponse CohereEmbedResponse
+
+	if err := json.Unmarshal(rawResponse, &cohereResponse); err != nil {
+		return nil, err
+	}
+
+	for idx, embedding := range cohereResponse.Embeddings {
+		response.Embeddings = append(response.Embeddings, schemas.Embedding{Index: idx, Embedding: embedding})
+	}
+
+	return response, nil
+}
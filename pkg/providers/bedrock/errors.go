@@ -0,0 +1,53 @@
+package bedrock
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"go.uber.org/zap"
+)
+
+type ErrorMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
+	return &ErrorMapper{
+		tel: tel,
+	}
+}
+
+// Map converts AWS SDK errors into Glide's client errors.
+//
+//	AWS SDK deserializes both HTTP errors and exceptions that come in the middle of the event stream into the same types
+func (m *ErrorMapper) Map(err error) error {
+	m.tel.Logger.Error(
+		"Chat request failed",
+		zap.String("provider", providerName),
+		zap.Error(err),
+	)
+
+	var throttlingErr *types.ThrottlingException
+	if errors.As(err, &throttlingErr) {
+		// bedrock doesn't provide any cooldown hints
+		return clients.NewRateLimitError(nil)
+	}
+
+	var accessDeniedErr *types.AccessDeniedException
+	if errors.As(err, &accessDeniedErr) {
+		return clients.ErrUnauthorized
+	}
+
+	var validationErr *types.ValidationException
+	if errors.As(err, &validationErr) {
+		// the request doesn't fit the model (e.g. the prompt is too long)
+		return clients.NewResponseError(http.StatusBadRequest, []byte(validationErr.ErrorMessage()))
+	}
+
+	// Server & client errors result in the same error to keep gateway resilient
+	return clients.ErrProviderUnavailable
+}
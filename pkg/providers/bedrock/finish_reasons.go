@@ -0,0 +1,70 @@
+package bedrock
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+var (
+	// Each model family reports its own finish reasons
+	// Reference: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters.html
+	CompleteReasons = []string{
+		"finish",            // titan
+		"stop_criteria_met", // titan
+		"end_turn",          // claude
+		"stop_sequence",     // claude
+		"stop",              // llama, mistral
+		"complete",          // cohere
+	}
+	MaxTokensReasons = []string{
+		"length",     // titan, llama, mistral
+		"max_tokens", // claude, cohere
+	}
+	ContentFilterReasons = []string{
+		"content_filtered", // titan
+		"error_toxic",      // cohere
+	}
+)
+
+func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
+	return &FinishReasonMapper{
+		tel: tel,
+	}
+}
+
+type FinishReasonMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func (m *FinishReasonMapper) Map(finishReason *string) *schemas.FinishReason {
+	if finishReason == nil || len(*finishReason) == 0 {
+		return nil
+	}
+
+	var reason *schemas.FinishReason
+
+	normalizedReason := strings.ToLower(*finishReason)
+
+	switch {
+	case slices.Contains(CompleteReasons, normalizedReason):
+		reason = &schemas.ReasonComplete
+	case slices.Contains(MaxTokensReasons, normalizedReason):
+		reason = &schemas.ReasonMaxTokens
+	case slices.Contains(ContentFilterReasons, normalizedReason):
+		reason = &schemas.ReasonContentFiltered
+	default:
+		m.tel.Logger.Warn(
+			"Unknown finish reason, other is going to used",
+			zap.String("unknown_reason", *finishReason),
+		)
+
+		reason = &schemas.ReasonOther
+	}
+
+	return reason
+}
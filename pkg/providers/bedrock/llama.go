@@ -0,0 +1,126 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// LlamaChatRequest is a Meta Llama request schema
+// Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-meta.html
+type LlamaChatRequest struct {
+	Prompt      string   `json:"prompt"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	MaxGenLen   int      `json:"max_gen_len"`
+}
+
+// LlamaChatCompletion is a Meta Llama response schema. Stream chunks share the same schema
+type LlamaChatCompletion struct {
+	Generation           string  `json:"generation"`
+	PromptTokenCount     int     `json:"prompt_token_count"`
+	GenerationTokenCount int     `json:"generation_token_count"`
+	StopReason           *string `json:"stop_reason"`
+}
+
+type LlamaAdapter struct {
+	params *Params
+	llama2 bool
+}
+
+func NewLlamaAdapter(modelID string, params *Params) *LlamaAdapter {
+	return &LlamaAdapter{
+		params: params,
+		llama2: strings.Contains(modelID, "llama2"),
+	}
+}
+
+// ChatRequest renders the conversation with the Llama chat template.
+//
+//	Llama 2 and Llama 3+ models use different templates
+//	Ref: https://llama.meta.com/docs/model-cards-and-prompt-formats/meta-llama-3
+func (a *LlamaAdapter) ChatRequest(params *schemas.ChatParams) any {
+	modelParams := a.params.mergeParams(&params.GenerationParams)
+
+	var prompt string
+
+	if a.llama2 {
+		prompt = llama2Prompt(params.Messages)
+	} else {
+		prompt = llama3Prompt(params.Messages)
+	}
+
+	return &LlamaChatRequest{
+		Prompt:      prompt,
+		Temperature: modelParams.Temperature,
+		TopP:        modelParams.TopP,
+		MaxGenLen:   modelParams.MaxTokens,
+	}
+}
+
+func (a *LlamaAdapter) ChatResponse(rawResponse []byte) (*ModelResult, error) {
+	var completion LlamaChatCompletion
+
+	err := json.Unmarshal(rawResponse, &completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse llama chat response: %w", err)
+	}
+
+	return &ModelResult{
+		Content:        completion.Generation,
+		FinishReason:   completion.StopReason,
+		PromptTokens:   completion.PromptTokenCount,
+		ResponseTokens: completion.GenerationTokenCount,
+	}, nil
+}
+
+func (a *LlamaAdapter) ChatStreamChunk(rawChunk []byte) (*ModelChunk, error) {
+	var chunk LlamaChatCompletion
+
+	err := json.Unmarshal(rawChunk, &chunk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse llama chat stream chunk: %w", err)
+	}
+
+	return &ModelChunk{
+		Content:      chunk.Generation,
+		FinishReason: chunk.StopReason,
+	}, nil
+}
+
+func llama3Prompt(messages []schemas.ChatMessage) string {
+	var prompt strings.Builder
+
+	prompt.WriteString("<|begin_of_text|>")
+
+	for _, message := range messages {
+		prompt.WriteString("<|start_header_id|>" + message.Role + "<|end_header_id|>\n\n")
+		prompt.WriteString(message.Content + "<|eot_id|>")
+	}
+
+	prompt.WriteString("<|start_header_id|>assistant<|end_header_id|>\n\n")
+
+	return prompt.String()
+}
+
+func llama2Prompt(messages []schemas.ChatMessage) string {
+	var prompt strings.Builder
+
+	systemPrompt := ""
+
+	for _, message := range messages {
+		switch message.Role {
+		case "system":
+			systemPrompt += "<<SYS>>\n" + message.Content + "\n<</SYS>>\n\n"
+		case "assistant":
+			prompt.WriteString(" " + message.Content + " </s>")
+		default:
+			prompt.WriteString("<s>[INST] " + systemPrompt + message.Content + " [/INST]")
+			systemPrompt = ""
+		}
+	}
+
+	return prompt.String()
+}
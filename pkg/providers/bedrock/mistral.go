@@ -0,0 +1,108 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// MistralChatRequest is a Mistral AI request schema
+// Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-mistral.html
+type MistralChatRequest struct {
+	Prompt      string   `json:"prompt"`
+	MaxTokens   int      `json:"max_tokens"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
+// MistralChatCompletion is a Mistral AI response schema. Stream chunks share the same schema.
+//
+//	Token counts are not included, so they are taken from the response headers
+type MistralChatCompletion struct {
+	Outputs []struct {
+		Text       string  `json:"text"`
+		StopReason *string `json:"stop_reason"`
+	} `json:"outputs"`
+}
+
+type MistralAdapter struct {
+	params *Params
+}
+
+func NewMistralAdapter(params *Params) *MistralAdapter {
+	return &MistralAdapter{
+		params: params,
+	}
+}
+
+// ChatRequest renders the conversation with the Mistral instruction template.
+//
+//	Mistral models have no system role, so system messages are prepended to the next user message
+func (a *MistralAdapter) ChatRequest(params *schemas.ChatParams) any {
+	modelParams := a.params.mergeParams(&params.GenerationParams)
+
+	var prompt strings.Builder
+
+	systemPrompt := ""
+
+	prompt.WriteString("<s>")
+
+	for _, message := range params.Messages {
+		switch message.Role {
+		case "system":
+			systemPrompt += message.Content + "\n\n"
+		case "assistant":
+			prompt.WriteString(" " + message.Content + "</s>")
+		default:
+			prompt.WriteString("[INST] " + systemPrompt + message.Content + " [/INST]")
+			systemPrompt = ""
+		}
+	}
+
+	return &MistralChatRequest{
+		Prompt:      prompt.String(),
+		MaxTokens:   modelParams.MaxTokens,
+		Temperature: modelParams.Temperature,
+		TopP:        modelParams.TopP,
+		Stop:        modelParams.Stop,
+	}
+}
+
+func (a *MistralAdapter) ChatResponse(rawResponse []byte) (*ModelResult, error) {
+	var completion MistralChatCompletion
+
+	err := json.Unmarshal(rawResponse, &completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mistral chat response: %w", err)
+	}
+
+	if len(completion.Outputs) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	return &ModelResult{
+		Content:      completion.Outputs[0].Text,
+		FinishReason: completion.Outputs[0].StopReason,
+	}, nil
+}
+
+func (a *MistralAdapter) ChatStreamChunk(rawChunk []byte) (*ModelChunk, error) {
+	var chunk MistralChatCompletion
+
+	err := json.Unmarshal(rawChunk, &chunk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse mistral chat stream chunk: %w", err)
+	}
+
+	if len(chunk.Outputs) == 0 {
+		return &ModelChunk{}, nil
+	}
+
+	return &ModelChunk{
+		Content:      chunk.Outputs[0].Text,
+		FinishReason: chunk.Outputs[0].StopReason,
+	}, nil
+}
@@ -1,11 +1,14 @@
 package bedrock
 
-// Bedrock Chat Response
-type ChatCompletion struct {
-	InputTextTokenCount int `json:"inputTextTokenCount"`
-	Results             []struct {
-		TokenCount       int    `json:"tokenCount"`
-		OutputText       string `json:"outputText"`
-		CompletionReason string `json:"completionReason"`
-	} `json:"results"`
+// InvocationMetrics are attached to the last chunk of the response stream regardless of the model family
+type InvocationMetrics struct {
+	InputTokenCount   int `json:"inputTokenCount"`
+	OutputTokenCount  int `json:"outputTokenCount"`
+	InvocationLatency int `json:"invocationLatency"`
+	FirstByteLatency  int `json:"firstByteLatency"`
+}
+
+// StreamChunkMetrics picks up invocation metrics from the stream chunk of any model family
+type StreamChunkMetrics struct {
+	InvocationMetrics *InvocationMetrics `json:"amazon-bedrock-invocationMetrics,omitempty"`
 }
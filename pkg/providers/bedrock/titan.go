@@ -0,0 +1,128 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// TitanChatRequest is an Amazon Titan Text request schema
+// Ref: https://docs.aws.amazon.com/bedrock/latest/userguide/model-parameters-titan-text.html
+type TitanChatRequest struct {
+	InputText            string                    `json:"inputText"`
+	TextGenerationConfig TitanTextGenerationConfig `json:"textGenerationConfig"`
+}
+
+type TitanTextGenerationConfig struct {
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"topP,omitempty"`
+	MaxTokenCount int      `json:"maxTokenCount"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+// TitanChatCompletion is an Amazon Titan Text response schema
+type TitanChatCompletion struct {
+	InputTextTokenCount int `json:"inputTextTokenCount"`
+	Results             []struct {
+		TokenCount       int    `json:"tokenCount"`
+		OutputText       string `json:"outputText"`
+		CompletionReason string `json:"completionReason"`
+	} `json:"results"`
+}
+
+// TitanChatCompletionChunk represents a payload of the chunk event in the Titan Text response stream.
+//
+//	The last chunk comes with the completion reason
+type TitanChatCompletionChunk struct {
+	Index                     int     `json:"index"`
+	OutputText                string  `json:"outputText"`
+	InputTextTokenCount       int     `json:"inputTextTokenCount"`
+	TotalOutputTextTokenCount int     `json:"totalOutputTextTokenCount"`
+	CompletionReason          *string `json:"completionReason"`
+}
+
+type TitanAdapter struct {
+	params *Params
+}
+
+func NewTitanAdapter(params *Params) *TitanAdapter {
+	return &TitanAdapter{
+		params: params,
+	}
+}
+
+// ChatRequest renders the conversation in the User/Bot format Titan models are tuned for
+func (a *TitanAdapter) ChatRequest(params *schemas.ChatParams) any {
+	modelParams := a.params.mergeParams(&params.GenerationParams)
+
+	var prompt strings.Builder
+
+	for _, message := range params.Messages {
+		switch message.Role {
+		case "system":
+			prompt.WriteString(message.Content)
+		case "assistant":
+			prompt.WriteString("Bot: " + message.Content)
+		default:
+			prompt.WriteString("User: " + message.Content)
+		}
+
+		prompt.WriteString("\n")
+	}
+
+	prompt.WriteString("Bot:")
+
+	return &TitanChatRequest{
+		InputText: prompt.String(),
+		TextGenerationConfig: TitanTextGenerationConfig{
+			Temperature:   modelParams.Temperature,
+			TopP:          modelParams.TopP,
+			MaxTokenCount: modelParams.MaxTokens,
+			StopSequences: modelParams.Stop,
+		},
+	}
+}
+
+func (a *TitanAdapter) ChatResponse(rawResponse []byte) (*ModelResult, error) {
+	var completion TitanChatCompletion
+
+	err := json.Unmarshal(rawResponse, &completion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse titan chat response: %w", err)
+	}
+
+	if len(completion.Results) == 0 {
+		return nil, ErrEmptyResponse
+	}
+
+	responseTokens := 0
+
+	for _, result := range completion.Results {
+		responseTokens += result.TokenCount
+	}
+
+	modelResult := completion.Results[0]
+
+	return &ModelResult{
+		Content:        modelResult.OutputText,
+		FinishReason:   &modelResult.CompletionReason,
+		PromptTokens:   completion.InputTextTokenCount,
+		ResponseTokens: responseTokens,
+	}, nil
+}
+
+func (a *TitanAdapter) ChatStreamChunk(rawChunk []byte) (*ModelChunk, error) {
+	var chunk TitanChatCompletionChunk
+
+	err := json.Unmarshal(rawChunk, &chunk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse titan chat stream chunk: %w", err)
+	}
+
+	return &ModelChunk{
+		Content:      chunk.OutputText,
+		FinishReason: chunk.CompletionReason,
+	}, nil
+}
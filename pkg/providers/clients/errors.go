@@ -1,8 +1,13 @@
 package clients
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,8 +16,138 @@ var (
 	ErrProviderUnavailable      = errors.New("provider is not available")
 	ErrUnauthorized             = errors.New("API key is wrong or not set")
 	ErrChatStreamNotImplemented = errors.New("streaming chat API is not implemented for provider")
+	ErrTimeout                  = errors.New("model has not answered in time")
 )
 
+// ProviderError is a failed provider response. The status code is kept to find out if the request is worth retrying.
+//
+//	It's still a kind of ErrProviderUnavailable, so the gateway stays resilient to errors it doesn't know about
+type ProviderError struct {
+	StatusCode int
+	// ContentFiltered tells that the request or the answer was rejected by the provider content filter
+	ContentFiltered bool
+	// PromptTooLong tells that the prompt doesn't fit the model context window
+	PromptTooLong bool
+}
+
+func NewProviderError(statusCode int) *ProviderError {
+	return &ProviderError{
+		StatusCode: statusCode,
+	}
+}
+
+// promptTooLongSignals are phrases providers use in error responses when the prompt doesn't fit the model context window
+var promptTooLongSignals = []string{
+	"context_length_exceeded",
+	"maximum context length",
+	"context window",
+	"prompt is too long",
+	"too many tokens",
+	"input is too long",
+}
+
+// NewResponseError builds the provider error from the failed response, so the error class is found out by the status code and the body
+func NewResponseError(statusCode int, body []byte) *ProviderError {
+	providerErr := NewProviderError(statusCode)
+	providerErr.PromptTooLong = IsPromptTooLong(statusCode, body)
+
+	return providerErr
+}
+
+// IsPromptTooLong checks if the error response tells that the prompt doesn't fit the model context window
+func IsPromptTooLong(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+	default:
+		return false
+	}
+
+	message := strings.ToLower(string(body))
+
+	for _, signal := range promptTooLongSignals {
+		if strings.Contains(message, signal) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *ProviderError) Error() string {
+	if e.ContentFiltered {
+		return fmt.Sprintf("%v: rejected by the content filter (status code %v)", ErrProviderUnavailable, e.StatusCode)
+	}
+
+	return fmt.Sprintf("%v (status code %v)", ErrProviderUnavailable, e.StatusCode)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return ErrProviderUnavailable
+}
+
+// ErrorClass groups model errors by the chance the request succeeds if it's retried
+type ErrorClass = string
+
+const (
+	ErrorClassTimeout       ErrorClass = "timeout"
+	ErrorClassServer        ErrorClass = "server" // 5xx responses & network failures
+	ErrorClassRateLimit     ErrorClass = "rate_limit"
+	ErrorClassContentFilter ErrorClass = "content_filter"
+	ErrorClassBadRequest    ErrorClass = "bad_request" // the request content is bad for any model (e.g. the prompt is too long)
+	ErrorClassClient        ErrorClass = "client"      // other 4xx responses that may be specific to the provider (e.g. the model is not found)
+	ErrorClassAuth          ErrorClass = "auth"
+	ErrorClassOther         ErrorClass = "other" // errors that are not reported by providers (e.g. unusable answers)
+)
+
+// ClassifyErr finds out the class of the model error
+func ClassifyErr(err error) ErrorClass {
+	var (
+		providerErr  *ProviderError
+		rateLimitErr *RateLimitError
+		netErr       net.Error
+	)
+
+	switch {
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &rateLimitErr):
+		return ErrorClassRateLimit
+	case errors.Is(err, ErrUnauthorized):
+		return ErrorClassAuth
+	case errors.As(err, &providerErr):
+		return classifyStatusCode(providerErr)
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+
+		return ErrorClassServer
+	case errors.Is(err, ErrProviderUnavailable):
+		return ErrorClassServer
+	default:
+		return ErrorClassOther
+	}
+}
+
+func classifyStatusCode(err *ProviderError) ErrorClass {
+	switch {
+	case err.ContentFiltered:
+		return ErrorClassContentFilter
+	case err.StatusCode == http.StatusRequestTimeout || err.StatusCode == http.StatusGatewayTimeout:
+		return ErrorClassTimeout
+	case err.StatusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimit
+	case err.StatusCode == http.StatusUnauthorized || err.StatusCode == http.StatusForbidden:
+		return ErrorClassAuth
+	case err.PromptTooLong:
+		return ErrorClassBadRequest
+	case err.StatusCode >= 400 && err.StatusCode < 500:
+		return ErrorClassClient
+	default:
+		return ErrorClassServer
+	}
+}
+
 type RateLimitError struct {
 	untilReset time.Duration
 }
@@ -36,3 +171,43 @@ func NewRateLimitError(untilReset *time.Duration) *RateLimitError {
 		untilReset: *untilReset,
 	}
 }
+
+// ResetDelayFromHeaders finds out how long to wait until the rate limit is reset.
+//
+//	Headers are checked in the given order and the first one with a valid value is used.
+//	Values may be given in seconds (e.g. "30" or "7.66"), as a duration (e.g. "2m59.56s") or as a HTTP date.
+//	Returns nil when none of the headers is set, so the default reset delay is used
+func ResetDelayFromHeaders(header http.Header, names ...string) *time.Duration {
+	for _, name := range names {
+		value := header.Get(name)
+		if len(value) == 0 {
+			continue
+		}
+
+		if delay, ok := parseResetDelay(value); ok {
+			return &delay
+		}
+	}
+
+	return nil
+}
+
+func parseResetDelay(value string) (time.Duration, bool) {
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+
+	if delay, err := time.ParseDuration(value); err == nil && delay >= 0 {
+		return delay, true
+	}
+
+	if resetAt, err := http.ParseTime(value); err == nil {
+		return max(time.Until(resetAt), 0), true
+	}
+
+	return 0, false
+}
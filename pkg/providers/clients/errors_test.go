@@ -1,6 +1,9 @@
 package clients
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -14,3 +17,100 @@ func TestRateLimitError(t *testing.T) {
 	require.Equal(t, duration, err.UntilReset())
 	require.Contains(t, err.Error(), "rate limit reached")
 }
+
+func TestResetDelayFromHeaders(t *testing.T) {
+	tests := map[string]struct {
+		value string
+		delay time.Duration
+	}{
+		"seconds":          {"30", 30 * time.Second},
+		"fraction seconds": {"7.66", 7660 * time.Millisecond},
+		"duration":         {"2m59.5s", 2*time.Minute + 59500*time.Millisecond},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Retry-After", tt.value)
+
+			delay := ResetDelayFromHeaders(header, "Retry-After")
+
+			require.NotNil(t, delay)
+			require.Equal(t, tt.delay, *delay)
+		})
+	}
+}
+
+func TestResetDelayFromHeaders_HeaderOrder(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "invalid")
+	header.Set("X-Ratelimit-Reset-Tokens", "6s")
+
+	delay := ResetDelayFromHeaders(header, "Retry-After", "X-Ratelimit-Reset-Requests", "X-Ratelimit-Reset-Tokens")
+
+	require.NotNil(t, delay)
+	require.Equal(t, 6*time.Second, *delay)
+}
+
+func TestResetDelayFromHeaders_HTTPDate(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+
+	delay := ResetDelayFromHeaders(header, "Retry-After")
+
+	require.NotNil(t, delay)
+	require.InDelta(t, time.Hour, *delay, float64(2*time.Second))
+}
+
+func TestResetDelayFromHeaders_NoHeaders(t *testing.T) {
+	require.Nil(t, ResetDelayFromHeaders(http.Header{}, "Retry-After"))
+}
+
+func TestClassifyErr(t *testing.T) {
+	contentFilterErr := NewProviderError(http.StatusBadRequest)
+	contentFilterErr.ContentFiltered = true
+
+	promptTooLongErr := NewResponseError(
+		http.StatusBadRequest,
+		[]byte(`{"error": {"message": "This model's maximum context length is 8192 tokens", "code": "context_length_exceeded"}}`),
+	)
+
+	tests := map[string]struct {
+		err   error
+		class ErrorClass
+	}{
+		"timeout":         {fmt.Errorf("%w: %w", ErrTimeout, context.DeadlineExceeded), ErrorClassTimeout},
+		"deadline":        {context.DeadlineExceeded, ErrorClassTimeout},
+		"server error":    {NewProviderError(http.StatusInternalServerError), ErrorClassServer},
+		"unavailable":     {ErrProviderUnavailable, ErrorClassServer},
+		"rate limit":      {NewRateLimitError(nil), ErrorClassRateLimit},
+		"too many":        {NewProviderError(http.StatusTooManyRequests), ErrorClassRateLimit},
+		"content filter":  {contentFilterErr, ErrorClassContentFilter},
+		"prompt too long": {promptTooLongErr, ErrorClassBadRequest},
+		"bad request":     {NewResponseError(http.StatusBadRequest, []byte(`{"error": {"message": "unknown param"}}`)), ErrorClassClient},
+		"not found":       {NewProviderError(http.StatusNotFound), ErrorClassClient},
+		"unauthorized":    {ErrUnauthorized, ErrorClassAuth},
+		"forbidden":       {NewProviderError(http.StatusForbidden), ErrorClassAuth},
+		"unknown failure": {ErrEmptyResponse, ErrorClassOther},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.class, ClassifyErr(tt.err))
+		})
+	}
+}
+
+func TestIsPromptTooLong(t *testing.T) {
+	require.True(t, IsPromptTooLong(http.StatusBadRequest, []byte(`{"type": "error", "error": {"message": "prompt is too long: 210000 tokens > 200000 maximum"}}`)))
+	require.True(t, IsPromptTooLong(http.StatusRequestEntityTooLarge, []byte("Input is too long for requested model")))
+	require.False(t, IsPromptTooLong(http.StatusBadRequest, []byte(`{"error": {"message": "model not supported"}}`)))
+	require.False(t, IsPromptTooLong(http.StatusNotFound, []byte("context window of the model is not known")))
+}
+
+func TestProviderError_IsProviderUnavailable(t *testing.T) {
+	err := NewProviderError(http.StatusBadRequest)
+
+	require.ErrorIs(t, err, ErrProviderUnavailable)
+	require.Contains(t, err.Error(), "400")
+}
@@ -1,4 +1,4 @@
-package cohere
+package clients
 
 import (
 	"bufio"
@@ -7,8 +7,8 @@ import (
 	"io"
 )
 
-// StreamReader reads Cohere streaming chat chunks that are formated
-// as serializer chunk json per line (a.k.a. application/stream+json)
+// StreamReader reads streaming chat chunks that are formated
+// as serializer chunk json per line (a.k.a. application/stream+json or application/x-ndjson)
 type StreamReader struct {
 	scanner *bufio.Scanner
 }
@@ -20,7 +20,7 @@ import (
 func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
 		Model:             cfg.ModelName,
-		Temperature:       cfg.DefaultParams.Temperature,
+		Temperature:       schemas.DefaultParam(cfg.DefaultParams.Temperature),
 		Preamble:          cfg.DefaultParams.Preamble,
 		PromptTruncation:  cfg.DefaultParams.PromptTruncation,
 		Connectors:        cfg.DefaultParams.Connectors,
@@ -110,10 +110,23 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 		return nil, err
 	}
 
-	if len(cohereCompletion.Text) == 0 {
+	toolCalls, err := ToSchemaToolCalls(cohereCompletion.GenerationID, cohereCompletion.ToolCalls)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse cohere tool calls", zap.Error(err))
+		return nil, err
+	}
+
+	if len(cohereCompletion.Text) == 0 && len(toolCalls) == 0 {
 		return nil, clients.ErrEmptyResponse
 	}
 
+	finishReason := c.finishReasonMapper.Map(cohereCompletion.FinishReason)
+
+	if len(toolCalls) > 0 {
+		// Cohere completes the generation normally when it requests tool calls
+		finishReason = &schemas.ReasonToolCalls
+	}
+
 	// Map response to ChatResponse schema
 	response := schemas.ChatResponse{
 		ID:        cohereCompletion.ResponseID,
@@ -127,8 +140,9 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				"responseId":   cohereCompletion.ResponseID,
 			},
 			Message: schemas.ChatMessage{
-				Role:    "assistant",
-				Content: cohereCompletion.Text,
+				Role:      "assistant",
+				Content:   cohereCompletion.Text,
+				ToolCalls: toolCalls,
 			},
 			TokenUsage: schemas.TokenUsage{
 				PromptTokens:   cohereCompletion.TokenCount.PromptTokens,
@@ -136,6 +150,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				TotalTokens:    cohereCompletion.TokenCount.TotalTokens,
 			},
 		},
+		FinishReason: finishReason,
 	}
 
 	return &response, nil
@@ -22,9 +22,10 @@ import (
 type SupportedEventType = string
 
 var (
-	StreamStartEvent SupportedEventType = "stream-start"
-	TextGenEvent     SupportedEventType = "text-generation"
-	StreamEndEvent   SupportedEventType = "stream-end"
+	StreamStartEvent    SupportedEventType = "stream-start"
+	TextGenEvent        SupportedEventType = "text-generation"
+	ToolCallsChunkEvent SupportedEventType = "tool-calls-chunk"
+	StreamEndEvent      SupportedEventType = "stream-end"
 )
 
 // ChatStream represents cohere chat stream for a specific request
@@ -34,8 +35,9 @@ type ChatStream struct {
 	modelName          string
 	resp               *http.Response
 	generationID       string
+	hasToolCalls       bool
 	streamFinished     bool
-	reader             *StreamReader
+	reader             *clients.StreamReader
 	errMapper          *ErrorMapper
 	finishReasonMapper *FinishReasonMapper
 	tel                *telemetry.Telemetry
@@ -73,7 +75,7 @@ func (s *ChatStream) Open() error {
 	s.tel.L().Debug("Resp Headers", zap.Any("headers", resp.Header))
 
 	s.resp = resp
-	s.reader = NewStreamReader(resp.Body, 8192) // TODO: should we expose maxBufferSize?
+	s.reader = clients.NewStreamReader(resp.Body, 8192) // TODO: should we expose maxBufferSize?
 
 	return nil
 }
@@ -83,9 +85,9 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 		return nil, io.EOF
 	}
 
-	var responseChunk ChatCompletionChunk
-
 	for {
+		var responseChunk ChatCompletionChunk
+
 		rawChunk, err := s.reader.ReadEvent()
 		if err != nil {
 			s.tel.L().Warn(
@@ -116,7 +118,15 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 			continue
 		}
 
-		if responseChunk.EventType != TextGenEvent && responseChunk.EventType != StreamEndEvent {
+		if responseChunk.EventType == ToolCallsChunkEvent && responseChunk.ToolCallDelta != nil {
+			s.hasToolCalls = true
+
+			return s.toolCallChunk(responseChunk.ToolCallDelta), nil
+		}
+
+		if responseChunk.EventType != TextGenEvent &&
+			responseChunk.EventType != ToolCallsChunkEvent &&
+			responseChunk.EventType != StreamEndEvent {
 			s.tel.L().Debug(
 				"Unsupported stream chunk type, skipping it",
 				zap.String("provider", providerName),
@@ -129,6 +139,13 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 		if responseChunk.IsFinished {
 			s.streamFinished = true
 
+			finishReason := s.finishReasonMapper.Map(responseChunk.FinishReason)
+
+			if s.hasToolCalls {
+				// Cohere completes the generation normally when it requests tool calls
+				finishReason = &schemas.ReasonToolCalls
+			}
+
 			// TODO: use objectpool here
 			return &schemas.ChatStreamChunk{
 				Cached:    false,
@@ -144,7 +161,7 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 						Content: responseChunk.Text,
 					},
 				},
-				FinishReason: s.finishReasonMapper.Map(responseChunk.FinishReason),
+				FinishReason: finishReason,
 			}, nil
 		}
 
@@ -166,6 +183,43 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 	}
 }
 
+// toolCallChunk maps a tool call delta. The first delta of the call carries its name,
+// the following ones carry chunks of parameters
+func (s *ChatStream) toolCallChunk(delta *ToolCallDelta) *schemas.ChatStreamChunk {
+	toolCallIdx := 0
+
+	if delta.Index != nil {
+		toolCallIdx = *delta.Index
+	}
+
+	toolCall := schemas.ToolCall{Index: &toolCallIdx}
+
+	if delta.Name != nil {
+		toolCall.ID = NewToolCallID(s.generationID, toolCallIdx)
+		toolCall.Type = schemas.ToolTypeFunction
+		toolCall.Function.Name = *delta.Name
+	}
+
+	if delta.Parameters != nil {
+		toolCall.Function.Arguments = *delta.Parameters
+	}
+
+	return &schemas.ChatStreamChunk{
+		Cached:    false,
+		Provider:  providerName,
+		ModelName: s.modelName,
+		ModelResponse: schemas.ModelChunkResponse{
+			Metadata: &schemas.Metadata{
+				"generation_id": s.generationID,
+			},
+			Message: schemas.ChatMessage{
+				Role:      "model",
+				ToolCalls: []schemas.ToolCall{toolCall},
+			},
+		},
+	}
+}
+
 func (s *ChatStream) Close() error {
 	if s.resp != nil {
 		return s.resp.Body.Close()
@@ -163,3 +163,65 @@ func TestCohere_ChatStreamRequestInterrupted(t *testing.T) {
 		})
 	}
 }
+
+func TestCohere_ChatStreamToolCalls(t *testing.T) {
+	cohereMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat_stream.tool_calls.txt"))
+		if err != nil {
+			t.Errorf("error reading cohere chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/stream+json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	cohereServer := httptest.NewServer(cohereMock)
+	defer cohereServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = cohereServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the weather in London?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	var (
+		toolCalls []schemas.ToolCall
+		lastChunk *schemas.ChatStreamChunk
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		toolCalls = append(toolCalls, chunk.ModelResponse.Message.ToolCalls...)
+		lastChunk = chunk
+	}
+
+	require.Len(t, toolCalls, 3)
+	require.Equal(t, "get_weather", toolCalls[0].Function.Name)
+	require.NotEmpty(t, toolCalls[0].ID)
+	require.JSONEq(t, `{"location": "London, UK"}`, toolCalls[1].Function.Arguments+toolCalls[2].Function.Arguments)
+	require.Equal(t, &schemas.ReasonToolCalls, lastChunk.FinishReason)
+}
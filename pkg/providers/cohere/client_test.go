@@ -65,3 +65,85 @@ func TestCohereClient_ChatRequest(t *testing.T) {
 
 	require.Equal(t, "ec9eb88b-2da5-462e-8f0f-0899d243aa2e", response.ID)
 }
+
+func TestCohereClient_ChatRequestToolCalls(t *testing.T) {
+	cohereMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Empty(t, data.Message)
+		require.Len(t, data.ChatHistory, 2)
+		require.Equal(t, RoleUser, data.ChatHistory[0].Role)
+		require.Equal(t, RoleChatbot, data.ChatHistory[1].Role)
+		require.Len(t, data.ToolResults, 1)
+		require.Equal(t, "get_weather", data.ToolResults[0].Call.Name)
+		require.Equal(t, "Paris", data.ToolResults[0].Call.Parameters["location"])
+		require.Equal(t, map[string]any{"result": "15 degrees, cloudy"}, data.ToolResults[0].Outputs[0])
+		require.Len(t, data.Tools, 1)
+		require.Equal(t, ParameterDefinition{Type: "str", Required: true}, data.Tools[0].ParameterDefinitions["location"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.tool_calls.json"))
+		if err != nil {
+			t.Errorf("error reading cohere chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	cohereServer := httptest.NewServer(cohereMock)
+	defer cohereServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = cohereServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{
+			{Role: "user", Content: "What's the weather in Paris?"},
+			{Role: "assistant", ToolCalls: []schemas.ToolCall{{
+				ID:       "call_1",
+				Type:     schemas.ToolTypeFunction,
+				Function: schemas.ToolCallFunction{Name: "get_weather", Arguments: `{"location": "Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: "15 degrees, cloudy"},
+		},
+		Tools: []schemas.Tool{{
+			Type: schemas.ToolTypeFunction,
+			Function: schemas.ToolFunction{
+				Name: "get_weather",
+				Parameters: map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"location": map[string]any{"type": "string"}},
+					"required":   []any{"location"},
+				},
+			},
+		}},
+	}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	message := response.ModelResponse.Message
+
+	require.Equal(t, &schemas.ReasonToolCalls, response.FinishReason)
+	require.Len(t, message.ToolCalls, 1)
+	require.NotEmpty(t, message.ToolCalls[0].ID)
+	require.Equal(t, "get_weather", message.ToolCalls[0].Function.Name)
+	require.JSONEq(t, `{"location": "London, UK"}`, message.ToolCalls[0].Function.Arguments)
+}
@@ -39,11 +39,14 @@ func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 type Config struct {
-	BaseURL       string        `yaml:"base_url" json:"base_url" validate:"required,http_url"`
-	ChatEndpoint  string        `yaml:"chat_endpoint" json:"chat_endpoint" validate:"required"`
-	ModelName     string        `yaml:"model" json:"model" validate:"required"` // https://docs.cohere.com/docs/models#command
-	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
-	DefaultParams *Params       `yaml:"default_params,omitempty" json:"defaultParams"`
+	BaseURL       string `yaml:"base_url" json:"base_url" validate:"required,http_url"`
+	ChatEndpoint  string `yaml:"chat_endpoint" json:"chat_endpoint" validate:"required"`
+	EmbedEndpoint string `yaml:"embed_endpoint" json:"embed_endpoint"`
+	// EmbedInputType tells embedding models what the vectors are used for (e.g. search_document, search_query, classification, clustering)
+	EmbedInputType string        `yaml:"embed_input_type" json:"embed_input_type" validate:"omitempty,oneof=search_document search_query classification clustering"`
+	ModelName      string        `yaml:"model" json:"model" validate:"required"` // https://docs.cohere.com/docs/models#command
+	APIKey         fields.Secret `yaml:"api_key" json:"-" validate:"required"`
+	DefaultParams  *Params       `yaml:"default_params,omitempty" json:"defaultParams"`
 }
 
 // DefaultConfig for Cohere models
@@ -51,10 +54,12 @@ func DefaultConfig() *Config {
 	defaultParams := DefaultParams()
 
 	return &Config{
-		BaseURL:       "https://api.cohere.ai/v1",
-		ChatEndpoint:  "/chat",
-		ModelName:     "command-light",
-		DefaultParams: &defaultParams,
+		BaseURL:        "https://api.cohere.ai/v1",
+		ChatEndpoint:   "/chat",
+		EmbedEndpoint:  "/embed",
+		EmbedInputType: "search_document",
+		ModelName:      "command-light",
+		DefaultParams:  &defaultParams,
 	}
 }
 
@@ -0,0 +1,120 @@
+package cohere
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// maxEmbedBatchSize is the max number of texts Cohere accepts in one embedding request
+const maxEmbedBatchSize = 96
+
+// EmbedRequest is a Cohere-specific embedding request schema
+// Ref: https://docs.cohere.com/reference/embed
+type EmbedRequest struct {
+	Model     string   `json:"model"`
+	Texts     []string `json:"texts"`
+	InputType string   `json:"input_type,omitempty"`
+	Truncate  string   `json:"truncate,omitempty"`
+}
+
+// EmbedResponse is a Cohere-specific embedding response schema
+type EmbedResponse struct {
+	ID         string      `json:"id"`
+	Embeddings [][]float64 `json:"embeddings"`
+	Texts      []string    `json:"texts"`
+	Meta       Meta        `json:"meta"`
+}
+
+// MaxEmbedBatchSize returns the max number of texts that can be embedded in one request
+func (c *Client) MaxEmbedBatchSize() int {
+	return maxEmbedBatchSize
+}
+
+// Embed sends an embedding request to the specified Cohere model
+func (c *Client) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	embedReq := EmbedRequest{
+		Model:     c.config.ModelName,
+		Texts:     params.Input,
+		InputType: c.config.EmbedInputType,
+	}
+
+	rawPayload, err := json.Marshal(embedReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal cohere embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create cohere embed request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	c.tel.Logger.Debug(
+		"cohere embed request",
+		zap.String("embed_url", c.embedURL),
+		zap.Int("inputs", len(params.Input)),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send cohere embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("failed to read cohere embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var embedResponse EmbedResponse
+
+	err = json.Unmarshal(bodyBytes, &embedResponse)
+	if err != nil {
+		c.tel.Logger.Error("failed to parse cohere embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(embedResponse.Embeddings) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	embeddings := make([]schemas.Embedding, 0, len(embedResponse.Embeddings))
+
+	for idx, embedding := range embedResponse.Embeddings {
+		embeddings = append(embeddings, schemas.Embedding{
+			Index:     idx,
+			Embedding: embedding,
+		})
+	}
+
+	return &schemas.EmbedResponse{
+		ID:         embedResponse.ID,
+		Created:    int(time.Now().UTC().Unix()),
+		Provider:   providerName,
+		ModelName:  c.config.ModelName,
+		Embeddings: embeddings,
+		TokenUsage: schemas.EmbedTokenUsage{
+			PromptTokens: embedResponse.Meta.BilledUnits.InputTokens,
+		},
+	}, nil
+}
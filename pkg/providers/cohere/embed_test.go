@@ -0,0 +1,68 @@
+package cohere
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCohereClient_EmbedRequest(t *testing.T) {
+	cohereMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/embed", r.URL.Path)
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data EmbedRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, []string{"hello", "goodbye"}, data.Texts)
+		require.Equal(t, "search_document", data.InputType)
+
+		embedResponse, err := os.ReadFile(filepath.Clean("./testdata/embed.success.json"))
+		if err != nil {
+			t.Errorf("error reading cohere embed mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(embedResponse)
+		if err != nil {
+			t.Errorf("error on sending embed response: %v", err)
+		}
+	})
+
+	cohereServer := httptest.NewServer(cohereMock)
+	defer cohereServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = cohereServer.URL
+	providerCfg.ModelName = "embed-english-v3.0"
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Embed(context.Background(), &schemas.EmbedParams{Input: []string{"hello", "goodbye"}})
+	require.NoError(t, err)
+
+	require.Equal(t, "da6e531f-54c6-4a73-bf92-f60566d8d753", response.ID)
+	require.Equal(t, 2, response.TokenUsage.PromptTokens)
+	require.Len(t, response.Embeddings, 2)
+	require.Equal(t, 1, response.Embeddings[1].Index)
+}
@@ -60,6 +60,7 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 		return clients.ErrUnauthorized
 	}
 
-	// Server & client errors result in the same error to keep gateway resilient
-	return clients.ErrProviderUnavailable
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	return clients.NewResponseError(resp.StatusCode, bodyBytes)
 }
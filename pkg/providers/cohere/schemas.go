@@ -1,6 +1,10 @@
 package cohere
 
-import "github.com/EinStack/glide/pkg/api/schemas"
+import (
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
 
 // Cohere Chat Response
 type ChatCompletion struct {
@@ -14,6 +18,8 @@ type ChatCompletion struct {
 	SearchResults []SearchResults        `json:"search_results"`
 	Meta          Meta                   `json:"meta"`
 	ToolInputs    map[string]interface{} `json:"tool_inputs"`
+	ToolCalls     []ToolCall             `json:"tool_calls,omitempty"`
+	FinishReason  *string                `json:"finish_reason,omitempty"`
 }
 
 type TokenCount struct {
@@ -71,12 +77,13 @@ type ConnectorsResponse struct {
 // ChatCompletionChunk represents SSEvent a chat response is broken down on chat streaming
 // Ref: https://docs.cohere.com/reference/about
 type ChatCompletionChunk struct {
-	IsFinished   bool           `json:"is_finished"`
-	EventType    string         `json:"event_type"`
-	GenerationID *string        `json:"generation_id"`
-	Text         string         `json:"text"`
-	Response     *FinalResponse `json:"response,omitempty"`
-	FinishReason *string        `json:"finish_reason,omitempty"`
+	IsFinished    bool           `json:"is_finished"`
+	EventType     string         `json:"event_type"`
+	GenerationID  *string        `json:"generation_id"`
+	Text          string         `json:"text"`
+	ToolCallDelta *ToolCallDelta `json:"tool_call_delta,omitempty"`
+	Response      *FinalResponse `json:"response,omitempty"`
+	FinishReason  *string        `json:"finish_reason,omitempty"`
 }
 
 type FinalResponse struct {
@@ -90,32 +97,73 @@ type FinalResponse struct {
 // ChatRequest is a request to complete a chat completion
 // Ref: https://docs.cohere.com/reference/chat
 type ChatRequest struct {
-	Model             string                `json:"model"`
-	Message           string                `json:"message"`
-	ChatHistory       []schemas.ChatMessage `json:"chat_history"`
-	Temperature       float64               `json:"temperature,omitempty"`
-	Preamble          string                `json:"preamble,omitempty"`
-	PromptTruncation  *string               `json:"prompt_truncation,omitempty"`
-	Connectors        []string              `json:"connectors,omitempty"`
-	SearchQueriesOnly bool                  `json:"search_queries_only,omitempty"`
-	Stream            bool                  `json:"stream,omitempty"`
-	Seed              *int                  `json:"seed,omitempty"`
-	MaxTokens         *int                  `json:"max_tokens,omitempty"`
-	K                 int                   `json:"k"`
-	P                 float32               `json:"p"`
-	FrequencyPenalty  float32               `json:"frequency_penalty"`
-	PresencePenalty   float32               `json:"presence_penalty"`
-	StopSequences     []string              `json:"stop_sequences"`
+	Model             string       `json:"model"`
+	Message           string       `json:"message"`
+	ChatHistory       []Message    `json:"chat_history"`
+	Temperature       *float64     `json:"temperature,omitempty"`
+	Preamble          string       `json:"preamble,omitempty"`
+	PromptTruncation  *string      `json:"prompt_truncation,omitempty"`
+	Connectors        []string     `json:"connectors,omitempty"`
+	SearchQueriesOnly bool         `json:"search_queries_only,omitempty"`
+	Stream            bool         `json:"stream,omitempty"`
+	Seed              *int         `json:"seed,omitempty"`
+	MaxTokens         int          `json:"max_tokens,omitempty"`
+	K                 int          `json:"k"`
+	P                 *float64     `json:"p,omitempty"`
+	FrequencyPenalty  *float64     `json:"frequency_penalty,omitempty"`
+	PresencePenalty   *float64     `json:"presence_penalty,omitempty"`
+	StopSequences     []string     `json:"stop_sequences"`
+	Tools             []Tool       `json:"tools,omitempty"`
+	ToolResults       []ToolResult `json:"tool_results,omitempty"`
+	ToolChoice        *string      `json:"tool_choice,omitempty"`
 }
 
 func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	message := params.Messages[len(params.Messages)-1]
-	messageHistory := params.Messages[:len(params.Messages)-1]
-
-	// TODO: Map chat message roles to Cohere roles: CHATBOT, SYSTEM, USER
-
-	r.Message = message.Content
-	r.ChatHistory = messageHistory
+	toolCalls := CollectToolCalls(params.Messages)
+
+	// trailing tool messages carry results of tool calls requested by the model on the previous turn,
+	//  Cohere expects them in the request itself rather than in the chat history
+	lastIdx := len(params.Messages) - 1
+	toolResultIdx := lastIdx + 1
+
+	for toolResultIdx > 0 && params.Messages[toolResultIdx-1].Role == "tool" {
+		toolResultIdx--
+	}
+
+	r.Message = ""
+	r.ToolResults = nil
+
+	if toolResultIdx <= lastIdx {
+		toolResults := make([]ToolResult, 0, len(params.Messages)-toolResultIdx)
+
+		for _, message := range params.Messages[toolResultIdx:] {
+			toolResults = append(toolResults, NewToolResult(&message, toolCalls))
+		}
+
+		r.ToolResults = toolResults
+		r.ChatHistory = NewMessages(params.Messages[:toolResultIdx], toolCalls)
+	} else {
+		r.Message = params.Messages[lastIdx].Content
+		r.ChatHistory = NewMessages(params.Messages[:lastIdx], toolCalls)
+	}
+
+	r.ToolChoice, r.Tools = NewToolChoice(params.ToolChoice, NewTools(params.Tools))
+
+	// Cohere has no JSON mode, so the response format is requested via the preamble
+	if instruction := params.ResponseFormat.Instruction(); len(instruction) > 0 {
+		r.Preamble = strings.TrimSpace(r.Preamble + "\n\n" + instruction)
+	}
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature:      &r.Temperature,
+		TopP:             &r.P,
+		TopK:             &r.K,
+		MaxTokens:        &r.MaxTokens,
+		Stop:             &r.StopSequences,
+		Seed:             &r.Seed,
+		FrequencyPenalty: &r.FrequencyPenalty,
+		PresencePenalty:  &r.PresencePenalty,
+	})
 }
 
 type Connectors struct {
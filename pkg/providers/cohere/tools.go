@@ -0,0 +1,274 @@
+package cohere
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// Cohere chat history roles
+var (
+	RoleUser    = "USER"
+	RoleChatbot = "CHATBOT"
+	RoleSystem  = "SYSTEM"
+	RoleTool    = "TOOL"
+)
+
+// Cohere tool choices
+var (
+	ToolChoiceRequired = "REQUIRED"
+	ToolChoiceNone     = "NONE"
+)
+
+// jsonSchemaTypes maps JSON Schema types to Cohere parameter types
+var jsonSchemaTypes = map[string]string{
+	"string":  "str",
+	"integer": "int",
+	"number":  "float",
+	"boolean": "bool",
+	"array":   "list",
+	"object":  "dict",
+}
+
+// Message is a message of the Cohere chat history
+type Message struct {
+	Role        string       `json:"role"`
+	Message     string       `json:"message,omitempty"`
+	ToolCalls   []ToolCall   `json:"tool_calls,omitempty"`
+	ToolResults []ToolResult `json:"tool_results,omitempty"`
+}
+
+// Tool is a tool definition the model may call
+// Ref: https://docs.cohere.com/docs/tool-use
+type Tool struct {
+	Name                 string                         `json:"name"`
+	Description          string                         `json:"description"`
+	ParameterDefinitions map[string]ParameterDefinition `json:"parameter_definitions,omitempty"`
+}
+
+type ParameterDefinition struct {
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+}
+
+type ToolCall struct {
+	Name       string         `json:"name"`
+	Parameters map[string]any `json:"parameters"`
+}
+
+type ToolResult struct {
+	Call    ToolCall         `json:"call"`
+	Outputs []map[string]any `json:"outputs"`
+}
+
+// ToolCallDelta is a part of the tool call streamed in the tool-calls-chunk event
+type ToolCallDelta struct {
+	Index      *int    `json:"index,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Parameters *string `json:"parameters,omitempty"`
+}
+
+// NewTools maps Glide's tool definitions to Cohere ones.
+// Cohere defines parameters as a flat map, so only top-level JSON Schema properties are translated
+func NewTools(tools []schemas.Tool) []Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	cohereTools := make([]Tool, 0, len(tools))
+
+	for _, tool := range tools {
+		cohereTools = append(cohereTools, Tool{
+			Name:                 tool.Function.Name,
+			Description:          tool.Function.Description,
+			ParameterDefinitions: newParameterDefinitions(tool.Function.Parameters),
+		})
+	}
+
+	return cohereTools
+}
+
+func newParameterDefinitions(parameters map[string]any) map[string]ParameterDefinition {
+	properties, _ := parameters["properties"].(map[string]any)
+	if len(properties) == 0 {
+		return nil
+	}
+
+	required := make(map[string]bool)
+
+	if requiredParams, ok := parameters["required"].([]any); ok {
+		for _, param := range requiredParams {
+			if paramName, ok := param.(string); ok {
+				required[paramName] = true
+			}
+		}
+	}
+
+	definitions := make(map[string]ParameterDefinition, len(properties))
+
+	for paramName, rawProperty := range properties {
+		property, _ := rawProperty.(map[string]any)
+
+		paramType, _ := property["type"].(string)
+		if cohereType, found := jsonSchemaTypes[paramType]; found {
+			paramType = cohereType
+		}
+
+		description, _ := property["description"].(string)
+
+		definitions[paramName] = ParameterDefinition{
+			Description: description,
+			Type:        paramType,
+			Required:    required[paramName],
+		}
+	}
+
+	return definitions
+}
+
+// NewToolChoice maps Glide's tool choice to Cohere one.
+// Cohere can't be forced to call a specific function, so only that function is left available in that case
+func NewToolChoice(choice *schemas.ToolChoice, tools []Tool) (*string, []Tool) {
+	if choice == nil {
+		return nil, tools
+	}
+
+	switch choice.Mode {
+	case schemas.ToolChoiceNone:
+		return &ToolChoiceNone, tools
+	case schemas.ToolChoiceRequired:
+		return &ToolChoiceRequired, tools
+	case schemas.ToolChoiceFunction:
+		for _, tool := range tools {
+			if tool.Name == choice.FunctionName {
+				return &ToolChoiceRequired, []Tool{tool}
+			}
+		}
+
+		return &ToolChoiceRequired, tools
+	default:
+		return nil, tools
+	}
+}
+
+// NewMessages maps Glide's chat messages to the Cohere chat history.
+// Tool results reference calls by name and parameters, so they are looked up by the tool call ID
+func NewMessages(messages []schemas.ChatMessage, toolCalls map[string]ToolCall) []Message {
+	cohereMessages := make([]Message, 0, len(messages))
+
+	for _, message := range messages {
+		switch message.Role {
+		case "system":
+			cohereMessages = append(cohereMessages, Message{Role: RoleSystem, Message: message.Content})
+		case "assistant":
+			cohereMessages = append(cohereMessages, Message{
+				Role:      RoleChatbot,
+				Message:   message.Content,
+				ToolCalls: newToolCalls(message.ToolCalls),
+			})
+		case "tool":
+			lastIdx := len(cohereMessages) - 1
+			toolResult := NewToolResult(&message, toolCalls)
+
+			if lastIdx >= 0 && cohereMessages[lastIdx].Role == RoleTool {
+				cohereMessages[lastIdx].ToolResults = append(cohereMessages[lastIdx].ToolResults, toolResult)
+
+				continue
+			}
+
+			cohereMessages = append(cohereMessages, Message{Role: RoleTool, ToolResults: []ToolResult{toolResult}})
+		default:
+			cohereMessages = append(cohereMessages, Message{Role: RoleUser, Message: message.Content})
+		}
+	}
+
+	return cohereMessages
+}
+
+// CollectToolCalls indexes tool calls requested by the model by their IDs
+func CollectToolCalls(messages []schemas.ChatMessage) map[string]ToolCall {
+	toolCalls := make(map[string]ToolCall)
+
+	for _, message := range messages {
+		for _, toolCall := range message.ToolCalls {
+			toolCalls[toolCall.ID] = newToolCall(&toolCall)
+		}
+	}
+
+	return toolCalls
+}
+
+// NewToolResult maps a tool message to the Cohere tool result.
+// Cohere expects outputs to be objects, so other content is wrapped into the result field
+func NewToolResult(message *schemas.ChatMessage, toolCalls map[string]ToolCall) ToolResult {
+	output := make(map[string]any)
+
+	if err := json.Unmarshal([]byte(message.Content), &output); err != nil {
+		output = map[string]any{"result": message.Content}
+	}
+
+	return ToolResult{
+		Call:    toolCalls[message.ToolCallID],
+		Outputs: []map[string]any{output},
+	}
+}
+
+func newToolCalls(toolCalls []schemas.ToolCall) []ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	cohereToolCalls := make([]ToolCall, 0, len(toolCalls))
+
+	for _, toolCall := range toolCalls {
+		cohereToolCalls = append(cohereToolCalls, newToolCall(&toolCall))
+	}
+
+	return cohereToolCalls
+}
+
+func newToolCall(toolCall *schemas.ToolCall) ToolCall {
+	parameters, err := toolCall.Function.ArgumentsMap()
+	if err != nil {
+		parameters = make(map[string]any)
+	}
+
+	return ToolCall{
+		Name:       toolCall.Function.Name,
+		Parameters: parameters,
+	}
+}
+
+// NewToolCallID generates a tool call ID as Cohere doesn't identify tool calls
+func NewToolCallID(generationID string, idx int) string {
+	return fmt.Sprintf("call_%s_%d", generationID, idx)
+}
+
+// ToSchemaToolCalls maps tool calls from the Cohere response
+func ToSchemaToolCalls(generationID string, toolCalls []ToolCall) ([]schemas.ToolCall, error) {
+	if len(toolCalls) == 0 {
+		return nil, nil
+	}
+
+	schemaToolCalls := make([]schemas.ToolCall, 0, len(toolCalls))
+
+	for idx, toolCall := range toolCalls {
+		arguments, err := json.Marshal(toolCall.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode parameters of the %q tool call: %w", toolCall.Name, err)
+		}
+
+		schemaToolCalls = append(schemaToolCalls, schemas.ToolCall{
+			ID:   NewToolCallID(generationID, idx),
+			Type: schemas.ToolTypeFunction,
+			Function: schemas.ToolCallFunction{
+				Name:      toolCall.Name,
+				Arguments: string(arguments),
+			},
+		})
+	}
+
+	return schemaToolCalls, nil
+}
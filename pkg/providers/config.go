@@ -8,6 +8,14 @@ import (
 
 	"github.com/EinStack/glide/pkg/providers/ollama"
 
+	"github.com/EinStack/glide/pkg/providers/gemini"
+
+	"github.com/EinStack/glide/pkg/providers/groq"
+
+	"github.com/EinStack/glide/pkg/providers/mistral"
+
+	"github.com/EinStack/glide/pkg/providers/openaicompatible"
+
 	"github.com/EinStack/glide/pkg/providers/clients"
 
 	"github.com/EinStack/glide/pkg/providers/bedrock"
@@ -25,6 +33,8 @@ import (
 	"github.com/EinStack/glide/pkg/providers/azureopenai"
 
 	"github.com/EinStack/glide/pkg/providers/anthropic"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 var ErrProviderNotFound = errors.New("provider not found")
@@ -36,6 +46,12 @@ type LangModelConfig struct {
 	Latency     *latency.Config       `yaml:"latency" json:"latency"`
 	Weight      int                   `yaml:"weight" json:"weight"`
 	Client      *clients.ClientConfig `yaml:"client" json:"client"`
+	// CircuitBreaker stops sending requests to the model that keeps failing
+	CircuitBreaker *health.CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+	// HealthCheck probes the model in background if set
+	HealthCheck *HealthCheckConfig `yaml:"health_check,omitempty" json:"health_check,omitempty"`
+	// Modalities restricts content the model accepts (e.g. text, image, document). All modalities the provider supports are accepted by default
+	Modalities []schemas.Modality `yaml:"modalities,omitempty" json:"modalities,omitempty" validate:"omitempty,dive,oneof=text image document"`
 	// Add other providers like
 	OpenAI      *openai.Config      `yaml:"openai,omitempty" json:"openai,omitempty"`
 	AzureOpenAI *azureopenai.Config `yaml:"azureopenai,omitempty" json:"azureopenai,omitempty"`
@@ -44,15 +60,21 @@ type LangModelConfig struct {
 	Anthropic   *anthropic.Config   `yaml:"anthropic,omitempty" json:"anthropic,omitempty"`
 	Bedrock     *bedrock.Config     `yaml:"bedrock,omitempty" json:"bedrock,omitempty"`
 	Ollama      *ollama.Config      `yaml:"ollama,omitempty" json:"ollama,omitempty"`
+	Gemini      *gemini.Config      `yaml:"gemini,omitempty" json:"gemini,omitempty"`
+	Mistral     *mistral.Config     `yaml:"mistral,omitempty" json:"mistral,omitempty"`
+	Groq        *groq.Config        `yaml:"groq,omitempty" json:"groq,omitempty"`
+	// OpenAICompatible covers self-hosted servers that speak the OpenAI wire format (e.g. vLLM, TGI, llama.cpp)
+	OpenAICompatible *openaicompatible.Config `yaml:"openai_compatible,omitempty" json:"openai_compatible,omitempty"`
 }
 
 func DefaultLangModelConfig() *LangModelConfig {
 	return &LangModelConfig{
-		Enabled:     true,
-		Client:      clients.DefaultClientConfig(),
-		ErrorBudget: health.DefaultErrorBudget(),
-		Latency:     latency.DefaultConfig(),
-		Weight:      1,
+		Enabled:        true,
+		Client:         clients.DefaultClientConfig(),
+		ErrorBudget:    health.DefaultErrorBudget(),
+		CircuitBreaker: health.DefaultCircuitBreakerConfig(),
+		Latency:        latency.DefaultConfig(),
+		Weight:         1,
 	}
 }
 
@@ -62,7 +84,11 @@ func (c *LangModelConfig) ToModel(tel *telemetry.Telemetry) (*LanguageModel, err
 		return nil, fmt.Errorf("error initializing client: %v", err)
 	}
 
-	return NewLangModel(c.ID, client, c.ErrorBudget, *c.Latency, c.Weight), nil
+	model := NewLangModel(c.ID, client, c.ErrorBudget, c.CircuitBreaker, *c.Latency, c.Weight)
+	model.healthCheck = c.HealthCheck
+	model.modalities = c.Modalities
+
+	return model, nil
 }
 
 // initClient initializes the language model client based on the provided configuration.
@@ -81,6 +107,16 @@ func (c *LangModelConfig) initClient(tel *telemetry.Telemetry) (LangProvider, er
 		return anthropic.NewClient(c.Anthropic, c.Client, tel)
 	case c.Bedrock != nil:
 		return bedrock.NewClient(c.Bedrock, c.Client, tel)
+	case c.Ollama != nil:
+		return ollama.NewClient(c.Ollama, c.Client, tel)
+	case c.Gemini != nil:
+		return gemini.NewClient(c.Gemini, c.Client, tel)
+	case c.Mistral != nil:
+		return mistral.NewClient(c.Mistral, c.Client, tel)
+	case c.Groq != nil:
+		return groq.NewClient(c.Groq, c.Client, tel)
+	case c.OpenAICompatible != nil:
+		return openaicompatible.NewClient(c.OpenAICompatible, c.Client, tel)
 	default:
 		return nil, ErrProviderNotFound
 	}
@@ -117,6 +153,22 @@ func (c *LangModelConfig) validateOneProvider() error {
 		providersConfigured++
 	}
 
+	if c.Gemini != nil {
+		providersConfigured++
+	}
+
+	if c.Mistral != nil {
+		providersConfigured++
+	}
+
+	if c.Groq != nil {
+		providersConfigured++
+	}
+
+	if c.OpenAICompatible != nil {
+		providersConfigured++
+	}
+
 	// check other providers here
 	if providersConfigured == 0 {
 		return fmt.Errorf("exactly one provider must be configured for model \"%v\", none is configured", c.ID)
@@ -144,3 +196,110 @@ func (c *LangModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 	return c.validateOneProvider()
 }
+
+// EmbedModelConfig defines an embedding model. Embedding models are served by the same providers as language models
+type EmbedModelConfig struct {
+	ID          string                `yaml:"id" json:"id" validate:"required"`           // Model instance ID (unique in scope of the router)
+	Enabled     bool                  `yaml:"enabled" json:"enabled" validate:"required"` // Is the model enabled?
+	ErrorBudget *health.ErrorBudget   `yaml:"error_budget" json:"error_budget" swaggertype:"primitive,string"`
+	Latency     *latency.Config       `yaml:"latency" json:"latency"`
+	Weight      int                   `yaml:"weight" json:"weight"`
+	Client      *clients.ClientConfig `yaml:"client" json:"client"`
+	// CircuitBreaker stops sending requests to the model that keeps failing
+	CircuitBreaker *health.CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`
+	// Dimensions is the length of vectors the model produces. All models of the router must produce vectors of the same length
+	Dimensions int `yaml:"dimensions" json:"dimensions" validate:"required,gt=0"`
+	// BatchSize limits the number of texts embedded in one provider request. The provider's max batch size is used by default
+	BatchSize   int                 `yaml:"batch_size,omitempty" json:"batch_size,omitempty" validate:"omitempty,gt=0"`
+	OpenAI      *openai.Config      `yaml:"openai,omitempty" json:"openai,omitempty"`
+	AzureOpenAI *azureopenai.Config `yaml:"azureopenai,omitempty" json:"azureopenai,omitempty"`
+	Cohere      *cohere.Config      `yaml:"cohere,omitempty" json:"cohere,omitempty"`
+	Bedrock     *bedrock.Config     `yaml:"bedrock,omitempty" json:"bedrock,omitempty"`
+	Ollama      *ollama.Config      `yaml:"ollama,omitempty" json:"ollama,omitempty"`
+}
+
+func DefaultEmbedModelConfig() *EmbedModelConfig {
+	return &EmbedModelConfig{
+		Enabled:        true,
+		Client:         clients.DefaultClientConfig(),
+		ErrorBudget:    health.DefaultErrorBudget(),
+		CircuitBreaker: health.DefaultCircuitBreakerConfig(),
+		Latency:        latency.DefaultConfig(),
+		Weight:         1,
+	}
+}
+
+func (c *EmbedModelConfig) ToModel(tel *telemetry.Telemetry) (*EmbeddingModel, error) {
+	client, err := c.initClient(tel)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing client: %v", err)
+	}
+
+	model := NewEmbedModel(c.ID, client, c.Dimensions, c.ErrorBudget, c.CircuitBreaker, *c.Latency, c.Weight)
+
+	if c.BatchSize > 0 && c.BatchSize < model.batchSize {
+		model.batchSize = c.BatchSize
+	}
+
+	return model, nil
+}
+
+// initClient initializes the embedding model client based on the provided configuration
+func (c *EmbedModelConfig) initClient(tel *telemetry.Telemetry) (EmbeddingProvider, error) {
+	switch {
+	case c.OpenAI != nil:
+		return openai.NewClient(c.OpenAI, c.Client, tel)
+	case c.AzureOpenAI != nil:
+		return azureopenai.NewClient(c.AzureOpenAI, c.Client, tel)
+	case c.Cohere != nil:
+		return cohere.NewClient(c.Cohere, c.Client, tel)
+	case c.Bedrock != nil:
+		return bedrock.NewClient(c.Bedrock, c.Client, tel)
+	case c.Ollama != nil:
+		return ollama.NewClient(c.Ollama, c.Client, tel)
+	default:
+		return nil, ErrProviderNotFound
+	}
+}
+
+func (c *EmbedModelConfig) validateOneProvider() error {
+	providersConfigured := 0
+
+	for _, configured := range []bool{
+		c.OpenAI != nil,
+		c.AzureOpenAI != nil,
+		c.Cohere != nil,
+		c.Bedrock != nil,
+		c.Ollama != nil,
+	} {
+		if configured {
+			providersConfigured++
+		}
+	}
+
+	if providersConfigured == 0 {
+		return fmt.Errorf("exactly one provider must be configured for embedding model \"%v\", none is configured", c.ID)
+	}
+
+	if providersConfigured > 1 {
+		return fmt.Errorf(
+			"exactly one provider must be configured for embedding model \"%v\", %v are configured",
+			c.ID,
+			providersConfigured,
+		)
+	}
+
+	return nil
+}
+
+func (c *EmbedModelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultEmbedModelConfig()
+
+	type plain EmbedModelConfig // to avoid recursion
+
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+
+	return c.validateOneProvider()
+}
@@ -0,0 +1,190 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+
+	"github.com/EinStack/glide/pkg/routers/health"
+
+	"github.com/EinStack/glide/pkg/routers/latency"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+var ErrDimensionsMismatch = errors.New("embedding dimensionality doesn't match the configured one")
+
+// EmbeddingProvider defines an interface a provider should fulfill to be able to serve embedding requests
+type EmbeddingProvider interface {
+	ModelProvider
+
+	// MaxEmbedBatchSize is the max number of texts the provider accepts in one embedding request
+	MaxEmbedBatchSize() int
+
+	Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error)
+}
+
+type EmbedModel interface {
+	Model
+	Provider() string
+	ModelName() string
+	Dimensions() int
+	Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error)
+}
+
+// EmbeddingModel wraps provider client and expend it with health & latency tracking
+//
+//	Inputs are split into batches the provider accepts. All batches are embedded by the same model,
+//	as vectors of different models are not comparable even if they have the same dimensionality
+type EmbeddingModel struct {
+	modelID               string
+	weight                int
+	dimensions            int
+	batchSize             int
+	client                EmbeddingProvider
+	healthTracker         *health.Tracker
+	embedLatency          *latency.MovingAverage
+	latencyUpdateInterval *fields.Duration
+}
+
+func NewEmbedModel(
+	modelID string,
+	client EmbeddingProvider,
+	dimensions int,
+	budget *health.ErrorBudget,
+	circuitConfig *health.CircuitBreakerConfig,
+	latencyConfig latency.Config,
+	weight int,
+) *EmbeddingModel {
+	return &EmbeddingModel{
+		modelID:               modelID,
+		client:                client,
+		dimensions:            dimensions,
+		batchSize:             client.MaxEmbedBatchSize(),
+		healthTracker:         health.NewTracker(budget, circuitConfig),
+		embedLatency:          latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		latencyUpdateInterval: latencyConfig.UpdateInterval,
+		weight:                weight,
+	}
+}
+
+func (m *EmbeddingModel) ID() string {
+	return m.modelID
+}
+
+func (m *EmbeddingModel) Healthy() bool {
+	return m.healthTracker.Healthy()
+}
+
+// HealthTracker returns the tracker of the model health (e.g. to manage the model circuit)
+func (m *EmbeddingModel) HealthTracker() *health.Tracker {
+	return m.healthTracker
+}
+
+func (m *EmbeddingModel) Weight() int {
+	return m.weight
+}
+
+func (m *EmbeddingModel) LatencyUpdateInterval() *fields.Duration {
+	return m.latencyUpdateInterval
+}
+
+func (m *EmbeddingModel) EmbedLatency() *latency.MovingAverage {
+	return m.embedLatency
+}
+
+func (m *EmbeddingModel) Provider() string {
+	return m.client.Provider()
+}
+
+func (m *EmbeddingModel) ModelName() string {
+	return m.client.ModelName()
+}
+
+// Dimensions is the length of vectors the model produces
+func (m *EmbeddingModel) Dimensions() int {
+	return m.dimensions
+}
+
+// BatchSize is the max number of texts sent to the provider in one request
+func (m *EmbeddingModel) BatchSize() int {
+	return m.batchSize
+}
+
+func (m *EmbeddingModel) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	if !m.healthTracker.Allow() {
+		return nil, health.ErrCircuitOpen
+	}
+
+	response := &schemas.EmbedResponse{
+		Embeddings: make([]schemas.Embedding, 0, len(params.Input)),
+	}
+
+	for offset := 0; offset < len(params.Input); offset += m.batchSize {
+		batch := params.Input[offset:min(offset+m.batchSize, len(params.Input))]
+
+		startedAt := time.Now()
+
+		batchResp, err := m.client.Embed(ctx, &schemas.EmbedParams{Input: batch})
+		if err != nil {
+			m.trackErr(ctx, err)
+
+			return nil, err
+		}
+
+		// record latency per input to normalize measurements
+		m.embedLatency.Add(float64(time.Since(startedAt)) / float64(len(batch)))
+
+		if len(batchResp.Embeddings) != len(batch) {
+			err := fmt.Errorf("expected %v embeddings, got %v", len(batch), len(batchResp.Embeddings))
+
+			// the model produces unusable output, so it's as failed as if it didn't respond
+			m.trackErr(ctx, err)
+
+			return nil, err
+		}
+
+		for _, embedding := range batchResp.Embeddings {
+			if len(embedding.Embedding) != m.dimensions {
+				err := fmt.Errorf(
+					"%w: expected %v, got %v",
+					ErrDimensionsMismatch,
+					m.dimensions,
+					len(embedding.Embedding),
+				)
+
+				m.trackErr(ctx, err)
+
+				return nil, err
+			}
+
+			embedding.Index += offset
+			response.Embeddings = append(response.Embeddings, embedding)
+		}
+
+		response.ID = batchResp.ID
+		response.Created = batchResp.Created
+		response.Provider = batchResp.Provider
+		response.ModelName = batchResp.ModelName
+		response.TokenUsage.PromptTokens += batchResp.TokenUsage.PromptTokens
+	}
+
+	m.healthTracker.TrackSuccess()
+
+	response.ModelID = m.modelID
+	response.Dimensions = m.dimensions
+
+	return response, nil
+}
+
+// trackErr counts the error against the model health unless the caller has cancelled the request
+func (m *EmbeddingModel) trackErr(ctx context.Context, err error) {
+	trackErr(ctx, m.healthTracker, err)
+}
+
+func EmbedLatency(model Model) *latency.MovingAverage {
+	return model.(*EmbeddingModel).EmbedLatency()
+}
@@ -0,0 +1,67 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddingModel_DimensionsMismatchSpendsErrBudget(t *testing.T) {
+	model := providers.NewEmbedModel(
+		"first",
+		ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Vector: []float64{0.1, 0.2}}}),
+		3,
+		health.NewErrorBudget(1, health.SEC),
+		nil,
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	_, err := model.Embed(context.Background(), &schemas.EmbedParams{Input: []string{"hello"}})
+	require.ErrorIs(t, err, providers.ErrDimensionsMismatch)
+
+	// vectors of wrong dimensionality are useless for clients, so the model is failing
+	require.Less(t, model.HealthTracker().ErrBudgetTokens(), 1.0)
+	require.False(t, model.Healthy())
+}
+
+func TestEmbeddingModel_CancelledRequestReleasesProbe(t *testing.T) {
+	circuitConfig := health.DefaultCircuitBreakerConfig()
+	circuitConfig.HalfOpenProbes = 1
+	circuitConfig.OpenDuration = fields.Duration(time.Millisecond)
+
+	model := providers.NewEmbedModel(
+		"first",
+		ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Err: context.Canceled}}),
+		3,
+		health.NewErrorBudget(1, health.SEC),
+		circuitConfig,
+		*latency.DefaultConfig(),
+		1,
+	)
+
+	circuit := model.HealthTracker().Circuit()
+	circuit.Trip()
+
+	time.Sleep(5 * time.Millisecond)
+	require.Equal(t, health.CircuitHalfOpen, circuit.State())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := model.Embed(ctx, &schemas.EmbedParams{Input: []string{"hello"}})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the cancelled request says nothing about the model health, so the probe slot is free for the next request
+	require.Equal(t, health.CircuitHalfOpen, circuit.State())
+	require.True(t, circuit.Available())
+	require.Equal(t, 1.0, model.HealthTracker().ErrBudgetTokens())
+}
@@ -0,0 +1,256 @@
+package gemini
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers/clients"
+)
+
+const (
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	// tokenLifetime is the max lifetime of access tokens issued for service accounts
+	tokenLifetime = time.Hour
+	// tokenExpiryLeeway makes sure the token is refreshed before it actually expires
+	tokenExpiryLeeway = time.Minute
+)
+
+var (
+	ErrNoCredentials         = errors.New("either api_key or service_account_key must be provided for gemini")
+	ErrAmbiguousCredentials  = errors.New("only one of api_key or service_account_key must be provided for gemini")
+	ErrInvalidServiceAccount = errors.New("invalid gemini service account key")
+	ErrNoProjectID           = errors.New("project_id must be provided for gemini when the service account key doesn't have it")
+)
+
+// Authenticator adds credentials to Gemini requests
+type Authenticator interface {
+	Authenticate(ctx context.Context, req *http.Request) error
+}
+
+// APIKeyAuth authenticates requests to Google AI API
+type APIKeyAuth struct {
+	apiKey fields.Secret
+}
+
+func NewAPIKeyAuth(apiKey fields.Secret) *APIKeyAuth {
+	return &APIKeyAuth{
+		apiKey: apiKey,
+	}
+}
+
+func (a *APIKeyAuth) Authenticate(_ context.Context, req *http.Request) error {
+	req.Header.Set("x-goog-api-key", string(a.apiKey))
+
+	return nil
+}
+
+// ServiceAccountKey is the JSON key of the Google Cloud service account
+type ServiceAccountKey struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// ServiceAccountAuth authenticates requests to Vertex AI API with OAuth2 access tokens.
+//
+//	Tokens are obtained via the JWT bearer flow and cached until they are about to expire
+//	Ref: https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+type ServiceAccountAuth struct {
+	key        *ServiceAccountKey
+	privateKey *rsa.PrivateKey
+	httpClient *http.Client
+	errMapper  *ErrorMapper
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func NewServiceAccountAuth(key *ServiceAccountKey, httpClient *http.Client, errMapper *ErrorMapper) (*ServiceAccountAuth, error) {
+	privateKey, err := parsePrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(key.ClientEmail) == 0 || len(key.TokenURI) == 0 {
+		return nil, fmt.Errorf("%w: client_email and token_uri are required", ErrInvalidServiceAccount)
+	}
+
+	return &ServiceAccountAuth{
+		key:        key,
+		privateKey: privateKey,
+		httpClient: httpClient,
+		errMapper:  errMapper,
+	}, nil
+}
+
+func (a *ServiceAccountAuth) Authenticate(ctx context.Context, req *http.Request) error {
+	token, err := a.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return nil
+}
+
+func (a *ServiceAccountAuth) accessToken(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	token, expiresAt := a.token, a.expiresAt
+	a.mu.Unlock()
+
+	if len(token) > 0 && time.Now().Before(expiresAt) {
+		return token, nil
+	}
+
+	// the lock is not held while the token is fetched, so a slow token endpoint doesn't block requests
+	//  that are cancelled in the meantime. Concurrent requests may fetch tokens at the same time then
+	token, expiresIn, err := a.fetchToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.token = token
+	a.expiresAt = time.Now().Add(expiresIn - tokenExpiryLeeway)
+
+	return token, nil
+}
+
+func (a *ServiceAccountAuth) fetchToken(ctx context.Context) (string, time.Duration, error) {
+	assertion, err := a.signedAssertion(time.Now())
+	if err != nil {
+		return "", 0, err
+	}
+
+	form := url.Values{
+		"grant_type": {jwtBearerGrantType},
+		"assertion":  {assertion},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to create gemini token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to send gemini token request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		// the service account key is revoked or invalid
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return "", 0, clients.ErrUnauthorized
+	default:
+		// OAuth outages & rate limits are transient, so they are not mistaken for bad credentials
+		return "", 0, a.errMapper.Map(resp)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", 0, fmt.Errorf("failed to parse gemini token response: %w", err)
+	}
+
+	return tokenResp.AccessToken, time.Duration(tokenResp.ExpiresIn) * time.Second, nil
+}
+
+func (a *ServiceAccountAuth) signedAssertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"kid": a.key.PrivateKeyID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iss":   a.key.ClientEmail,
+		"scope": cloudPlatformScope,
+		"aud":   a.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenLifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign gemini token assertion: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParseServiceAccountKey reads the service account JSON key
+func ParseServiceAccountKey(rawKey fields.Secret) (*ServiceAccountKey, error) {
+	var key ServiceAccountKey
+
+	if err := json.Unmarshal([]byte(rawKey), &key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidServiceAccount, err)
+	}
+
+	return &key, nil
+}
+
+func parsePrivateKey(rawKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(rawKey))
+	if block == nil {
+		return nil, fmt.Errorf("%w: private_key is not PEM encoded", ErrInvalidServiceAccount)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidServiceAccount, err)
+	}
+
+	key, ok := parsedKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: private_key is not an RSA key", ErrInvalidServiceAccount)
+	}
+
+	return key, nil
+}
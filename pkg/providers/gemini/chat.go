@@ -0,0 +1,290 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/google/uuid"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// Gemini uses its own naming of the assistant role
+const (
+	userRole  = "user"
+	modelRole = "model"
+)
+
+const jsonMimeType = "application/json"
+
+// ChatRequest is a Gemini-specific request schema
+type ChatRequest struct {
+	Contents          []Content        `json:"contents"`
+	SystemInstruction *Content         `json:"systemInstruction,omitempty"`
+	GenerationConfig  GenerationConfig `json:"generationConfig"`
+	Tools             []Tool           `json:"tools,omitempty"`
+	ToolConfig        *ToolConfig      `json:"toolConfig,omitempty"`
+}
+
+// ApplyParams maps chat messages to Gemini contents.
+//
+//	System messages are passed as the system instruction as Gemini doesn't accept them in the contents.
+//	Results of consecutive tool calls are grouped into one user content
+func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
+	// Gemini's response schemas support a limited subset of OpenAPI, so the schema is requested via the system instruction
+	messages := schemas.WithFormatInstruction(params.Messages, params.ResponseFormat)
+
+	contents := make([]Content, 0, len(messages))
+	systemParts := make([]Part, 0, 1)
+	functionNames := CollectFunctionNames(messages)
+
+	for _, message := range messages {
+		switch message.Role {
+		case "system":
+			systemParts = append(systemParts, Part{Text: message.Content})
+		case "assistant", modelRole:
+			parts := make([]Part, 0, len(message.ToolCalls)+1)
+
+			if len(message.Content) > 0 || len(message.ToolCalls) == 0 {
+				parts = append(parts, Part{Text: message.Content})
+			}
+
+			parts = append(parts, NewFunctionCallParts(message.ToolCalls)...)
+
+			contents = append(contents, Content{Role: modelRole, Parts: parts})
+		case "tool":
+			responsePart := NewFunctionResponsePart(&message, functionNames)
+			lastIdx := len(contents) - 1
+
+			if lastIdx >= 0 && contents[lastIdx].Role == userRole && contents[lastIdx].Parts[0].FunctionResponse != nil {
+				contents[lastIdx].Parts = append(contents[lastIdx].Parts, responsePart)
+
+				continue
+			}
+
+			contents = append(contents, Content{Role: userRole, Parts: []Part{responsePart}})
+		default:
+			contents = append(contents, Content{Role: userRole, Parts: NewParts(&message)})
+		}
+	}
+
+	r.Contents = contents
+	r.SystemInstruction = nil
+
+	if len(systemParts) > 0 {
+		r.SystemInstruction = &Content{Parts: systemParts}
+	}
+
+	r.Tools = NewTools(params.Tools)
+	r.ToolConfig = NewToolConfig(params.ToolChoice)
+	r.GenerationConfig.ResponseMimeType = ""
+
+	if params.ResponseFormat.IsJSON() {
+		r.GenerationConfig.ResponseMimeType = jsonMimeType
+	}
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature: &r.GenerationConfig.Temperature,
+		TopP:        &r.GenerationConfig.TopP,
+		TopK:        &r.GenerationConfig.TopK,
+		MaxTokens:   &r.GenerationConfig.MaxOutputTokens,
+		Stop:        &r.GenerationConfig.StopSequences,
+	})
+}
+
+// NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
+func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
+	return &ChatRequest{
+		GenerationConfig: GenerationConfig{
+			Temperature:     schemas.DefaultParam(cfg.DefaultParams.Temperature),
+			TopP:            schemas.DefaultParam(cfg.DefaultParams.TopP),
+			TopK:            cfg.DefaultParams.TopK,
+			MaxOutputTokens: cfg.DefaultParams.MaxOutputTokens,
+			StopSequences:   cfg.DefaultParams.StopSequences,
+		},
+	}
+}
+
+// Chat sends a chat request to the specified Gemini model.
+//
+//	Ref: https://ai.google.dev/api/generate-content#method:-models.generatecontent
+func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error) {
+	// Create a new chat request
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate // hoping to get a copy of the template
+	chatReq.ApplyParams(params)
+
+	chatResponse, err := c.doChatRequest(ctx, &chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return chatResponse, nil
+}
+
+func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*schemas.ChatResponse, error) {
+	req, err := c.newRequest(ctx, c.chatURL, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.tel.Logger.Debug(
+		"Chat request",
+		zap.String("provider", providerName),
+		zap.String("chatURL", c.chatURL),
+		zap.Any("payload", payload),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send gemini chat request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	// Read the response body into a byte slice
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tel.Logger.Error("Failed to read gemini chat response", zap.Error(err))
+
+		return nil, err
+	}
+
+	// Parse the response JSON
+	var completion ChatCompletion
+
+	err = json.Unmarshal(bodyBytes, &completion)
+	if err != nil {
+		c.tel.Logger.Error("Failed to parse gemini chat response", zap.Error(err))
+
+		return nil, err
+	}
+
+	content, toolCalls, finishReason, err := c.candidateResult(&completion)
+	if err != nil {
+		c.tel.Logger.Error("Failed to parse gemini function calls", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(content) == 0 && len(toolCalls) == 0 && (finishReason == nil || *finishReason != schemas.ReasonContentFiltered) {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	tokenUsage := schemas.TokenUsage{}
+
+	if usage := completion.UsageMetadata; usage != nil {
+		tokenUsage = schemas.TokenUsage{
+			PromptTokens:   usage.PromptTokenCount,
+			ResponseTokens: usage.CandidatesTokenCount,
+			TotalTokens:    usage.TotalTokenCount,
+		}
+	}
+
+	// Map response to ChatResponse schema
+	response := schemas.ChatResponse{
+		ID:        uuid.NewString(),
+		Created:   int(time.Now().UTC().Unix()),
+		Provider:  providerName,
+		ModelName: c.config.ModelName,
+		Cached:    false,
+		ModelResponse: schemas.ModelResponse{
+			Metadata: map[string]string{
+				"model_version": completion.ModelVersion,
+			},
+			Message: schemas.ChatMessage{
+				Role:      "assistant",
+				Content:   content,
+				ToolCalls: toolCalls,
+			},
+			TokenUsage: tokenUsage,
+		},
+		FinishReason: finishReason,
+	}
+
+	return &response, nil
+}
+
+// candidateResult picks the content, function calls and the finish reason of the first candidate.
+//
+//	When the prompt is blocked, there are no candidates at all, so the response is considered to be filtered.
+//	Gemini stops normally when it requests function calls, so the finish reason is adjusted in that case
+func (c *Client) candidateResult(completion *ChatCompletion) (string, []schemas.ToolCall, *schemas.FinishReason, error) {
+	if len(completion.Candidates) == 0 {
+		if feedback := completion.PromptFeedback; feedback != nil && feedback.BlockReason != nil {
+			c.tel.Logger.Warn(
+				"Prompt was blocked",
+				zap.String("provider", providerName),
+				zap.String("blockReason", *feedback.BlockReason),
+			)
+
+			return "", nil, &schemas.ReasonContentFiltered, nil
+		}
+
+		return "", nil, nil, nil
+	}
+
+	candidate := completion.Candidates[0]
+
+	var (
+		content   strings.Builder
+		toolCalls []schemas.ToolCall
+	)
+
+	for _, part := range candidate.Content.Parts {
+		if part.FunctionCall != nil {
+			toolCall, err := NewToolCall(part.FunctionCall)
+			if err != nil {
+				return "", nil, nil, err
+			}
+
+			toolCalls = append(toolCalls, toolCall)
+
+			continue
+		}
+
+		content.WriteString(part.Text)
+	}
+
+	finishReason := c.finishReasonMapper.Map(candidate.FinishReason)
+
+	if len(toolCalls) > 0 && finishReason != nil && *finishReason == schemas.ReasonComplete {
+		finishReason = &schemas.ReasonToolCalls
+	}
+
+	return content.String(), toolCalls, finishReason, nil
+}
+
+func (c *Client) newRequest(ctx context.Context, url string, payload *ChatRequest) (*http.Request, error) {
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal gemini chat request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create gemini chat request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if err := c.auth.Authenticate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
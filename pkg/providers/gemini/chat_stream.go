@@ -0,0 +1,237 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/r3labs/sse/v2"
+
+	"go.uber.org/zap"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// ChatStream represents Gemini chat stream for a specific request.
+//
+//	Gemini streams chunks as Server-Sent Events and just closes the connection when the stream is over
+type ChatStream struct {
+	tel                *telemetry.Telemetry
+	client             *http.Client
+	req                *http.Request
+	modelName          string
+	resp               *http.Response
+	reader             *sse.EventStreamReader
+	toolCallCount      int
+	streamFinished     bool
+	finishReasonMapper *FinishReasonMapper
+	errMapper          *ErrorMapper
+}
+
+func NewChatStream(
+	tel *telemetry.Telemetry,
+	client *http.Client,
+	req *http.Request,
+	modelName string,
+	finishReasonMapper *FinishReasonMapper,
+	errMapper *ErrorMapper,
+) *ChatStream {
+	return &ChatStream{
+		tel:                tel,
+		client:             client,
+		req:                req,
+		modelName:          modelName,
+		finishReasonMapper: finishReasonMapper,
+		errMapper:          errMapper,
+	}
+}
+
+func (s *ChatStream) Open() error {
+	resp, err := s.client.Do(s.req) //nolint:bodyclose
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s.errMapper.Map(resp)
+	}
+
+	s.resp = resp
+	s.reader = sse.NewEventStreamReader(resp.Body, 8192) // TODO: should we expose maxBufferSize?
+
+	return nil
+}
+
+func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
+	if s.streamFinished {
+		return nil, io.EOF
+	}
+
+	for {
+		rawEvent, err := s.reader.ReadEvent()
+		if err != nil {
+			s.tel.L().Warn(
+				"Chat stream is unexpectedly disconnected",
+				zap.String("provider", providerName),
+				zap.Error(err),
+			)
+
+			// if err is io.EOF, this still means that the stream is interrupted unexpectedly
+			//  because the normal stream termination is done via finding out the finish reason
+
+			return nil, clients.ErrProviderUnavailable
+		}
+
+		s.tel.L().Debug(
+			"Raw chat stream chunk",
+			zap.String("provider", providerName),
+			zap.ByteString("rawChunk", rawEvent),
+		)
+
+		event, err := clients.ParseSSEvent(rawEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse chat stream message: %v", err)
+		}
+
+		if !event.HasContent() {
+			s.tel.L().Debug(
+				"Received an empty message in chat stream, skipping it",
+				zap.String("provider", providerName),
+				zap.Any("msg", event),
+			)
+
+			continue
+		}
+
+		var completionChunk ChatCompletion
+
+		err = json.Unmarshal(event.Data, &completionChunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal gemini chat stream chunk: %v", err)
+		}
+
+		chunk := &schemas.ChatStreamChunk{
+			Cached:    false,
+			Provider:  providerName,
+			ModelName: s.modelName,
+			ModelResponse: schemas.ModelChunkResponse{
+				Message: schemas.ChatMessage{
+					Role: "assistant",
+				},
+			},
+		}
+
+		if len(completionChunk.Candidates) == 0 {
+			feedback := completionChunk.PromptFeedback
+			if feedback == nil || feedback.BlockReason == nil {
+				continue
+			}
+
+			// the prompt was blocked, so no candidates are going to be generated
+			s.streamFinished = true
+			chunk.FinishReason = &schemas.ReasonContentFiltered
+
+			return chunk, nil
+		}
+
+		candidate := completionChunk.Candidates[0]
+
+		for _, part := range candidate.Content.Parts {
+			if part.FunctionCall == nil {
+				chunk.ModelResponse.Message.Content += part.Text
+
+				continue
+			}
+
+			// Gemini streams function calls as a whole, so each of them takes only one chunk
+			toolCall, err := NewToolCall(part.FunctionCall)
+			if err != nil {
+				return nil, err
+			}
+
+			toolCallIdx := s.toolCallCount
+			toolCall.Index = &toolCallIdx
+			s.toolCallCount++
+
+			chunk.ModelResponse.Message.ToolCalls = append(chunk.ModelResponse.Message.ToolCalls, toolCall)
+		}
+
+		chunk.FinishReason = s.finishReasonMapper.Map(candidate.FinishReason)
+
+		if s.toolCallCount > 0 && chunk.FinishReason != nil && *chunk.FinishReason == schemas.ReasonComplete {
+			chunk.FinishReason = &schemas.ReasonToolCalls
+		}
+
+		if chunk.FinishReason != nil {
+			s.streamFinished = true
+
+			if usage := completionChunk.UsageMetadata; usage != nil {
+				chunk.ModelResponse.Metadata = &schemas.Metadata{
+					"prompt_tokens":   usage.PromptTokenCount,
+					"response_tokens": usage.CandidatesTokenCount,
+					"total_tokens":    usage.TotalTokenCount,
+				}
+			}
+		}
+
+		return chunk, nil
+	}
+}
+
+func (s *ChatStream) Close() error {
+	if s.resp != nil {
+		return s.resp.Body.Close()
+	}
+
+	return nil
+}
+
+func (c *Client) SupportChatStream() bool {
+	return true
+}
+
+func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	// Create a new chat request
+	httpRequest, err := c.makeStreamReq(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChatStream(
+		c.tel,
+		c.httpClient,
+		httpRequest,
+		c.config.ModelName,
+		c.finishReasonMapper,
+		c.errMapper,
+	), nil
+}
+
+func (c *Client) makeStreamReq(ctx context.Context, params *schemas.ChatParams) (*http.Request, error) {
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate
+	chatReq.ApplyParams(params)
+
+	request, err := c.newRequest(ctx, c.chatStreamURL, &chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Cache-Control", "no-cache")
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("Connection", "keep-alive")
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.tel.L().Debug(
+		"Stream chat request",
+		zap.String("chatURL", c.chatStreamURL),
+		zap.Any("payload", chatReq),
+	)
+
+	return request, nil
+}
@@ -0,0 +1,160 @@
+package gemini
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeminiClient_ChatStreamSupported(t *testing.T) {
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportChatStream())
+}
+
+func TestGeminiClient_ChatStreamRequest(t *testing.T) {
+	tests := map[string]string{
+		"success stream": "./testdata/chat_stream.success.txt",
+	}
+
+	for name, streamFile := range tests {
+		t.Run(name, func(t *testing.T) {
+			geminiMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "/models/gemini-1.5-flash:streamGenerateContent", r.URL.Path)
+				require.Equal(t, "sse", r.URL.Query().Get("alt"))
+
+				chatResponse, err := os.ReadFile(filepath.Clean(streamFile))
+				if err != nil {
+					t.Errorf("error reading gemini chat mock response: %v", err)
+				}
+
+				w.Header().Set("Content-Type", "text/event-stream")
+
+				_, err = w.Write(chatResponse)
+				if err != nil {
+					t.Errorf("error on sending chat response: %v", err)
+				}
+			})
+
+			geminiServer := httptest.NewServer(geminiMock)
+			defer geminiServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = geminiServer.URL
+			providerCfg.APIKey = "test-key"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the capital of the United Kingdom?",
+			}}}
+
+			stream, err := client.ChatStream(ctx, &chatParams)
+			require.NoError(t, err)
+
+			err = stream.Open()
+			require.NoError(t, err)
+
+			var lastChunk *schemas.ChatStreamChunk
+
+			for {
+				chunk, err := stream.Recv()
+
+				if err == io.EOF {
+					require.NotNil(t, lastChunk)
+					require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+					require.NotNil(t, lastChunk.ModelResponse.Metadata)
+					require.Equal(t, 18, (*lastChunk.ModelResponse.Metadata)["total_tokens"])
+
+					return
+				}
+
+				require.NoError(t, err)
+				require.NotNil(t, chunk)
+
+				lastChunk = chunk
+			}
+		})
+	}
+}
+
+func TestGeminiClient_ChatStreamRequestInterrupted(t *testing.T) {
+	tests := map[string]string{
+		"success stream, but no finish reason": "./testdata/chat_stream.nodone.txt",
+	}
+
+	for name, streamFile := range tests {
+		t.Run(name, func(t *testing.T) {
+			geminiMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				chatResponse, err := os.ReadFile(filepath.Clean(streamFile))
+				if err != nil {
+					t.Errorf("error reading gemini chat mock response: %v", err)
+				}
+
+				w.Header().Set("Content-Type", "text/event-stream")
+
+				_, err = w.Write(chatResponse)
+				if err != nil {
+					t.Errorf("error on sending chat response: %v", err)
+				}
+			})
+
+			geminiServer := httptest.NewServer(geminiMock)
+			defer geminiServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = geminiServer.URL
+			providerCfg.APIKey = "test-key"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the capital of the United Kingdom?",
+			}}}
+
+			stream, err := client.ChatStream(ctx, &chatParams)
+			require.NoError(t, err)
+
+			err = stream.Open()
+			require.NoError(t, err)
+
+			for {
+				chunk, err := stream.Recv()
+				if err != nil {
+					require.ErrorIs(t, err, clients.ErrProviderUnavailable)
+					return
+				}
+
+				require.NotNil(t, chunk)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package gemini
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+const (
+	providerName = "gemini"
+	// GoogleAIBaseURL is used when authenticating via API keys
+	GoogleAIBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+	// vertexAIBaseURL is used when authenticating via service accounts
+	vertexAIBaseURL = "https://%s-aiplatform.googleapis.com/v1"
+)
+
+// Client is a client for accessing Gemini API
+type Client struct {
+	baseURL             string
+	chatURL             string
+	chatStreamURL       string
+	chatRequestTemplate *ChatRequest
+	auth                Authenticator
+	errMapper           *ErrorMapper
+	finishReasonMapper  *FinishReasonMapper
+	config              *Config
+	httpClient          *http.Client
+	tel                 *telemetry.Telemetry
+}
+
+// NewClient creates a new Gemini client for Google AI or Vertex AI API depending on the provided credentials
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	httpClient := &http.Client{
+		Timeout: time.Duration(*clientConfig.Timeout),
+		Transport: &http.Transport{
+			MaxIdleConns:        *clientConfig.MaxIdleConns,
+			MaxIdleConnsPerHost: *clientConfig.MaxIdleConnsPerHost,
+		},
+	}
+
+	errMapper := NewErrorMapper(tel)
+
+	auth, baseURL, modelPath, err := resolveAPI(providerConfig, httpClient, errMapper)
+	if err != nil {
+		return nil, err
+	}
+
+	modelURL, err := url.JoinPath(baseURL, modelPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:             baseURL,
+		chatURL:             modelURL + ":generateContent",
+		chatStreamURL:       modelURL + ":streamGenerateContent?alt=sse",
+		config:              providerConfig,
+		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		auth:                auth,
+		errMapper:           errMapper,
+		finishReasonMapper:  NewFinishReasonMapper(tel),
+		httpClient:          httpClient,
+		tel:                 tel,
+	}
+
+	return c, nil
+}
+
+// resolveAPI picks Google AI API for API keys and Vertex AI API for service accounts
+func resolveAPI(cfg *Config, httpClient *http.Client, errMapper *ErrorMapper) (Authenticator, string, string, error) {
+	hasAPIKey := len(cfg.APIKey) > 0
+	hasServiceAccount := len(cfg.ServiceAccountKey) > 0
+
+	if hasAPIKey && hasServiceAccount {
+		return nil, "", "", ErrAmbiguousCredentials
+	}
+
+	if hasAPIKey {
+		baseURL := cfg.BaseURL
+		if len(baseURL) == 0 {
+			baseURL = GoogleAIBaseURL
+		}
+
+		return NewAPIKeyAuth(cfg.APIKey), baseURL, "/models/" + cfg.ModelName, nil
+	}
+
+	if !hasServiceAccount {
+		return nil, "", "", ErrNoCredentials
+	}
+
+	serviceAccountKey, err := ParseServiceAccountKey(cfg.ServiceAccountKey)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	auth, err := NewServiceAccountAuth(serviceAccountKey, httpClient, errMapper)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	projectID := cfg.ProjectID
+	if len(projectID) == 0 {
+		projectID = serviceAccountKey.ProjectID
+	}
+
+	if len(projectID) == 0 {
+		return nil, "", "", ErrNoProjectID
+	}
+
+	baseURL := cfg.BaseURL
+	if len(baseURL) == 0 {
+		baseURL = fmt.Sprintf(vertexAIBaseURL, cfg.Location)
+	}
+
+	modelPath := fmt.Sprintf("/projects/%s/locations/%s/publishers/google/models/%s", projectID, cfg.Location, cfg.ModelName)
+
+	return auth, baseURL, modelPath, nil
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+func (c *Client) ModelName() string {
+	return c.config.ModelName
+}
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage || modality == schemas.ModalityDocument
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamTopK,
+		schemas.ParamMaxTokens, schemas.ParamStop:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,490 @@
+package gemini
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeminiClient_ChatRequest(t *testing.T) {
+	// Gemini Chat API: https://ai.google.dev/api/generate-content
+	geminiMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/models/gemini-1.5-flash:generateContent", r.URL.Path)
+		require.Equal(t, "test-key", r.Header.Get("x-goog-api-key"))
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.NotNil(t, data.SystemInstruction)
+		require.Equal(t, "You are a zoologist", data.SystemInstruction.Parts[0].Text)
+		require.Len(t, data.Contents, 3)
+		require.Equal(t, userRole, data.Contents[0].Role)
+		require.Equal(t, modelRole, data.Contents[1].Role)
+		require.Equal(t, userRole, data.Contents[2].Role)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading gemini chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	geminiServer := httptest.NewServer(geminiMock)
+	defer geminiServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = geminiServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{
+		{Role: "system", Content: "You are a zoologist"},
+		{Role: "user", Content: "Hi!"},
+		{Role: "assistant", Content: "Hello! How can I help you?"},
+		{Role: "user", Content: "What's the biggest animal?"},
+	}}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	require.Equal(t, providerCfg.ModelName, response.ModelName)
+	require.Equal(t, "The blue whale is the biggest animal that has ever lived.", response.ModelResponse.Message.Content)
+	require.Equal(t, &schemas.ReasonComplete, response.FinishReason)
+	require.Equal(t, 12, response.ModelResponse.TokenUsage.PromptTokens)
+	require.Equal(t, 13, response.ModelResponse.TokenUsage.ResponseTokens)
+	require.Equal(t, 25, response.ModelResponse.TokenUsage.TotalTokens)
+}
+
+func TestGeminiClient_ChatRequestBlocked(t *testing.T) {
+	geminiMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.blocked.json"))
+		if err != nil {
+			t.Errorf("error reading gemini chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	geminiServer := httptest.NewServer(geminiMock)
+	defer geminiServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = geminiServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "How to do something dangerous?",
+	}}}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	require.Empty(t, response.ModelResponse.Message.Content)
+	require.Equal(t, &schemas.ReasonContentFiltered, response.FinishReason)
+}
+
+func TestGeminiClient_Chat_Error(t *testing.T) {
+	tests := map[int]error{
+		http.StatusForbidden:           clients.ErrUnauthorized,
+		http.StatusInternalServerError: clients.ErrProviderUnavailable,
+	}
+
+	for statusCode, expectedErr := range tests {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			geminiMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, `{"error": {"code": 0, "message": "Error", "status": "ERROR"}}`, statusCode)
+			})
+
+			geminiServer := httptest.NewServer(geminiMock)
+			defer geminiServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = geminiServer.URL
+			providerCfg.APIKey = "test-key"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			response, err := client.Chat(ctx, &chatParams)
+			require.Nil(t, response)
+			require.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
+
+func TestGeminiClient_Chat_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		untilReset time.Duration
+	}{
+		"retry after in seconds": {map[string]string{"Retry-After": "30"}, 30 * time.Second},
+		"no cooldown header":     {map[string]string{}, time.Minute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			geminiMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for header, value := range tt.headers {
+					w.Header().Set(header, value)
+				}
+
+				http.Error(w, `{"error": {"code": 429, "message": "Resource has been exhausted", "status": "RESOURCE_EXHAUSTED"}}`, http.StatusTooManyRequests)
+			})
+
+			geminiServer := httptest.NewServer(geminiMock)
+			defer geminiServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = geminiServer.URL
+			providerCfg.APIKey = "test-key"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			response, err := client.Chat(ctx, &chatParams)
+			require.Nil(t, response)
+
+			var rateLimitErr *clients.RateLimitError
+
+			require.ErrorAs(t, err, &rateLimitErr)
+			require.Equal(t, tt.untilReset, rateLimitErr.UntilReset())
+		})
+	}
+}
+
+func newServiceAccountKey(t *testing.T, tokenURI string) fields.Secret {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	serviceAccountKey, err := json.Marshal(ServiceAccountKey{
+		Type:         "service_account",
+		ProjectID:    "test-project",
+		PrivateKeyID: "test-key-id",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+		})),
+		ClientEmail: "glide@test-project.iam.gserviceaccount.com",
+		TokenURI:    tokenURI,
+	})
+	require.NoError(t, err)
+
+	return fields.Secret(serviceAccountKey)
+}
+
+func TestGeminiClient_ServiceAccountAuth(t *testing.T) {
+	tokenRequests := 0
+
+	geminiMock := http.NewServeMux()
+	geminiMock.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, jwtBearerGrantType, r.PostForm.Get("grant_type"))
+		require.NotEmpty(t, r.PostForm.Get("assertion"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "test-token", "expires_in": 3599, "token_type": "Bearer"}`))
+	})
+	geminiMock.HandleFunc(
+		"/projects/test-project/locations/us-central1/publishers/google/models/gemini-1.5-flash:generateContent",
+		func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+
+			chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+			if err != nil {
+				t.Errorf("error reading gemini chat mock response: %v", err)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write(chatResponse)
+		},
+	)
+
+	geminiServer := httptest.NewServer(geminiMock)
+	defer geminiServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = geminiServer.URL
+	providerCfg.ServiceAccountKey = newServiceAccountKey(t, geminiServer.URL+"/token")
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the biggest animal?",
+	}}}
+
+	for range 2 {
+		_, err = client.Chat(ctx, &chatParams)
+		require.NoError(t, err)
+	}
+
+	// the access token is cached between requests
+	require.Equal(t, 1, tokenRequests)
+}
+
+func TestGeminiClient_ServiceAccountAuthErrors(t *testing.T) {
+	// only rejected credentials trip the model circuit as unauthorized
+	tests := map[int]bool{
+		http.StatusUnauthorized:       true,
+		http.StatusTooManyRequests:    false,
+		http.StatusServiceUnavailable: false,
+	}
+
+	for statusCode, unauthorized := range tests {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			tokenMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, `{"error": "token request failed"}`, statusCode)
+			})
+
+			tokenServer := httptest.NewServer(tokenMock)
+			defer tokenServer.Close()
+
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = tokenServer.URL
+			providerCfg.ServiceAccountKey = newServiceAccountKey(t, tokenServer.URL)
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			_, err = client.Chat(context.Background(), &chatParams)
+			require.Error(t, err)
+			require.Equal(t, unauthorized, errors.Is(err, clients.ErrUnauthorized))
+		})
+	}
+}
+
+func TestGeminiClient_Credentials(t *testing.T) {
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg := DefaultConfig()
+
+	_, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.ErrorIs(t, err, ErrNoCredentials)
+
+	providerCfg.APIKey = "test-key"
+	providerCfg.ServiceAccountKey = `{"type": "service_account"}`
+
+	_, err = NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.ErrorIs(t, err, ErrAmbiguousCredentials)
+
+	providerCfg.APIKey = ""
+
+	_, err = NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.ErrorIs(t, err, ErrInvalidServiceAccount)
+}
+
+func TestGeminiClient_ChatRequestFunctionCall(t *testing.T) {
+	geminiMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Len(t, data.Contents, 3)
+		require.Equal(t, "get_weather", data.Contents[1].Parts[0].FunctionCall.Name)
+		require.Equal(t, "Paris", data.Contents[1].Parts[0].FunctionCall.Args["location"])
+		require.Equal(t, userRole, data.Contents[2].Role)
+		require.Equal(t, "get_weather", data.Contents[2].Parts[0].FunctionResponse.Name)
+		require.Equal(t, map[string]any{"temperature": float64(15)}, data.Contents[2].Parts[0].FunctionResponse.Response)
+		require.Len(t, data.Tools, 1)
+		require.Equal(t, "get_weather", data.Tools[0].FunctionDeclarations[0].Name)
+		require.Equal(t, FunctionCallingAny, data.ToolConfig.FunctionCallingConfig.Mode)
+		require.Equal(t, []string{"get_weather"}, data.ToolConfig.FunctionCallingConfig.AllowedFunctionNames)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.function_call.json"))
+		if err != nil {
+			t.Errorf("error reading gemini chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	geminiServer := httptest.NewServer(geminiMock)
+	defer geminiServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = geminiServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{
+			{Role: "user", Content: "What's the weather in Paris?"},
+			{Role: "assistant", ToolCalls: []schemas.ToolCall{{
+				ID:       "call_1",
+				Type:     schemas.ToolTypeFunction,
+				Function: schemas.ToolCallFunction{Name: "get_weather", Arguments: `{"location": "Paris"}`},
+			}}},
+			{Role: "tool", ToolCallID: "call_1", Content: `{"temperature": 15}`},
+		},
+		Tools: []schemas.Tool{{
+			Type:     schemas.ToolTypeFunction,
+			Function: schemas.ToolFunction{Name: "get_weather"},
+		}},
+		ToolChoice: &schemas.ToolChoice{Mode: schemas.ToolChoiceFunction, FunctionName: "get_weather"},
+	}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	message := response.ModelResponse.Message
+
+	require.Equal(t, &schemas.ReasonToolCalls, response.FinishReason)
+	require.Len(t, message.ToolCalls, 1)
+	require.NotEmpty(t, message.ToolCalls[0].ID)
+	require.Equal(t, "get_weather", message.ToolCalls[0].Function.Name)
+	require.JSONEq(t, `{"location": "London, UK"}`, message.ToolCalls[0].Function.Arguments)
+}
+
+func TestGeminiClient_ChatRequestMultimodal(t *testing.T) {
+	geminiMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data ChatRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		parts := data.Contents[0].Parts
+
+		require.Len(t, parts, 4)
+		require.Equal(t, "Compare these", parts[0].Text)
+		require.Equal(t, &Blob{MimeType: "image/png", Data: "iVBORw0KGgo="}, parts[1].InlineData)
+		require.Equal(t, &FileData{MimeType: "image/jpeg", FileURI: "gs://bucket/cat.jpg"}, parts[2].FileData)
+		require.Equal(t, &FileData{MimeType: "application/pdf", FileURI: "gs://bucket/report.pdf"}, parts[3].FileData)
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading gemini chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	geminiServer := httptest.NewServer(geminiMock)
+	defer geminiServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = geminiServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role: "user",
+		ContentParts: []schemas.ContentPart{
+			{Type: schemas.ContentPartText, Text: "Compare these"},
+			{Type: schemas.ContentPartImageURL, ImageURL: &schemas.ImageURL{URL: "data:image/png;base64,iVBORw0KGgo="}},
+			{Type: schemas.ContentPartImageURL, ImageURL: &schemas.ImageURL{URL: "gs://bucket/cat.jpg"}},
+			{Type: schemas.ContentPartDocument, Document: &schemas.Document{MediaType: "application/pdf", URL: "gs://bucket/report.pdf"}},
+		},
+	}}}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
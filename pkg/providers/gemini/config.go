@@ -0,0 +1,65 @@
+package gemini
+
+import (
+	"github.com/EinStack/glide/pkg/config/fields"
+)
+
+// Params defines Gemini-specific model params with the specific validation of values
+// TODO: Add validations
+type Params struct {
+	Temperature     float64  `yaml:"temperature,omitempty" json:"temperature"`
+	TopP            float64  `yaml:"top_p,omitempty" json:"top_p"`
+	TopK            int      `yaml:"top_k,omitempty" json:"top_k"`
+	MaxOutputTokens int      `yaml:"max_output_tokens,omitempty" json:"max_output_tokens"`
+	StopSequences   []string `yaml:"stop_sequences,omitempty" json:"stop_sequences"`
+}
+
+func DefaultParams() Params {
+	return Params{
+		Temperature:     1,
+		TopP:            0.95,
+		MaxOutputTokens: 512,
+		StopSequences:   []string{},
+	}
+}
+
+func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*p = DefaultParams()
+
+	type plain Params // to avoid recursion
+
+	return unmarshal((*plain)(p))
+}
+
+// Config for Gemini models.
+//
+//	Gemini is available via Google AI API (authenticated by an API key)
+//	or via Vertex AI API (authenticated by a service account key)
+type Config struct {
+	BaseURL           string        `yaml:"base_url,omitempty" json:"base_url"` // derived from the auth method by default
+	ModelName         string        `yaml:"model" json:"model" validate:"required"`
+	APIKey            fields.Secret `yaml:"api_key,omitempty" json:"-"`
+	ServiceAccountKey fields.Secret `yaml:"service_account_key,omitempty" json:"-"` // the service account JSON key
+	ProjectID         string        `yaml:"project_id,omitempty" json:"project_id"` // taken from the service account key by default
+	Location          string        `yaml:"location,omitempty" json:"location"`
+	DefaultParams     *Params       `yaml:"default_params,omitempty" json:"default_params"`
+}
+
+// DefaultConfig for Gemini models
+func DefaultConfig() *Config {
+	defaultParams := DefaultParams()
+
+	return &Config{
+		ModelName:     "gemini-1.5-flash",
+		Location:      "us-central1",
+		DefaultParams: &defaultParams,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,71 @@
+package gemini
+
+import (
+	"mime"
+	"net/url"
+	"path"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// defaultImageMimeType is used when the image type can't be guessed from its URL
+const defaultImageMimeType = "image/jpeg"
+
+// NewParts maps the message content to Gemini parts
+// Ref: https://ai.google.dev/gemini-api/docs/vision
+func NewParts(message *schemas.ChatMessage) []Part {
+	if !message.IsMultimodal() {
+		return []Part{{Text: message.Content}}
+	}
+
+	parts := make([]Part, 0, len(message.ContentParts))
+
+	for _, part := range message.ContentParts {
+		switch part.Type {
+		case schemas.ContentPartImageURL:
+			parts = append(parts, newImagePart(part.ImageURL))
+		case schemas.ContentPartDocument:
+			parts = append(parts, newDocumentPart(part.Document))
+		default:
+			parts = append(parts, Part{Text: part.Text})
+		}
+	}
+
+	return parts
+}
+
+func newImagePart(image *schemas.ImageURL) Part {
+	if mimeType, data, ok := image.Data(); ok {
+		return Part{InlineData: &Blob{MimeType: mimeType, Data: data}}
+	}
+
+	mimeType := guessMimeType(image.URL)
+	if len(mimeType) == 0 {
+		mimeType = defaultImageMimeType
+	}
+
+	return Part{FileData: &FileData{MimeType: mimeType, FileURI: image.URL}}
+}
+
+func newDocumentPart(document *schemas.Document) Part {
+	if len(document.Data) > 0 {
+		return Part{InlineData: &Blob{MimeType: document.MediaType, Data: document.Data}}
+	}
+
+	mimeType := document.MediaType
+	if len(mimeType) == 0 {
+		mimeType = guessMimeType(document.URL)
+	}
+
+	return Part{FileData: &FileData{MimeType: mimeType, FileURI: document.URL}}
+}
+
+// guessMimeType guesses the media type by the file extension as Gemini requires it for file references
+func guessMimeType(fileURL string) string {
+	parsedURL, err := url.Parse(fileURL)
+	if err != nil {
+		return ""
+	}
+
+	return mime.TypeByExtension(path.Ext(parsedURL.Path))
+}
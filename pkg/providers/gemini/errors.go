@@ -0,0 +1,56 @@
+package gemini
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"go.uber.org/zap"
+)
+
+type ErrorMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
+	return &ErrorMapper{
+		tel: tel,
+	}
+}
+
+func (m *ErrorMapper) Map(resp *http.Response) error {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		m.tel.Logger.Error(
+			"Failed to unmarshal chat response error",
+			zap.String("provider", providerName),
+			zap.Error(err),
+			zap.ByteString("rawResponse", bodyBytes),
+		)
+
+		return clients.ErrProviderUnavailable
+	}
+
+	m.tel.Logger.Error(
+		"Chat request failed",
+		zap.String("provider", providerName),
+		zap.Int("statusCode", resp.StatusCode),
+		zap.String("response", string(bodyBytes)),
+		zap.Any("headers", resp.Header),
+	)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// Gemini rarely sends the cooldown delay, so the default one is used in that case
+		return clients.NewRateLimitError(clients.ResetDelayFromHeaders(resp.Header, "Retry-After"))
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return clients.ErrUnauthorized
+	}
+
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	return clients.NewResponseError(resp.StatusCode, bodyBytes)
+}
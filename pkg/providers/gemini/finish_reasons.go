@@ -0,0 +1,63 @@
+package gemini
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+var (
+	// Reference: https://ai.google.dev/api/generate-content#FinishReason
+	CompleteReason  = "stop"
+	MaxTokensReason = "max_tokens"
+	// BlockedReasons are reported when candidates are blocked by safety filters
+	BlockedReasons = []string{
+		"safety",
+		"recitation",
+		"blocklist",
+		"prohibited_content",
+		"spii",
+	}
+)
+
+func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
+	return &FinishReasonMapper{
+		tel: tel,
+	}
+}
+
+type FinishReasonMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func (m *FinishReasonMapper) Map(finishReason *string) *schemas.FinishReason {
+	if finishReason == nil || len(*finishReason) == 0 {
+		return nil
+	}
+
+	var reason *schemas.FinishReason
+
+	normalizedReason := strings.ToLower(*finishReason)
+
+	switch {
+	case normalizedReason == CompleteReason:
+		reason = &schemas.ReasonComplete
+	case normalizedReason == MaxTokensReason:
+		reason = &schemas.ReasonMaxTokens
+	case slices.Contains(BlockedReasons, normalizedReason):
+		reason = &schemas.ReasonContentFiltered
+	default:
+		m.tel.Logger.Warn(
+			"Unknown finish reason, other is going to used",
+			zap.String("unknown_reason", *finishReason),
+		)
+
+		reason = &schemas.ReasonOther
+	}
+
+	return reason
+}
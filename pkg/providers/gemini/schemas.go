@@ -0,0 +1,83 @@
+package gemini
+
+// Gemini Chat Request & Response schemas
+// Ref: https://ai.google.dev/api/generate-content
+
+type Content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []Part `json:"parts"`
+}
+
+// Part is a piece of the content. It holds either text, media, a function call requested by the model or its result
+type Part struct {
+	Text             string            `json:"text,omitempty"`
+	InlineData       *Blob             `json:"inlineData,omitempty"`
+	FileData         *FileData         `json:"fileData,omitempty"`
+	FunctionCall     *FunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *FunctionResponse `json:"functionResponse,omitempty"`
+}
+
+// Blob is media embedded into the request as base64-encoded data
+type Blob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+// FileData references media by URI
+type FileData struct {
+	MimeType string `json:"mimeType"`
+	FileURI  string `json:"fileUri"`
+}
+
+type FunctionCall struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+type FunctionResponse struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type GenerationConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"topP,omitempty"`
+	TopK            int      `json:"topK,omitempty"`
+	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
+	StopSequences   []string `json:"stopSequences,omitempty"`
+	// ResponseMimeType set to application/json enables the JSON mode
+	ResponseMimeType string `json:"responseMimeType,omitempty"`
+}
+
+// ChatCompletion is a Gemini chat response. Chat stream chunks come in the same schema
+type ChatCompletion struct {
+	Candidates     []Candidate     `json:"candidates"`
+	PromptFeedback *PromptFeedback `json:"promptFeedback,omitempty"`
+	UsageMetadata  *UsageMetadata  `json:"usageMetadata,omitempty"`
+	ModelVersion   string          `json:"modelVersion"`
+}
+
+type Candidate struct {
+	Index         int            `json:"index"`
+	Content       Content        `json:"content"`
+	FinishReason  *string        `json:"finishReason"`
+	SafetyRatings []SafetyRating `json:"safetyRatings"`
+}
+
+type SafetyRating struct {
+	Category    string `json:"category"`
+	Probability string `json:"probability"`
+	Blocked     bool   `json:"blocked,omitempty"`
+}
+
+// PromptFeedback is reported when the prompt itself was blocked, so no candidates are generated
+type PromptFeedback struct {
+	BlockReason   *string        `json:"blockReason"`
+	SafetyRatings []SafetyRating `json:"safetyRatings"`
+}
+
+type UsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
+}
@@ -0,0 +1,146 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/google/uuid"
+)
+
+// Gemini function calling modes
+// Ref: https://ai.google.dev/gemini-api/docs/function-calling
+var (
+	FunctionCallingAuto = "AUTO"
+	FunctionCallingAny  = "ANY"
+	FunctionCallingNone = "NONE"
+)
+
+// Tool groups function declarations the model may call
+type Tool struct {
+	FunctionDeclarations []FunctionDeclaration `json:"functionDeclarations"`
+}
+
+type FunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type ToolConfig struct {
+	FunctionCallingConfig FunctionCallingConfig `json:"functionCallingConfig"`
+}
+
+type FunctionCallingConfig struct {
+	Mode                 string   `json:"mode"`
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+// NewTools maps Glide's tool definitions to Gemini function declarations
+func NewTools(tools []schemas.Tool) []Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	declarations := make([]FunctionDeclaration, 0, len(tools))
+
+	for _, tool := range tools {
+		declarations = append(declarations, FunctionDeclaration{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+		})
+	}
+
+	return []Tool{{FunctionDeclarations: declarations}}
+}
+
+// NewToolConfig maps Glide's tool choice to the Gemini function calling config
+func NewToolConfig(choice *schemas.ToolChoice) *ToolConfig {
+	if choice == nil {
+		return nil
+	}
+
+	config := FunctionCallingConfig{Mode: FunctionCallingAuto}
+
+	switch choice.Mode {
+	case schemas.ToolChoiceNone:
+		config.Mode = FunctionCallingNone
+	case schemas.ToolChoiceRequired:
+		config.Mode = FunctionCallingAny
+	case schemas.ToolChoiceFunction:
+		config.Mode = FunctionCallingAny
+		config.AllowedFunctionNames = []string{choice.FunctionName}
+	}
+
+	return &ToolConfig{FunctionCallingConfig: config}
+}
+
+// NewFunctionCallParts maps tool calls of the assistant message to Gemini parts
+func NewFunctionCallParts(toolCalls []schemas.ToolCall) []Part {
+	parts := make([]Part, 0, len(toolCalls))
+
+	for _, toolCall := range toolCalls {
+		args, err := toolCall.Function.ArgumentsMap()
+		if err != nil {
+			args = nil
+		}
+
+		parts = append(parts, Part{FunctionCall: &FunctionCall{Name: toolCall.Function.Name, Args: args}})
+	}
+
+	return parts
+}
+
+// NewFunctionResponsePart maps a tool message to the Gemini function response.
+//
+//	Gemini references calls by function names, so they are looked up by the tool call ID.
+//	The response must be an object, so other content is wrapped into the result field
+func NewFunctionResponsePart(message *schemas.ChatMessage, functionNames map[string]string) Part {
+	response := make(map[string]any)
+
+	if err := json.Unmarshal([]byte(message.Content), &response); err != nil {
+		response = map[string]any{"result": message.Content}
+	}
+
+	return Part{FunctionResponse: &FunctionResponse{
+		Name:     functionNames[message.ToolCallID],
+		Response: response,
+	}}
+}
+
+// CollectFunctionNames indexes names of functions called by the model by tool call IDs
+func CollectFunctionNames(messages []schemas.ChatMessage) map[string]string {
+	functionNames := make(map[string]string)
+
+	for _, message := range messages {
+		for _, toolCall := range message.ToolCalls {
+			functionNames[toolCall.ID] = toolCall.Function.Name
+		}
+	}
+
+	return functionNames
+}
+
+// NewToolCall maps the function call requested by the model.
+// Gemini doesn't identify function calls, so an ID is generated for each
+func NewToolCall(call *FunctionCall) (schemas.ToolCall, error) {
+	args := call.Args
+	if args == nil {
+		args = make(map[string]any)
+	}
+
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return schemas.ToolCall{}, fmt.Errorf("failed to encode arguments of the %q function call: %w", call.Name, err)
+	}
+
+	return schemas.ToolCall{
+		ID:   "call_" + uuid.NewString(),
+		Type: schemas.ToolTypeFunction,
+		Function: schemas.ToolCallFunction{
+			Name:      call.Name,
+			Arguments: string(arguments),
+		},
+	}, nil
+}
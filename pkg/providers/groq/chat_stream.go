@@ -0,0 +1,86 @@
+package groq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/providers/openai"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"go.uber.org/zap"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+func (c *Client) SupportChatStream() bool {
+	return true
+}
+
+// ChatStream reuses the OpenAI chat stream as Groq streams chunks in the same way, but reports token usage in the x_groq field of the last chunk
+func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	// Create a new chat request
+	httpRequest, err := c.makeStreamReq(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return openai.NewChatStream(
+		providerName,
+		c.httpClient,
+		httpRequest,
+		c.finishReasonMapper,
+		c.errMapper,
+		c.telemetry.L().With(zap.String("provider", providerName)),
+	).WithUsageReader(readUsage), nil
+}
+
+func (c *Client) makeStreamReq(ctx context.Context, params *schemas.ChatParams) (*http.Request, error) {
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate // hoping to get a copy of the template
+	chatReq.ApplyParams(params)
+
+	chatReq.Stream = true
+
+	rawPayload, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal groq chat stream request payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.chatURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create groq stream chat request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	request.Header.Set("Cache-Control", "no-cache")
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("Connection", "keep-alive")
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.telemetry.L().Debug(
+		"Stream chat request",
+		zap.String("chatURL", c.chatURL),
+		zap.Any("payload", chatReq),
+	)
+
+	return request, nil
+}
+
+// readUsage reads token usage from the x_groq field of chunks
+func readUsage(rawChunk []byte) (*openai.Usage, error) {
+	var completionChunk ChatCompletionChunk
+
+	if err := json.Unmarshal(rawChunk, &completionChunk); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal groq chat stream chunk: %w", err)
+	}
+
+	if completionChunk.XGroq == nil {
+		return nil, nil
+	}
+
+	return completionChunk.XGroq.Usage, nil
+}
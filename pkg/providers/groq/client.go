@@ -0,0 +1,82 @@
+package groq
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/openai"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+const (
+	providerName = "groq"
+)
+
+// Client is a client for accessing Groq API
+type Client struct {
+	baseURL             string
+	chatURL             string
+	chatRequestTemplate *ChatRequest
+	finishReasonMapper  *openai.FinishReasonMapper
+	errMapper           *ErrorMapper
+	config              *Config
+	httpClient          *http.Client
+	telemetry           *telemetry.Telemetry
+}
+
+// NewClient creates a new Groq client for the Groq API.
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	chatURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ChatEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:             providerConfig.BaseURL,
+		chatURL:             chatURL,
+		config:              providerConfig,
+		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		finishReasonMapper:  openai.NewFinishReasonMapper(tel),
+		errMapper:           NewErrorMapper(tel),
+		httpClient: &http.Client{
+			Timeout: time.Duration(*clientConfig.Timeout),
+			Transport: &http.Transport{
+				MaxIdleConns:        *clientConfig.MaxIdleConns,
+				MaxIdleConnsPerHost: *clientConfig.MaxIdleConnsPerHost,
+			},
+		},
+		telemetry: tel,
+	}
+
+	return c, nil
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+func (c *Client) ModelName() string {
+	return c.config.ModelName
+}
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens, schemas.ParamStop,
+		schemas.ParamSeed, schemas.ParamFrequencyPenalty, schemas.ParamPresencePenalty:
+		return true
+	default:
+		return false
+	}
+}
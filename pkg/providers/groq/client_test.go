@@ -0,0 +1,129 @@
+package groq
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroqClient_ChatRequest(t *testing.T) {
+	// Groq Chat API: https://console.groq.com/docs/api-reference#chat-create
+	groqMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, "llama-3.1-8b-instant", data["model"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading groq chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	groqServer := httptest.NewServer(groqMock)
+	defer groqServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = groqServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the biggest animal?",
+	}}}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	require.Equal(t, providerCfg.ModelName, response.ModelName)
+	require.Equal(t, "chatcmpl-f51b2cd2-bef7-417e-964e-a08f0b513c22", response.ID)
+	require.Equal(t, &schemas.ReasonComplete, response.FinishReason)
+	require.Equal(t, 29, response.ModelResponse.TokenUsage.TotalTokens)
+}
+
+// Groq reports the cooldown delay in its own headers
+func TestGroqClient_Chat_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		untilReset time.Duration
+	}{
+		"retry after":        {map[string]string{"Retry-After": "20"}, 20 * time.Second},
+		"tokens limit reset": {map[string]string{"X-Ratelimit-Reset-Tokens": "7.66s"}, 7660 * time.Millisecond},
+		"requests limit reset": {
+			map[string]string{"X-Ratelimit-Reset-Requests": "2m59.56s"},
+			2*time.Minute + 59560*time.Millisecond,
+		},
+		"no cooldown header": {map[string]string{}, time.Minute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			groqMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for header, value := range tt.headers {
+					w.Header().Set(header, value)
+				}
+
+				http.Error(w, `{"error": {"message": "Rate limit reached", "type": "tokens", "code": "rate_limit_exceeded"}}`, http.StatusTooManyRequests)
+			})
+
+			groqServer := httptest.NewServer(groqMock)
+			defer groqServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = groqServer.URL
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			_, err = client.Chat(ctx, &chatParams)
+
+			var rateLimitErr *clients.RateLimitError
+
+			require.ErrorAs(t, err, &rateLimitErr)
+			require.Equal(t, tt.untilReset, rateLimitErr.UntilReset())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package groq
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"go.uber.org/zap"
+)
+
+// Groq reports the cooldown delay in Retry-After (in seconds) and in the reset headers of
+// the requests and tokens limits (e.g. "2m59.56s" or "7.66s")
+// Ref: https://console.groq.com/docs/rate-limits#handling-rate-limits
+var rateLimitResetHeaders = []string{"Retry-After", "X-Ratelimit-Reset-Tokens", "X-Ratelimit-Reset-Requests"}
+
+type ErrorMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func NewErrorMapper(tel *telemetry.Telemetry) *ErrorMapper {
+	return &ErrorMapper{
+		tel: tel,
+	}
+}
+
+func (m *ErrorMapper) Map(resp *http.Response) error {
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		m.tel.Logger.Error(
+			"Failed to unmarshal chat response error",
+			zap.String("provider", providerName),
+			zap.Error(err),
+			zap.ByteString("rawResponse", bodyBytes),
+		)
+
+		return clients.ErrProviderUnavailable
+	}
+
+	m.tel.Logger.Error(
+		"Chat request failed",
+		zap.String("provider", providerName),
+		zap.Int("statusCode", resp.StatusCode),
+		zap.String("response", string(bodyBytes)),
+		zap.Any("headers", resp.Header),
+	)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		// the default cooldown delay is used when it's not reported
+		return clients.NewRateLimitError(clients.ResetDelayFromHeaders(resp.Header, rateLimitResetHeaders...))
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return clients.ErrUnauthorized
+	}
+
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	return clients.NewResponseError(resp.StatusCode, bodyBytes)
+}
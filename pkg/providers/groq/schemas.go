@@ -0,0 +1,57 @@
+package groq
+
+import (
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers/openai"
+)
+
+// ChatRequest is a Groq-specific request schema
+type ChatRequest struct {
+	Model            string                 `json:"model"`
+	Messages         []schemas.ChatMessage  `json:"messages"`
+	Temperature      *float64               `json:"temperature,omitempty"`
+	TopP             *float64               `json:"top_p,omitempty"`
+	MaxTokens        int                    `json:"max_tokens,omitempty"`
+	StopWords        []string               `json:"stop,omitempty"`
+	Stream           bool                   `json:"stream,omitempty"`
+	FrequencyPenalty *float64               `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64               `json:"presence_penalty,omitempty"`
+	Seed             *int                   `json:"seed,omitempty"`
+	User             *string                `json:"user,omitempty"`
+	ResponseFormat   *openai.ResponseFormat `json:"response_format,omitempty"`
+}
+
+func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
+	r.Messages = params.Messages
+	r.ResponseFormat = nil
+
+	// Groq supports the JSON object mode only, so JSON schemas are requested via the format instruction
+	if params.ResponseFormat != nil {
+		r.Messages = schemas.WithFormatInstruction(params.Messages, params.ResponseFormat)
+		r.ResponseFormat = openai.NewJSONObjectFormat(params.ResponseFormat)
+	}
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature:      &r.Temperature,
+		TopP:             &r.TopP,
+		MaxTokens:        &r.MaxTokens,
+		Stop:             &r.StopWords,
+		Seed:             &r.Seed,
+		FrequencyPenalty: &r.FrequencyPenalty,
+		PresencePenalty:  &r.PresencePenalty,
+	})
+}
+
+// ChatCompletionChunk represents SSEvent a chat response is broken down on chat streaming.
+//
+//	Groq uses the OpenAI chunk schema, but reports token usage in the x_groq field of the last chunk
+//	Ref: https://console.groq.com/docs/text-chat#streaming-a-chat-completion
+type ChatCompletionChunk struct {
+	openai.ChatCompletionChunk
+	XGroq *XGroq `json:"x_groq,omitempty"`
+}
+
+type XGroq struct {
+	ID    string        `json:"id"`
+	Usage *openai.Usage `json:"usage,omitempty"`
+}
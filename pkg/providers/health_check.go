@@ -0,0 +1,203 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"go.uber.org/zap"
+)
+
+var ErrModelNotListed = errors.New("model is not in the list of models served by the provider")
+
+// HealthCheckMethod defines how the model is probed
+type HealthCheckMethod = string
+
+const (
+	ChatCheck       HealthCheckMethod = "chat"        // send a short chat request
+	ListModelsCheck HealthCheckMethod = "list_models" // check the model is listed by the provider (no tokens are spent)
+)
+
+// ModelLister is a provider that can list models it serves
+type ModelLister interface {
+	ListModels(ctx context.Context) ([]string, error)
+}
+
+// HealthCheckConfig defines active health checks of the model. The model is probed in background,
+// so outages are noticed even if the model serves no traffic
+type HealthCheckConfig struct {
+	Method    HealthCheckMethod `yaml:"method" json:"method" validate:"oneof=chat list_models"`
+	Prompt    string            `yaml:"prompt" json:"prompt"`         // the chat check only
+	MaxTokens int               `yaml:"max_tokens" json:"max_tokens"` // the chat check only
+	Interval  fields.Duration   `yaml:"interval" json:"interval" swaggertype:"primitive,string" validate:"required"`
+	Timeout   fields.Duration   `yaml:"timeout" json:"timeout" swaggertype:"primitive,string" validate:"required"`
+}
+
+func DefaultHealthCheckConfig() *HealthCheckConfig {
+	return &HealthCheckConfig{
+		Method:    ChatCheck,
+		Prompt:    "ping",
+		MaxTokens: 1,
+		Interval:  fields.Duration(30 * time.Second),
+		Timeout:   fields.Duration(10 * time.Second),
+	}
+}
+
+func (c *HealthCheckConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultHealthCheckConfig()
+
+	type plain HealthCheckConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// HealthChecker probes the model periodically. Results are tracked as any other model request,
+// but one failed check is enough to stop sending requests to the model until it recovers
+type HealthChecker struct {
+	model  *LanguageModel
+	config *HealthCheckConfig
+	logger *zap.Logger
+	cancel context.CancelFunc
+	doneWG sync.WaitGroup
+}
+
+func NewHealthChecker(model *LanguageModel, config *HealthCheckConfig, logger *zap.Logger) (*HealthChecker, error) {
+	if config.Method == ListModelsCheck {
+		if _, ok := model.client.(ModelLister); !ok {
+			return nil, fmt.Errorf("provider \"%v\" can't list models, please use the chat health check", model.Provider())
+		}
+	}
+
+	return &HealthChecker{
+		model:  model,
+		config: config,
+		logger: logger.With(zap.String("modelID", model.ID())),
+	}, nil
+}
+
+// Check probes the model once
+func (c *HealthChecker) Check(ctx context.Context) error {
+	checkCtx, cancel := context.WithTimeout(ctx, time.Duration(c.config.Timeout))
+	defer cancel()
+
+	var err error
+
+	switch c.config.Method {
+	case ListModelsCheck:
+		err = c.model.checkModelListed(checkCtx)
+	default:
+		err = c.model.checkChat(checkCtx, c.chatParams())
+	}
+
+	var rateLimitErr *clients.RateLimitError
+
+	switch {
+	case err == nil:
+		return nil
+	case ctx.Err() != nil, errors.Is(err, health.ErrCircuitOpen), errors.As(err, &rateLimitErr):
+		// the check was stopped or the model is known to be unavailable already
+		return err
+	}
+
+	c.logger.Warn("Model health check failed", zap.Error(err))
+
+	c.model.healthTracker.Circuit().Trip()
+
+	return err
+}
+
+// Start probes the model in background until the checker is stopped
+func (c *HealthChecker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+
+	c.doneWG.Add(1)
+
+	go func() {
+		defer c.doneWG.Done()
+
+		ticker := time.NewTicker(time.Duration(c.config.Interval))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				_ = c.Check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop cancels the running check and stops background checks
+func (c *HealthChecker) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+
+	c.doneWG.Wait()
+}
+
+func (c *HealthChecker) chatParams() *schemas.ChatParams {
+	params := &schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{Role: "user", Content: c.config.Prompt}},
+	}
+
+	if c.config.MaxTokens > 0 && c.model.SupportParam(schemas.ParamMaxTokens) {
+		maxTokens := c.config.MaxTokens
+		params.GenerationParams.MaxTokens = &maxTokens
+	}
+
+	return params
+}
+
+// checkChat sends the probe chat request to the model. Only the model health is tracked,
+// so short probe prompts don't skew latency stats used to route and hedge requests
+func (m *LanguageModel) checkChat(ctx context.Context, params *schemas.ChatParams) error {
+	if !m.healthTracker.Allow() {
+		return health.ErrCircuitOpen
+	}
+
+	if _, err := m.client.Chat(ctx, params); err != nil {
+		m.trackErr(ctx, err)
+
+		return err
+	}
+
+	m.healthTracker.TrackSuccess()
+
+	return nil
+}
+
+// checkModelListed checks if the provider lists the model, so it's reachable & the API key is valid
+func (m *LanguageModel) checkModelListed(ctx context.Context) error {
+	if !m.healthTracker.Allow() {
+		return health.ErrCircuitOpen
+	}
+
+	modelNames, err := m.client.(ModelLister).ListModels(ctx)
+	if err != nil {
+		m.trackErr(ctx, err)
+
+		return err
+	}
+
+	if !slices.Contains(modelNames, m.ModelName()) {
+		err = fmt.Errorf("%w: %v", ErrModelNotListed, m.ModelName())
+		m.healthTracker.TrackErr(err)
+
+		return err
+	}
+
+	m.healthTracker.TrackSuccess()
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newCheckedModel(responses []ptesting.RespMock) *providers.LanguageModel {
+	return providers.NewLangModel(
+		"first",
+		ptesting.NewProviderMock(nil, responses),
+		health.NewErrorBudget(10, health.SEC),
+		nil,
+		*latency.DefaultConfig(),
+		1,
+	)
+}
+
+func TestHealthChecker_CheckSucceeded(t *testing.T) {
+	model := newCheckedModel([]ptesting.RespMock{{Msg: "pong"}})
+
+	checker, err := providers.NewHealthChecker(model, providers.DefaultHealthCheckConfig(), telemetry.NewLoggerMock())
+	require.NoError(t, err)
+
+	require.NoError(t, checker.Check(context.Background()))
+	require.True(t, model.Healthy())
+}
+
+func TestHealthChecker_CheckDoesNotTrackLatency(t *testing.T) {
+	probes := 5
+	responses := make([]ptesting.RespMock, 0, probes)
+
+	for range probes {
+		responses = append(responses, ptesting.RespMock{Msg: "pong"})
+	}
+
+	model := newCheckedModel(responses)
+
+	checker, err := providers.NewHealthChecker(model, providers.DefaultHealthCheckConfig(), telemetry.NewLoggerMock())
+	require.NoError(t, err)
+
+	for range probes {
+		require.NoError(t, checker.Check(context.Background()))
+	}
+
+	// probes must not skew latency stats of real traffic
+	require.False(t, model.ChatLatency().WarmedUp())
+
+	_, found := model.ChatLatencyPercentile(0.95)
+	require.False(t, found)
+}
+
+func TestHealthChecker_CheckFailed(t *testing.T) {
+	// one error doesn't drain the error budget, but it's enough to stop sending traffic to the model
+	model := newCheckedModel([]ptesting.RespMock{{Err: clients.ErrProviderUnavailable}})
+
+	checker, err := providers.NewHealthChecker(model, providers.DefaultHealthCheckConfig(), telemetry.NewLoggerMock())
+	require.NoError(t, err)
+
+	require.Error(t, checker.Check(context.Background()))
+	require.False(t, model.Healthy())
+	require.Equal(t, health.CircuitOpen, model.HealthTracker().Circuit().State())
+}
+
+func TestHealthChecker_CheckTimedOut(t *testing.T) {
+	model := newCheckedModel([]ptesting.RespMock{{Msg: "pong", Delay: 100 * time.Millisecond}})
+
+	config := providers.DefaultHealthCheckConfig()
+	config.Timeout = fields.Duration(5 * time.Millisecond)
+
+	checker, err := providers.NewHealthChecker(model, config, telemetry.NewLoggerMock())
+	require.NoError(t, err)
+
+	require.Error(t, checker.Check(context.Background()))
+	require.False(t, model.Healthy())
+	require.Equal(t, uint64(1), model.HealthTracker().Timeouts())
+}
+
+func TestHealthChecker_ListModelsNotSupported(t *testing.T) {
+	model := newCheckedModel(nil)
+
+	config := providers.DefaultHealthCheckConfig()
+	config.Method = providers.ListModelsCheck
+
+	_, err := providers.NewHealthChecker(model, config, telemetry.NewLoggerMock())
+	require.Error(t, err)
+}
+
+func TestHealthChecker_StartStop(t *testing.T) {
+	model := newCheckedModel(nil)
+
+	checker, err := providers.NewHealthChecker(model, providers.DefaultHealthCheckConfig(), telemetry.NewLoggerMock())
+	require.NoError(t, err)
+
+	checker.Start()
+	checker.Stop()
+}
@@ -2,7 +2,9 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/EinStack/glide/pkg/config/fields"
@@ -21,15 +23,23 @@ type LangProvider interface {
 	ModelProvider
 
 	SupportChatStream() bool
+	SupportModality(modality schemas.Modality) bool
+	SupportParam(param schemas.GenerationParam) bool
 
 	Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error)
 	ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error)
 }
 
+// chatDurationWindowSize is the number of the most recent chat requests used to estimate latency percentiles
+const chatDurationWindowSize = 100
+
 type LangModel interface {
 	Model
 	Provider() string
 	ModelName() string
+	SupportModalities(modalities []schemas.Modality) bool
+	SupportParam(param schemas.GenerationParam) bool
+	ChatLatencyPercentile(percentile float64) (time.Duration, bool)
 	Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error)
 	ChatStream(ctx context.Context, params *schemas.ChatParams) (<-chan *clients.ChatStreamResult, error)
 }
@@ -45,16 +55,27 @@ type LanguageModel struct {
 	healthTracker         *health.Tracker
 	chatLatency           *latency.MovingAverage
 	chatStreamLatency     *latency.MovingAverage
+	chatDurations         *latency.Window // durations of whole chat requests to estimate the tail latency
 	latencyUpdateInterval *fields.Duration
+	modalities            []schemas.Modality
+	healthCheck           *HealthCheckConfig
 }
 
-func NewLangModel(modelID string, client LangProvider, budget *health.ErrorBudget, latencyConfig latency.Config, weight int) *LanguageModel {
+func NewLangModel(
+	modelID string,
+	client LangProvider,
+	budget *health.ErrorBudget,
+	circuitConfig *health.CircuitBreakerConfig,
+	latencyConfig latency.Config,
+	weight int,
+) *LanguageModel {
 	return &LanguageModel{
 		modelID:               modelID,
 		client:                client,
-		healthTracker:         health.NewTracker(budget),
+		healthTracker:         health.NewTracker(budget, circuitConfig),
 		chatLatency:           latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
 		chatStreamLatency:     latency.NewMovingAverage(latencyConfig.Decay, latencyConfig.WarmupSamples),
+		chatDurations:         latency.NewWindow(chatDurationWindowSize, latencyConfig.WarmupSamples),
 		latencyUpdateInterval: latencyConfig.UpdateInterval,
 		weight:                weight,
 	}
@@ -68,6 +89,16 @@ func (m LanguageModel) Healthy() bool {
 	return m.healthTracker.Healthy()
 }
 
+// HealthTracker returns the tracker of the model health (e.g. to manage the model circuit)
+func (m LanguageModel) HealthTracker() *health.Tracker {
+	return m.healthTracker
+}
+
+// HealthCheck returns the config of active health checks. It's nil if the model is not probed
+func (m LanguageModel) HealthCheck() *HealthCheckConfig {
+	return m.healthCheck
+}
+
 func (m LanguageModel) Weight() int {
 	return m.weight
 }
@@ -80,6 +111,28 @@ func (m *LanguageModel) SupportChatStream() bool {
 	return m.client.SupportChatStream()
 }
 
+// SupportModalities checks if the model can process content of all given modalities.
+//
+//	The provider must be able to translate the modality and the model must not be restricted from it by the config
+func (m *LanguageModel) SupportModalities(modalities []schemas.Modality) bool {
+	for _, modality := range modalities {
+		if !m.client.SupportModality(modality) {
+			return false
+		}
+
+		if len(m.modalities) > 0 && !slices.Contains(m.modalities, modality) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SupportParam checks if the model provider accepts the generation param. Unsupported params are not sent to the provider
+func (m *LanguageModel) SupportParam(param schemas.GenerationParam) bool {
+	return m.client.SupportParam(param)
+}
+
 func (m LanguageModel) ChatLatency() *latency.MovingAverage {
 	return m.chatLatency
 }
@@ -88,18 +141,40 @@ func (m LanguageModel) ChatStreamLatency() *latency.MovingAverage {
 	return m.chatStreamLatency
 }
 
+// ChatLatencyPercentile returns the percentile (e.g. 95) of recent chat request durations.
+// It's false if the model has not served enough requests yet
+func (m *LanguageModel) ChatLatencyPercentile(percentile float64) (time.Duration, bool) {
+	duration, ok := m.chatDurations.Percentile(percentile)
+
+	return time.Duration(duration), ok
+}
+
 func (m *LanguageModel) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error) {
+	if !m.healthTracker.Allow() {
+		return nil, health.ErrCircuitOpen
+	}
+
 	startedAt := time.Now()
 
 	resp, err := m.client.Chat(ctx, params)
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.trackErr(ctx, err)
 
 		return resp, err
 	}
 
+	m.healthTracker.TrackSuccess()
+
+	duration := time.Since(startedAt)
+
 	// record latency per token to normalize measurements
-	m.chatLatency.Add(float64(time.Since(startedAt)) / float64(resp.ModelResponse.TokenUsage.ResponseTokens))
+	m.chatLatency.Add(float64(duration) / float64(resp.ModelResponse.TokenUsage.ResponseTokens))
+	m.chatDurations.Add(float64(duration))
+
+	// the model is healthy, but its answer can't be used, so the router should try the next model
+	if err := validateResponseFormat(params.ResponseFormat, resp); err != nil {
+		return nil, err
+	}
 
 	// successful response
 	resp.ModelID = m.modelID
@@ -107,10 +182,24 @@ func (m *LanguageModel) Chat(ctx context.Context, params *schemas.ChatParams) (*
 	return resp, err
 }
 
+// validateResponseFormat checks the response against the requested format if the validation was requested.
+// Responses with tool calls are not validated as they don't carry the final answer
+func validateResponseFormat(format *schemas.ResponseFormat, resp *schemas.ChatResponse) error {
+	if format == nil || !format.Validate || resp.ModelResponse.Message.HasToolCalls() {
+		return nil
+	}
+
+	return format.ValidateContent(resp.ModelResponse.Message.Content)
+}
+
 func (m *LanguageModel) ChatStream(ctx context.Context, params *schemas.ChatParams) (<-chan *clients.ChatStreamResult, error) {
+	if !m.healthTracker.Allow() {
+		return nil, health.ErrCircuitOpen
+	}
+
 	stream, err := m.client.ChatStream(ctx, params)
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.trackErr(ctx, err)
 
 		return nil, err
 	}
@@ -123,7 +212,7 @@ func (m *LanguageModel) ChatStream(ctx context.Context, params *schemas.ChatPara
 	m.chatStreamLatency.Add(float64(chunkLatency))
 
 	if err != nil {
-		m.healthTracker.TrackErr(err)
+		m.trackErr(ctx, err)
 
 		// if connection was not even open, we should not send our clients any messages about this failure
 
@@ -144,12 +233,14 @@ func (m *LanguageModel) ChatStream(ctx context.Context, params *schemas.ChatPara
 			if err != nil {
 				if err == io.EOF {
 					// end of the stream
+					m.healthTracker.TrackSuccess()
+
 					return
 				}
 
 				streamResultC <- clients.NewChatStreamResult(nil, err)
 
-				m.healthTracker.TrackErr(err)
+				m.trackErr(ctx, err)
 
 				return
 			}
@@ -171,6 +262,25 @@ func (m *LanguageModel) ChatStream(ctx context.Context, params *schemas.ChatPara
 	return streamResultC, nil
 }
 
+// trackErr counts the error against the model health unless the caller has cancelled the request
+// (e.g. the client has gone or another model has answered the hedged request first)
+func (m *LanguageModel) trackErr(ctx context.Context, err error) {
+	trackErr(ctx, m.healthTracker, err)
+}
+
+// trackErr counts the error against the given model health tracker the way it's done for all models
+func trackErr(ctx context.Context, tracker *health.Tracker, err error) {
+	switch {
+	case errors.Is(ctx.Err(), context.Canceled):
+		tracker.Release()
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		// providers report deadlines in many ways, so timeouts are tracked uniformly
+		tracker.TrackErr(clients.ErrTimeout)
+	default:
+		tracker.TrackErr(err)
+	}
+}
+
 func (m *LanguageModel) Provider() string {
 	return m.client.Provider()
 }
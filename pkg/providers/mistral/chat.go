@@ -0,0 +1,130 @@
+package mistral
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"go.uber.org/zap"
+)
+
+// NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
+func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
+	return &ChatRequest{
+		Model:       cfg.ModelName,
+		Temperature: schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:        schemas.DefaultParam(cfg.DefaultParams.TopP),
+		MaxTokens:   cfg.DefaultParams.MaxTokens,
+		StopWords:   cfg.DefaultParams.StopWords,
+		RandomSeed:  cfg.DefaultParams.RandomSeed,
+		SafePrompt:  cfg.DefaultParams.SafePrompt,
+	}
+}
+
+// Chat sends a chat request to the specified Mistral model.
+func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error) {
+	// Create a new chat request
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate // hoping to get a copy of the template
+	chatReq.ApplyParams(params)
+
+	chatReq.Stream = false
+
+	chatResponse, err := c.doChatRequest(ctx, &chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return chatResponse, nil
+}
+
+func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*schemas.ChatResponse, error) {
+	// Build request payload
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal mistral chat request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.chatURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create mistral chat request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+string(c.config.APIKey))
+	req.Header.Set("Content-Type", "application/json")
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.telemetry.Logger.Debug(
+		"mistral chat request",
+		zap.String("chat_url", c.chatURL),
+		zap.Any("payload", payload),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send mistral chat request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	// Read the response body into a byte slice
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.telemetry.Logger.Error("failed to read mistral chat response", zap.Error(err))
+		return nil, err
+	}
+
+	// Parse the response JSON
+	var completion ChatCompletion
+
+	err = json.Unmarshal(bodyBytes, &completion)
+	if err != nil {
+		c.telemetry.Logger.Error("failed to parse mistral chat response", zap.Error(err))
+		return nil, err
+	}
+
+	if len(completion.Choices) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	modelChoice := completion.Choices[0]
+
+	if len(modelChoice.Message.Content) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	// Map response to ChatResponse schema
+	response := schemas.ChatResponse{
+		ID:        completion.ID,
+		Created:   completion.Created,
+		Provider:  providerName,
+		ModelName: completion.ModelName,
+		Cached:    false,
+		ModelResponse: schemas.ModelResponse{
+			Metadata: map[string]string{},
+			Message: schemas.ChatMessage{
+				Role:    modelChoice.Message.Role,
+				Content: modelChoice.Message.Content,
+			},
+			TokenUsage: schemas.TokenUsage{
+				PromptTokens:   completion.Usage.PromptTokens,
+				ResponseTokens: completion.Usage.CompletionTokens,
+				TotalTokens:    completion.Usage.TotalTokens,
+			},
+		},
+		FinishReason: c.finishReasonMapper.Map(modelChoice.FinishReason),
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,124 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Chunks are parsed by the OpenAI chat stream, so only what's specific to Mistral is tested here
+func TestMistralClient_ChatStream(t *testing.T) {
+	mistralMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/chat/completions", r.URL.Path)
+		require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, true, data["stream"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat_stream.success.txt"))
+		if err != nil {
+			t.Errorf("error reading mistral chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	mistralServer := httptest.NewServer(mistralMock)
+	defer mistralServer.Close()
+
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = mistralServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+	require.True(t, client.SupportChatStream())
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(context.Background(), &chatParams)
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Open())
+
+	defer stream.Close()
+
+	var lastChunk *schemas.ChatStreamChunk
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, providerName, chunk.Provider)
+
+		lastChunk = chunk
+	}
+
+	require.NotNil(t, lastChunk)
+	require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+
+	// Mistral reports token usage in the last chunk
+	require.Equal(t, 23, (*lastChunk.ModelResponse.Metadata)["total_tokens"])
+}
+
+func TestMistralClient_ChatStreamError(t *testing.T) {
+	mistralMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message": "Unauthorized"}`, http.StatusUnauthorized)
+	})
+
+	mistralServer := httptest.NewServer(mistralMock)
+	defer mistralServer.Close()
+
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = mistralServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(context.Background(), &chatParams)
+	require.NoError(t, err)
+
+	// failed responses are mapped by the provider error mapper
+	require.ErrorIs(t, stream.Open(), clients.ErrUnauthorized)
+}
@@ -0,0 +1,125 @@
+package mistral
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMistralClient_ChatRequest(t *testing.T) {
+	// Mistral Chat API: https://docs.mistral.ai/api/#tag/chat
+	mistralMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, "mistral-small-latest", data["model"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading mistral chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	mistralServer := httptest.NewServer(mistralMock)
+	defer mistralServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = mistralServer.URL
+	providerCfg.APIKey = "test-key"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the biggest animal?",
+	}}}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	require.Equal(t, providerCfg.ModelName, response.ModelName)
+	require.Equal(t, "cmpl-e5cc70bb28c444948073e77776eb30ef", response.ID)
+	require.Equal(t, &schemas.ReasonComplete, response.FinishReason)
+	require.Equal(t, 27, response.ModelResponse.TokenUsage.TotalTokens)
+}
+
+// Mistral reports the cooldown delay in its own headers
+func TestMistralClient_Chat_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		untilReset time.Duration
+	}{
+		"retry after":        {map[string]string{"Retry-After": "20"}, 20 * time.Second},
+		"rate limit reset":   {map[string]string{"X-Ratelimit-Reset": "5"}, 5 * time.Second},
+		"no cooldown header": {map[string]string{}, time.Minute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mistralMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for header, value := range tt.headers {
+					w.Header().Set(header, value)
+				}
+
+				http.Error(w, `{"message": "Requests rate limit exceeded"}`, http.StatusTooManyRequests)
+			})
+
+			mistralServer := httptest.NewServer(mistralMock)
+			defer mistralServer.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = mistralServer.URL
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			_, err = client.Chat(ctx, &chatParams)
+
+			var rateLimitErr *clients.RateLimitError
+
+			require.ErrorAs(t, err, &rateLimitErr)
+			require.Equal(t, tt.untilReset, rateLimitErr.UntilReset())
+		})
+	}
+}
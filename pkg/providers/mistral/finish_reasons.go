@@ -0,0 +1,52 @@
+package mistral
+
+import (
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+var (
+	// Reference: https://docs.mistral.ai/api/#tag/chat/operation/chat_completion_v1_chat_completions_post
+	CompleteReason       = "stop"
+	MaxTokensReason      = "length"
+	ModelMaxTokensReason = "model_length" // the context window of the model is exhausted
+	ErrorReason          = "error"
+)
+
+func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
+	return &FinishReasonMapper{
+		tel: tel,
+	}
+}
+
+type FinishReasonMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func (m *FinishReasonMapper) Map(finishReason string) *schemas.FinishReason {
+	if len(finishReason) == 0 {
+		return nil
+	}
+
+	var reason *schemas.FinishReason
+
+	switch finishReason {
+	case CompleteReason:
+		reason = &schemas.ReasonComplete
+	case MaxTokensReason, ModelMaxTokensReason:
+		reason = &schemas.ReasonMaxTokens
+	case ErrorReason:
+		reason = &schemas.ReasonError
+	default:
+		m.tel.Logger.Warn(
+			"Unknown finish reason, other is going to used",
+			zap.String("unknown_reason", finishReason),
+		)
+
+		reason = &schemas.ReasonOther
+	}
+
+	return reason
+}
@@ -0,0 +1,62 @@
+package mistral
+
+import (
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers/openai"
+)
+
+// ChatRequest is a Mistral-specific request schema
+type ChatRequest struct {
+	Model          string                 `json:"model"`
+	Messages       []schemas.ChatMessage  `json:"messages"`
+	Temperature    *float64               `json:"temperature,omitempty"`
+	TopP           *float64               `json:"top_p,omitempty"`
+	MaxTokens      int                    `json:"max_tokens,omitempty"`
+	StopWords      []string               `json:"stop,omitempty"`
+	RandomSeed     *int                   `json:"random_seed,omitempty"`
+	SafePrompt     bool                   `json:"safe_prompt,omitempty"`
+	Stream         bool                   `json:"stream,omitempty"`
+	ResponseFormat *openai.ResponseFormat `json:"response_format,omitempty"`
+}
+
+func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
+	r.Messages = params.Messages
+	r.ResponseFormat = nil
+
+	// Mistral supports the JSON object mode only, so JSON schemas are requested via the format instruction
+	if params.ResponseFormat != nil {
+		r.Messages = schemas.WithFormatInstruction(params.Messages, params.ResponseFormat)
+		r.ResponseFormat = openai.NewJSONObjectFormat(params.ResponseFormat)
+	}
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature: &r.Temperature,
+		TopP:        &r.TopP,
+		MaxTokens:   &r.MaxTokens,
+		Stop:        &r.StopWords,
+		Seed:        &r.RandomSeed,
+	})
+}
+
+// ChatCompletion
+// Ref: https://docs.mistral.ai/api/#tag/chat/operation/chat_completion_v1_chat_completions_post
+type ChatCompletion struct {
+	ID        string   `json:"id"`
+	Object    string   `json:"object"`
+	Created   int      `json:"created"`
+	ModelName string   `json:"model"`
+	Choices   []Choice `json:"choices"`
+	Usage     Usage    `json:"usage"`
+}
+
+type Choice struct {
+	Index        int                 `json:"index"`
+	Message      schemas.ChatMessage `json:"message"`
+	FinishReason string              `json:"finish_reason"`
+}
+
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
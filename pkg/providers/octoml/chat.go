@@ -19,30 +19,39 @@ import (
 type ChatRequest struct {
 	Model            string                `json:"model"`
 	Messages         []schemas.ChatMessage `json:"messages"`
-	Temperature      float64               `json:"temperature,omitempty"`
-	TopP             float64               `json:"top_p,omitempty"`
+	Temperature      *float64              `json:"temperature,omitempty"`
+	TopP             *float64              `json:"top_p,omitempty"`
 	MaxTokens        int                   `json:"max_tokens,omitempty"`
 	StopWords        []string              `json:"stop,omitempty"`
 	Stream           bool                  `json:"stream,omitempty"`
-	FrequencyPenalty int                   `json:"frequency_penalty,omitempty"`
-	PresencePenalty  int                   `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64              `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64              `json:"presence_penalty,omitempty"`
 }
 
 func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	// TODO(185): set other params
-	r.Messages = params.Messages
+	// OctoML has no JSON mode, so the response format is requested via the format instruction
+	r.Messages = schemas.TextMessages(schemas.WithFormatInstruction(params.Messages, params.ResponseFormat))
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature:      &r.Temperature,
+		TopP:             &r.TopP,
+		MaxTokens:        &r.MaxTokens,
+		Stop:             &r.StopWords,
+		FrequencyPenalty: &r.FrequencyPenalty,
+		PresencePenalty:  &r.PresencePenalty,
+	})
 }
 
 // NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
 func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
 		Model:            cfg.ModelName,
-		Temperature:      cfg.DefaultParams.Temperature,
-		TopP:             cfg.DefaultParams.TopP,
+		Temperature:      schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:             schemas.DefaultParam(cfg.DefaultParams.TopP),
 		MaxTokens:        cfg.DefaultParams.MaxTokens,
 		StopWords:        cfg.DefaultParams.StopWords,
-		FrequencyPenalty: cfg.DefaultParams.FrequencyPenalty,
-		PresencePenalty:  cfg.DefaultParams.PresencePenalty,
+		FrequencyPenalty: schemas.DefaultParam(float64(cfg.DefaultParams.FrequencyPenalty)),
+		PresencePenalty:  schemas.DefaultParam(float64(cfg.DefaultParams.PresencePenalty)),
 	}
 }
 
@@ -139,6 +148,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				TotalTokens:    completion.Usage.TotalTokens,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(modelChoice.FinishReason),
 	}
 
 	return &response, nil
@@ -6,9 +6,13 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/EinStack/glide/pkg/providers/openai"
+
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -25,6 +29,7 @@ type Client struct {
 	baseURL             string
 	chatURL             string
 	chatRequestTemplate *ChatRequest
+	finishReasonMapper  *openai.FinishReasonMapper
 	errMapper           *ErrorMapper
 	config              *Config
 	httpClient          *http.Client
@@ -43,6 +48,7 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		chatURL:             chatURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		finishReasonMapper:  openai.NewFinishReasonMapper(tel),
 		errMapper:           NewErrorMapper(tel),
 		httpClient: &http.Client{
 			Timeout: time.Duration(*clientConfig.Timeout),
@@ -64,3 +70,19 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens,
+		schemas.ParamStop, schemas.ParamFrequencyPenalty, schemas.ParamPresencePenalty:
+		return true
+	default:
+		return false
+	}
+}
@@ -18,6 +18,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// jsonFormat enables Ollama's JSON mode
+const jsonFormat = "json"
+
 // ChatRequest is an ollama-specific request schema
 type ChatRequest struct {
 	Model        string                `json:"model"`
@@ -30,26 +33,41 @@ type ChatRequest struct {
 	NumGpu       int                   `json:"num_gpu,omitempty"`
 	NumThread    int                   `json:"num_thread,omitempty"`
 	RepeatLastN  int                   `json:"repeat_last_n,omitempty"`
-	Temperature  float64               `json:"temperature,omitempty"`
-	Seed         int                   `json:"seed,omitempty"`
+	Temperature  *float64              `json:"temperature,omitempty"`
+	Seed         *int                  `json:"seed,omitempty"`
 	StopWords    []string              `json:"stop,omitempty"`
 	Tfsz         float64               `json:"tfs_z,omitempty"`
 	NumPredict   int                   `json:"num_predict,omitempty"`
 	TopK         int                   `json:"top_k,omitempty"`
-	TopP         float64               `json:"top_p,omitempty"`
+	TopP         *float64              `json:"top_p,omitempty"`
 	Stream       bool                  `json:"stream"`
+	Format       string                `json:"format,omitempty"`
 }
 
 func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	// TODO(185): set other params
-	r.Messages = params.Messages
+	r.Messages = schemas.TextMessages(schemas.WithFormatInstruction(params.Messages, params.ResponseFormat))
+	r.Format = ""
+
+	// Ollama's JSON mode doesn't take a schema, so the model is instructed to follow it in addition
+	if params.ResponseFormat.IsJSON() {
+		r.Format = jsonFormat
+	}
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature: &r.Temperature,
+		TopP:        &r.TopP,
+		TopK:        &r.TopK,
+		MaxTokens:   &r.NumPredict,
+		Stop:        &r.StopWords,
+		Seed:        &r.Seed,
+	})
 }
 
 // NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
 func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
 		Model:        cfg.ModelName,
-		Temperature:  cfg.DefaultParams.Temperature,
+		Temperature:  schemas.DefaultParam(cfg.DefaultParams.Temperature),
 		Microstat:    cfg.DefaultParams.Microstat,
 		MicrostatEta: cfg.DefaultParams.MicrostatEta,
 		MicrostatTau: cfg.DefaultParams.MicrostatTau,
@@ -58,11 +76,11 @@ func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 		NumGpu:       cfg.DefaultParams.NumGpu,
 		NumThread:    cfg.DefaultParams.NumThread,
 		RepeatLastN:  cfg.DefaultParams.RepeatLastN,
-		Seed:         cfg.DefaultParams.Seed,
+		Seed:         schemas.DefaultParam(cfg.DefaultParams.Seed),
 		StopWords:    cfg.DefaultParams.StopWords,
 		Tfsz:         cfg.DefaultParams.Tfsz,
 		NumPredict:   cfg.DefaultParams.NumPredict,
-		TopP:         cfg.DefaultParams.TopP,
+		TopP:         schemas.DefaultParam(cfg.DefaultParams.TopP),
 		TopK:         cfg.DefaultParams.TopK,
 	}
 }
@@ -84,7 +102,7 @@ func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas
 	return chatResponse, nil
 }
 
-func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*schemas.ChatResponse, error) { //nolint:cyclop
+func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*schemas.ChatResponse, error) {
 	// Build request payload
 	rawPayload, err := json.Marshal(payload)
 	if err != nil {
@@ -113,33 +131,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			c.telemetry.Logger.Error("failed to read ollama chat response", zap.Error(err))
-		}
-
-		c.telemetry.Logger.Error(
-			"ollama chat request failed",
-			zap.Int("status_code", resp.StatusCode),
-			zap.String("response", string(bodyBytes)),
-			zap.Any("headers", resp.Header),
-		)
-
-		if resp.StatusCode == http.StatusTooManyRequests {
-			// Read the value of the "Retry-After" header to get the cooldown delay
-			retryAfter := resp.Header.Get("Retry-After")
-
-			// Parse the value to get the duration
-			cooldownDelay, err := time.ParseDuration(retryAfter)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse cooldown delay from headers: %w", err)
-			}
-
-			return nil, clients.NewRateLimitError(&cooldownDelay)
-		}
-
-		// Server & client errors result in the same error to keep gateway resilient
-		return nil, clients.ErrProviderUnavailable
+		return nil, c.errMapper.Map(resp)
 	}
 
 	// Read the response body into a byte slice
@@ -176,11 +168,12 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				Content: ollamaCompletion.Message.Content,
 			},
 			TokenUsage: schemas.TokenUsage{
-				PromptTokens:   ollamaCompletion.EvalCount,
+				PromptTokens:   ollamaCompletion.PromptEvalCount,
 				ResponseTokens: ollamaCompletion.EvalCount,
-				TotalTokens:    ollamaCompletion.EvalCount,
+				TotalTokens:    ollamaCompletion.PromptEvalCount + ollamaCompletion.EvalCount,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(ollamaCompletion.DoneReason),
 	}
 
 	return &response, nil
@@ -1,17 +1,210 @@
 package ollama
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 
+	"go.uber.org/zap"
+
 	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
+// ChatStream represents ollama chat stream for a specific request.
+//
+//	Ollama streams chat chunks as newline-delimited JSON
+type ChatStream struct {
+	client             *http.Client
+	req                *http.Request
+	modelName          string
+	resp               *http.Response
+	streamFinished     bool
+	reader             *clients.StreamReader
+	errMapper          *ErrorMapper
+	finishReasonMapper *FinishReasonMapper
+	tel                *telemetry.Telemetry
+}
+
+func NewChatStream(
+	tel *telemetry.Telemetry,
+	client *http.Client,
+	req *http.Request,
+	modelName string,
+	errMapper *ErrorMapper,
+	finishReasonMapper *FinishReasonMapper,
+) *ChatStream {
+	return &ChatStream{
+		tel:                tel,
+		client:             client,
+		req:                req,
+		modelName:          modelName,
+		errMapper:          errMapper,
+		streamFinished:     false,
+		finishReasonMapper: finishReasonMapper,
+	}
+}
+
+func (s *ChatStream) Open() error {
+	resp, err := s.client.Do(s.req) //nolint:bodyclose
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s.errMapper.Map(resp)
+	}
+
+	s.resp = resp
+	s.reader = clients.NewStreamReader(resp.Body, 8192) // TODO: should we expose maxBufferSize?
+
+	return nil
+}
+
+func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
+	if s.streamFinished {
+		return nil, io.EOF
+	}
+
+	for {
+		rawChunk, err := s.reader.ReadEvent()
+		if err != nil {
+			s.tel.L().Warn(
+				"Chat stream is unexpectedly disconnected",
+				zap.String("provider", providerName),
+				zap.Error(err),
+			)
+
+			// if io.EOF occurred in the middle of the stream, then the stream was interrupted
+			return nil, clients.ErrProviderUnavailable
+		}
+
+		if len(bytes.TrimSpace(rawChunk)) == 0 {
+			continue
+		}
+
+		s.tel.L().Debug(
+			"Raw chat stream chunk",
+			zap.String("provider", providerName),
+			zap.ByteString("rawChunk", rawChunk),
+		)
+
+		var responseChunk ChatCompletionChunk
+
+		err = json.Unmarshal(rawChunk, &responseChunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chat stream chunk: %v", err)
+		}
+
+		if responseChunk.Error != nil {
+			s.tel.L().Error(
+				"Chat stream has failed on the provider side",
+				zap.String("provider", providerName),
+				zap.String("error", *responseChunk.Error),
+			)
+
+			return nil, clients.ErrProviderUnavailable
+		}
+
+		modelName := responseChunk.Model
+		if len(modelName) == 0 {
+			modelName = s.modelName
+		}
+
+		// TODO: use objectpool here
+		chunk := &schemas.ChatStreamChunk{
+			Cached:    false,
+			Provider:  providerName,
+			ModelName: modelName,
+			ModelResponse: schemas.ModelChunkResponse{
+				Message: schemas.ChatMessage{
+					Role:    responseChunk.Message.Role,
+					Content: responseChunk.Message.Content,
+				},
+			},
+		}
+
+		if responseChunk.Done {
+			s.streamFinished = true
+
+			chunk.ModelResponse.Metadata = &schemas.Metadata{
+				"prompt_tokens":   responseChunk.PromptEvalCount,
+				"response_tokens": responseChunk.EvalCount,
+				"total_tokens":    responseChunk.PromptEvalCount + responseChunk.EvalCount,
+			}
+
+			chunk.FinishReason = s.finishReasonMapper.Map(responseChunk.DoneReason)
+			if chunk.FinishReason == nil {
+				// older ollama versions don't report done reasons
+				chunk.FinishReason = &schemas.ReasonComplete
+			}
+		}
+
+		return chunk, nil
+	}
+}
+
+func (s *ChatStream) Close() error {
+	if s.resp != nil {
+		return s.resp.Body.Close()
+	}
+
+	return nil
+}
+
 func (c *Client) SupportChatStream() bool {
-	return false
+	return true
 }
 
-func (c *Client) ChatStream(_ context.Context, _ *schemas.ChatParams) (clients.ChatStream, error) {
-	return nil, clients.ErrChatStreamNotImplemented
+func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	// Create a new chat request
+	httpRequest, err := c.makeStreamReq(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChatStream(
+		c.telemetry,
+		c.httpClient,
+		httpRequest,
+		c.chatRequestTemplate.Model,
+		c.errMapper,
+		c.finishReasonMapper,
+	), nil
+}
+
+func (c *Client) makeStreamReq(ctx context.Context, params *schemas.ChatParams) (*http.Request, error) {
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate
+	chatReq.ApplyParams(params)
+
+	chatReq.Stream = true
+
+	rawPayload, err := json.Marshal(chatReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal ollama chat stream request payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.chatURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ollama stream chat request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/x-ndjson")
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.telemetry.L().Debug(
+		"Stream chat request",
+		zap.String("chatURL", c.chatURL),
+		zap.Any("payload", chatReq),
+	)
+
+	return request, nil
 }
@@ -0,0 +1,150 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newOllamaStreamServer(t *testing.T, streamFile string) *httptest.Server {
+	t.Helper()
+
+	ollamaMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+		// Parse the JSON body
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		if data["stream"] != true {
+			t.Errorf("stream flag is expected to be set in the payload: %q", string(rawPayload))
+		}
+
+		chatResponse, err := os.ReadFile(filepath.Clean(streamFile))
+		if err != nil {
+			t.Errorf("error reading ollama chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	return httptest.NewServer(ollamaMock)
+}
+
+func newOllamaStream(t *testing.T, baseURL string) clients.ChatStream {
+	t.Helper()
+
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = baseURL
+	providerCfg.ModelName = "llama2"
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(context.Background(), &chatParams)
+	require.NoError(t, err)
+
+	return stream
+}
+
+func TestOllama_ChatStreamSupported(t *testing.T) {
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportChatStream())
+}
+
+func TestOllama_ChatStreamRequest(t *testing.T) {
+	ollamaServer := newOllamaStreamServer(t, "./testdata/chat_stream.success.txt")
+	defer ollamaServer.Close()
+
+	stream := newOllamaStream(t, ollamaServer.URL)
+
+	err := stream.Open()
+	require.NoError(t, err)
+
+	defer stream.Close()
+
+	var lastChunk *schemas.ChatStreamChunk
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.NotNil(t, chunk)
+
+		lastChunk = chunk
+	}
+
+	require.NotNil(t, lastChunk)
+	require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+	require.Equal(t, 39, (*lastChunk.ModelResponse.Metadata)["total_tokens"])
+}
+
+func TestOllama_ChatStreamRequestInterrupted(t *testing.T) {
+	tests := map[string]string{
+		"interrupted stream": "./testdata/chat_stream.nodone.txt",
+		"error in stream":    "./testdata/chat_stream.error.txt",
+	}
+
+	for name, streamFile := range tests {
+		t.Run(name, func(t *testing.T) {
+			ollamaServer := newOllamaStreamServer(t, streamFile)
+			defer ollamaServer.Close()
+
+			stream := newOllamaStream(t, ollamaServer.URL)
+
+			err := stream.Open()
+			require.NoError(t, err)
+
+			defer stream.Close()
+
+			for {
+				chunk, err := stream.Recv()
+				if err != nil {
+					require.ErrorIs(t, err, clients.ErrProviderUnavailable)
+					require.Nil(t, chunk)
+
+					return
+				}
+
+				require.NotNil(t, chunk)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -18,7 +20,10 @@ const (
 type Client struct {
 	baseURL             string
 	chatURL             string
+	embedURL            string
 	chatRequestTemplate *ChatRequest
+	errMapper           *ErrorMapper
+	finishReasonMapper  *FinishReasonMapper
 	config              *Config
 	httpClient          *http.Client
 	telemetry           *telemetry.Telemetry
@@ -31,11 +36,19 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	embedURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.EmbedEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		embedURL:            embedURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		errMapper:           NewErrorMapper(tel),
+		finishReasonMapper:  NewFinishReasonMapper(tel),
 		httpClient: &http.Client{
 			Timeout: time.Duration(*clientConfig.Timeout),
 			Transport: &http.Transport{
@@ -56,3 +69,19 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamTopK,
+		schemas.ParamMaxTokens, schemas.ParamStop, schemas.ParamSeed:
+		return true
+	default:
+		return false
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 
@@ -95,6 +96,49 @@ func TestOllamaClient_ChatRequest_Non200Response(t *testing.T) {
 	require.Contains(t, err.Error(), "provider is not available")
 }
 
+func TestOllamaClient_ChatRequest_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		untilReset time.Duration
+	}{
+		"retry after in seconds": {map[string]string{"Retry-After": "15"}, 15 * time.Second},
+		"no cooldown header":     {map[string]string{}, time.Minute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for header, value := range tt.headers {
+					w.Header().Set(header, value)
+				}
+
+				w.WriteHeader(http.StatusTooManyRequests)
+			}))
+
+			defer mockServer.Close()
+
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+			providerCfg.BaseURL = mockServer.URL
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the capital of the United Kingdom?",
+			}}}
+
+			_, err = client.Chat(context.Background(), &chatParams)
+
+			var rateLimitErr *clients.RateLimitError
+
+			require.ErrorAs(t, err, &rateLimitErr)
+			require.Equal(t, tt.untilReset, rateLimitErr.UntilReset())
+		})
+	}
+}
+
 func TestOllamaClient_ChatRequest_SuccessfulResponse(t *testing.T) {
 	// Create a mock HTTP server that returns an OK status code and a sample response
 	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
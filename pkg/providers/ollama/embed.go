@@ -0,0 +1,110 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// maxEmbedBatchSize limits the number of texts sent to a local Ollama server in one request,
+// so large inputs don't occupy the server for too long
+const maxEmbedBatchSize = 512
+
+// EmbedRequest is an Ollama-specific embedding request schema
+// Ref: https://github.com/ollama/ollama/blob/main/docs/api.md#generate-embeddings
+type EmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// EmbedResponse is an Ollama-specific embedding response schema
+type EmbedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float64 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
+
+// MaxEmbedBatchSize returns the max number of texts that can be embedded in one request
+func (c *Client) MaxEmbedBatchSize() int {
+	return maxEmbedBatchSize
+}
+
+// Embed sends an embedding request to the specified Ollama model
+func (c *Client) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	rawPayload, err := json.Marshal(EmbedRequest{Model: c.config.ModelName, Input: params.Input})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal ollama embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create ollama embed request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	c.telemetry.Logger.Debug(
+		"ollama embed request",
+		zap.String("embed_url", c.embedURL),
+		zap.Int("inputs", len(params.Input)),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send ollama embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.telemetry.Logger.Error("failed to read ollama embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var embedResponse EmbedResponse
+
+	err = json.Unmarshal(bodyBytes, &embedResponse)
+	if err != nil {
+		c.telemetry.Logger.Error("failed to parse ollama embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	if len(embedResponse.Embeddings) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	embeddings := make([]schemas.Embedding, 0, len(embedResponse.Embeddings))
+
+	for idx, embedding := range embedResponse.Embeddings {
+		embeddings = append(embeddings, schemas.Embedding{
+			Index:     idx,
+			Embedding: embedding,
+		})
+	}
+
+	return &schemas.EmbedResponse{
+		Created:    int(time.Now().UTC().Unix()),
+		Provider:   providerName,
+		ModelName:  embedResponse.Model,
+		Embeddings: embeddings,
+		TokenUsage: schemas.EmbedTokenUsage{
+			PromptTokens: embedResponse.PromptEvalCount,
+		},
+	}, nil
+}
@@ -0,0 +1,50 @@
+package ollama
+
+import (
+	"strings"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+var (
+	// Reference: https://github.com/ollama/ollama/blob/main/docs/api.md#generate-a-chat-completion
+	CompleteReason  = "stop"
+	MaxTokensReason = "length"
+)
+
+func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
+	return &FinishReasonMapper{
+		tel: tel,
+	}
+}
+
+type FinishReasonMapper struct {
+	tel *telemetry.Telemetry
+}
+
+func (m *FinishReasonMapper) Map(finishReason *string) *schemas.FinishReason {
+	if finishReason == nil || len(*finishReason) == 0 {
+		return nil
+	}
+
+	var reason *schemas.FinishReason
+
+	switch strings.ToLower(*finishReason) {
+	case CompleteReason:
+		reason = &schemas.ReasonComplete
+	case MaxTokensReason:
+		reason = &schemas.ReasonMaxTokens
+	default:
+		m.tel.Logger.Warn(
+			"Unknown finish reason, other is going to used",
+			zap.String("unknown_reason", *finishReason),
+		)
+
+		reason = &schemas.ReasonOther
+	}
+
+	return reason
+}
@@ -7,11 +7,19 @@ type ChatCompletion struct {
 		Role    string `json:"role"`
 		Content string `json:"content"`
 	} `json:"message"`
-	Done               bool  `json:"done"`
-	TotalDuration      int64 `json:"total_duration"`
-	LoadDuration       int64 `json:"load_duration"`
-	PromptEvalCount    int   `json:"prompt_eval_count,omitempty"`
-	PromptEvalDuration int64 `json:"prompt_eval_duration"`
-	EvalCount          int   `json:"eval_count"`
-	EvalDuration       int64 `json:"eval_duration"`
+	Done               bool    `json:"done"`
+	DoneReason         *string `json:"done_reason,omitempty"`
+	TotalDuration      int64   `json:"total_duration"`
+	LoadDuration       int64   `json:"load_duration"`
+	PromptEvalCount    int     `json:"prompt_eval_count,omitempty"`
+	PromptEvalDuration int64   `json:"prompt_eval_duration"`
+	EvalCount          int     `json:"eval_count"`
+	EvalDuration       int64   `json:"eval_duration"`
+	Error              *string `json:"error,omitempty"`
 }
+
+// ChatCompletionChunk represents a line of newline-delimited JSON a chat response is broken down on chat streaming.
+//
+//	The last chunk has the done flag set and comes with generation stats
+//	Ref: https://github.com/ollama/ollama/blob/main/docs/api.md#generate-a-chat-completion
+type ChatCompletionChunk = ChatCompletion
@@ -18,14 +18,14 @@ import (
 func NewChatRequestFromConfig(cfg *Config) *ChatRequest {
 	return &ChatRequest{
 		Model:            cfg.ModelName,
-		Temperature:      cfg.DefaultParams.Temperature,
-		TopP:             cfg.DefaultParams.TopP,
+		Temperature:      schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:             schemas.DefaultParam(cfg.DefaultParams.TopP),
 		MaxTokens:        cfg.DefaultParams.MaxTokens,
 		N:                cfg.DefaultParams.N,
 		StopWords:        cfg.DefaultParams.StopWords,
 		Stream:           false,
-		FrequencyPenalty: cfg.DefaultParams.FrequencyPenalty,
-		PresencePenalty:  cfg.DefaultParams.PresencePenalty,
+		FrequencyPenalty: schemas.DefaultParam(float64(cfg.DefaultParams.FrequencyPenalty)),
+		PresencePenalty:  schemas.DefaultParam(float64(cfg.DefaultParams.PresencePenalty)),
 		LogitBias:        cfg.DefaultParams.LogitBias,
 		User:             cfg.DefaultParams.User,
 		Seed:             cfg.DefaultParams.Seed,
@@ -118,7 +118,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 
 	modelChoice := chatCompletion.Choices[0]
 
-	if len(modelChoice.Message.Content) == 0 {
+	if len(modelChoice.Message.Content) == 0 && !modelChoice.Message.HasToolCalls() {
 		return nil, clients.ErrEmptyResponse
 	}
 
@@ -134,8 +134,9 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				"system_fingerprint": chatCompletion.SystemFingerprint,
 			},
 			Message: schemas.ChatMessage{
-				Role:    modelChoice.Message.Role,
-				Content: modelChoice.Message.Content,
+				Role:      modelChoice.Message.Role,
+				Content:   modelChoice.Message.Content,
+				ToolCalls: modelChoice.Message.ToolCalls,
 			},
 			TokenUsage: schemas.TokenUsage{
 				PromptTokens:   chatCompletion.Usage.PromptTokens,
@@ -143,6 +144,7 @@ func (c *Client) doChatRequest(ctx context.Context, payload *ChatRequest) (*sche
 				TotalTokens:    chatCompletion.Usage.TotalTokens,
 			},
 		},
+		FinishReason: c.finishReasonMapper.Map(modelChoice.FinishReason),
 	}
 
 	return &response, nil
@@ -17,25 +17,44 @@ import (
 
 var StreamDoneMarker = []byte("[DONE]")
 
-// ChatStream represents OpenAI chat stream for a specific request
+// StreamErrorMapper maps failed chat stream responses to the gateway errors
+type StreamErrorMapper interface {
+	Map(resp *http.Response) error
+}
+
+// StreamFinishReasonMapper maps provider finish reasons of chat stream chunks to the gateway ones
+type StreamFinishReasonMapper interface {
+	Map(finishReason string) *schemas.FinishReason
+}
+
+// StreamUsageReader reads token usage from raw chat stream chunks of providers that don't report it in the usage field
+type StreamUsageReader func(rawChunk []byte) (*Usage, error)
+
+// ChatStream represents OpenAI chat stream for a specific request.
+//
+//	It's also used by providers that speak the OpenAI wire format, so the provider name is configurable
 type ChatStream struct {
+	provider           string
 	client             *http.Client
 	req                *http.Request
 	resp               *http.Response
 	reader             *sse.EventStreamReader
-	finishReasonMapper *FinishReasonMapper
-	errMapper          *ErrorMapper
+	finishReasonMapper StreamFinishReasonMapper
+	errMapper          StreamErrorMapper
+	usageReader        StreamUsageReader
 	logger             *zap.Logger
 }
 
 func NewChatStream(
+	provider string,
 	client *http.Client,
 	req *http.Request,
-	finishReasonMapper *FinishReasonMapper,
-	errMapper *ErrorMapper,
+	finishReasonMapper StreamFinishReasonMapper,
+	errMapper StreamErrorMapper,
 	logger *zap.Logger,
 ) *ChatStream {
 	return &ChatStream{
+		provider:           provider,
 		client:             client,
 		req:                req,
 		finishReasonMapper: finishReasonMapper,
@@ -44,6 +63,13 @@ func NewChatStream(
 	}
 }
 
+// WithUsageReader sets the way token usage is read from chunks
+func (s *ChatStream) WithUsageReader(usageReader StreamUsageReader) *ChatStream {
+	s.usageReader = usageReader
+
+	return s
+}
+
 func (s *ChatStream) Open() error {
 	resp, err := s.client.Do(s.req) //nolint:bodyclose
 	if err != nil {
@@ -61,8 +87,6 @@ func (s *ChatStream) Open() error {
 }
 
 func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
-	var completionChunk ChatCompletionChunk
-
 	for {
 		rawEvent, err := s.reader.ReadEvent()
 		if err != nil {
@@ -101,27 +125,58 @@ func (s *ChatStream) Recv() (*schemas.ChatStreamChunk, error) {
 			continue
 		}
 
+		// a new chunk is decoded into a fresh struct, so tool call deltas don't leak into following chunks
+		var completionChunk ChatCompletionChunk
+
 		err = json.Unmarshal(event.Data, &completionChunk)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal chat stream chunk: %v", err)
 		}
 
+		if len(completionChunk.Choices) == 0 {
+			s.logger.Debug(
+				"Received a chunk without choices in chat stream, skipping it",
+				zap.ByteString("chunk", event.Data),
+			)
+
+			continue
+		}
+
 		responseChunk := completionChunk.Choices[0]
 
+		metadata := schemas.Metadata{
+			"response_id":        completionChunk.ID,
+			"system_fingerprint": completionChunk.SystemFingerprint,
+			"generated_at":       completionChunk.Created,
+		}
+
+		usage := completionChunk.Usage
+
+		if s.usageReader != nil {
+			usage, err = s.usageReader(event.Data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read token usage of chat stream chunk: %v", err)
+			}
+		}
+
+		if usage != nil {
+			// token usage is usually reported in the last chunk only
+			metadata["prompt_tokens"] = usage.PromptTokens
+			metadata["response_tokens"] = usage.CompletionTokens
+			metadata["total_tokens"] = usage.TotalTokens
+		}
+
 		// TODO: use objectpool here
 		return &schemas.ChatStreamChunk{
 			Cached:    false,
-			Provider:  providerName,
+			Provider:  s.provider,
 			ModelName: completionChunk.ModelName,
 			ModelResponse: schemas.ModelChunkResponse{
-				Metadata: &schemas.Metadata{
-					"response_id":        completionChunk.ID,
-					"system_fingerprint": completionChunk.SystemFingerprint,
-					"generated_at":       completionChunk.Created,
-				},
+				Metadata: &metadata,
 				Message: schemas.ChatMessage{
-					Role:    "assistant", // doesn't present in all chunks
-					Content: responseChunk.Delta.Content,
+					Role:      "assistant", // doesn't present in all chunks
+					Content:   responseChunk.Delta.Content,
+					ToolCalls: responseChunk.Delta.ToolCalls,
 				},
 			},
 			FinishReason: s.finishReasonMapper.Map(responseChunk.FinishReason),
@@ -149,6 +204,7 @@ func (c *Client) ChatStream(ctx context.Context, params *schemas.ChatParams) (cl
 	}
 
 	return NewChatStream(
+		providerName,
 		c.httpClient,
 		httpRequest,
 		c.finishReasonMapper,
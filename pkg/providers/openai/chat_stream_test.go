@@ -163,3 +163,66 @@ func TestOpenAIClient_ChatStreamRequestInterrupted(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAIClient_ChatStreamToolCalls(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat_stream.tool_calls.txt"))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the weather in London?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	var (
+		toolCalls []schemas.ToolCall
+		lastChunk *schemas.ChatStreamChunk
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		toolCalls = append(toolCalls, chunk.ModelResponse.Message.ToolCalls...)
+		lastChunk = chunk
+	}
+
+	require.Len(t, toolCalls, 3)
+	require.Equal(t, "call_abc123", toolCalls[0].ID)
+	require.Equal(t, "get_weather", toolCalls[0].Function.Name)
+	require.Empty(t, toolCalls[1].ID)
+	require.JSONEq(t, `{"location": "London, UK"}`, toolCalls[1].Function.Arguments+toolCalls[2].Function.Arguments)
+	require.Equal(t, &schemas.ReasonToolCalls, lastChunk.FinishReason)
+}
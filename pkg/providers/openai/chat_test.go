@@ -67,6 +67,200 @@ func TestOpenAIClient_ChatRequest(t *testing.T) {
 	require.Equal(t, "chatcmpl-123", response.ID)
 }
 
+func TestOpenAIClient_ChatRequestGenerationParams(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, 0.3, data["temperature"])
+		require.Equal(t, 64.0, data["max_tokens"])
+		require.Equal(t, 42.0, data["seed"])
+		require.Equal(t, 0.5, data["frequency_penalty"])
+		require.Equal(t, []interface{}{"END"}, data["stop"])
+		require.NotContains(t, data, "top_k")
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	temperature := 0.3
+	maxTokens := 64
+	seed := 42
+	frequencyPenalty := 0.5
+	topK := 10
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{
+			Role:    "user",
+			Content: "What's the capital of the United Kingdom?",
+		}},
+		GenerationParams: schemas.GenerationParams{
+			Temperature:      &temperature,
+			MaxTokens:        &maxTokens,
+			Seed:             &seed,
+			FrequencyPenalty: &frequencyPenalty,
+			Stop:             schemas.StopWords{"END"},
+			TopK:             &topK,
+		},
+	}
+
+	require.False(t, client.SupportParam(schemas.ParamTopK))
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	// the request params must not leak into the next requests
+	require.Equal(t, providerCfg.DefaultParams.Temperature, *client.chatRequestTemplate.Temperature)
+}
+
+func TestOpenAIClient_ChatRequestZeroTemperature(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		// explicit zeros must reach the provider, otherwise the provider default is used
+		require.Contains(t, data, "temperature")
+		require.Equal(t, 0.0, data["temperature"])
+		require.Contains(t, data, "presence_penalty")
+		require.Equal(t, 0.0, data["presence_penalty"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	temperature := 0.0
+	presencePenalty := 0.0
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{
+			Role:    "user",
+			Content: "What's the capital of the United Kingdom?",
+		}},
+		GenerationParams: schemas.GenerationParams{
+			Temperature:     &temperature,
+			PresencePenalty: &presencePenalty,
+		},
+	}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
+
+func TestOpenAIClient_ChatRequestResponseFormat(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		responseFormat := data["response_format"].(map[string]interface{})
+
+		require.Equal(t, "json_schema", responseFormat["type"])
+		require.Equal(t, "answer", responseFormat["json_schema"].(map[string]interface{})["name"])
+		require.NotContains(t, responseFormat, "validate")
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{
+			Role:    "user",
+			Content: "What's the capital of the United Kingdom?",
+		}},
+		ResponseFormat: &schemas.ResponseFormat{
+			Type: schemas.ResponseFormatJSONSchema,
+			JSONSchema: &schemas.JSONSchemaFormat{
+				Name:   "answer",
+				Schema: map[string]any{"type": "object"},
+			},
+			Validate: true,
+		},
+	}
+
+	_, err = client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+}
+
 func TestOpenAIClient_RateLimit(t *testing.T) {
 	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.Header().Set("Retry-After", "5m")
@@ -95,3 +289,66 @@ func TestOpenAIClient_RateLimit(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, &clients.RateLimitError{}, err)
 }
+
+func TestOpenAIClient_ChatRequestToolCalls(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data map[string]interface{}
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Len(t, data["tools"], 1)
+		require.Equal(t, "required", data["tool_choice"])
+
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.tool_calls.json"))
+		if err != nil {
+			t.Errorf("error reading openai chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{
+		Messages: []schemas.ChatMessage{{
+			Role:    "user",
+			Content: "What's the weather in London?",
+		}},
+		Tools: []schemas.Tool{{
+			Type:     schemas.ToolTypeFunction,
+			Function: schemas.ToolFunction{Name: "get_weather"},
+		}},
+		ToolChoice: &schemas.ToolChoice{Mode: schemas.ToolChoiceRequired},
+	}
+
+	response, err := client.Chat(ctx, &chatParams)
+	require.NoError(t, err)
+
+	message := response.ModelResponse.Message
+
+	require.Equal(t, &schemas.ReasonToolCalls, response.FinishReason)
+	require.Len(t, message.ToolCalls, 1)
+	require.Equal(t, "call_abc123", message.ToolCalls[0].ID)
+	require.Equal(t, "get_weather", message.ToolCalls[0].Function.Name)
+	require.JSONEq(t, `{"location": "London, UK"}`, message.ToolCalls[0].Function.Arguments)
+}
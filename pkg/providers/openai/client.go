@@ -10,6 +10,8 @@ import (
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
 )
 
 const (
@@ -20,6 +22,7 @@ const (
 type Client struct {
 	baseURL             string
 	chatURL             string
+	embedURL            string
 	chatRequestTemplate *ChatRequest
 	errMapper           *ErrorMapper
 	finishReasonMapper  *FinishReasonMapper
@@ -36,6 +39,11 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 		return nil, err
 	}
 
+	embedURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.EmbedEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
 	logger := tel.L().With(
 		zap.String("provider", providerName),
 	)
@@ -43,6 +51,7 @@ func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *
 	c := &Client{
 		baseURL:             providerConfig.BaseURL,
 		chatURL:             chatURL,
+		embedURL:            embedURL,
 		config:              providerConfig,
 		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
 		finishReasonMapper:  NewFinishReasonMapper(tel),
@@ -68,3 +77,19 @@ func (c *Client) Provider() string {
 func (c *Client) ModelName() string {
 	return c.config.ModelName
 }
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens, schemas.ParamStop,
+		schemas.ParamSeed, schemas.ParamFrequencyPenalty, schemas.ParamPresencePenalty:
+		return true
+	default:
+		return false
+	}
+}
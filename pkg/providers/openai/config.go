@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"github.com/EinStack/glide/pkg/api/schemas"
 	"github.com/EinStack/glide/pkg/config/fields"
 )
 
@@ -17,9 +18,9 @@ type Params struct {
 	LogitBias        *map[int]float64 `yaml:"logit_bias,omitempty" json:"logit_bias"`
 	User             *string          `yaml:"user,omitempty" json:"user"`
 	Seed             *int             `yaml:"seed,omitempty" json:"seed"`
-	Tools            []string         `yaml:"tools,omitempty" json:"tools"`
+	Tools            []schemas.Tool   `yaml:"tools,omitempty" json:"tools"`
 	ToolChoice       interface{}      `yaml:"tool_choice,omitempty" json:"tool_choice"`
-	ResponseFormat   interface{}      `yaml:"response_format,omitempty" json:"response_format"` // TODO: should this be a part of the chat request API?
+	ResponseFormat   *ResponseFormat  `yaml:"response_format,omitempty" json:"response_format"`
 }
 
 func DefaultParams() Params {
@@ -29,7 +30,7 @@ func DefaultParams() Params {
 		MaxTokens:   100,
 		N:           1,
 		StopWords:   []string{},
-		Tools:       []string{},
+		Tools:       []schemas.Tool{},
 	}
 }
 
@@ -44,6 +45,7 @@ func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type Config struct {
 	BaseURL       string        `yaml:"base_url" json:"base_url" validate:"required"`
 	ChatEndpoint  string        `yaml:"chat_endpoint" json:"chat_endpoint" validate:"required"`
+	EmbedEndpoint string        `yaml:"embed_endpoint" json:"embed_endpoint"`
 	ModelName     string        `yaml:"model" json:"model" validate:"required"`
 	APIKey        fields.Secret `yaml:"api_key" json:"-" validate:"required"`
 	DefaultParams *Params       `yaml:"default_params,omitempty" json:"default_params"`
@@ -56,6 +58,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		BaseURL:       "https://api.openai.com/v1",
 		ChatEndpoint:  "/chat/completions",
+		EmbedEndpoint: "/embeddings",
 		ModelName:     "gpt-4o",
 		DefaultParams: &defaultParams,
 	}
@@ -0,0 +1,144 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// maxEmbedBatchSize is the max number of inputs OpenAI accepts in one embedding request
+const maxEmbedBatchSize = 2048
+
+// EmbedRequest is an OpenAI-specific embedding request schema
+// Ref: https://platform.openai.com/docs/api-reference/embeddings/create
+type EmbedRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+}
+
+// EmbedResponse is an OpenAI-specific embedding response schema
+type EmbedResponse struct {
+	Object string           `json:"object"`
+	Model  string           `json:"model"`
+	Data   []EmbeddingEntry `json:"data"`
+	Usage  EmbedUsage       `json:"usage"`
+}
+
+type EmbeddingEntry struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+type EmbedUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// MaxEmbedBatchSize returns the max number of texts that can be embedded in one request
+func (c *Client) MaxEmbedBatchSize() int {
+	return maxEmbedBatchSize
+}
+
+// Embed sends an embedding request to the specified OpenAI model
+func (c *Client) Embed(ctx context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	embedReq := EmbedRequest{
+		Model:          c.config.ModelName,
+		Input:          params.Input,
+		EncodingFormat: "float",
+	}
+
+	rawPayload, err := json.Marshal(embedReq)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal openai embed request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.embedURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai embed request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	c.logger.Debug(
+		"Embed Request",
+		zap.String("embedURL", c.embedURL),
+		zap.Int("inputs", len(params.Input)),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai embed request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error("Failed to read embed response", zap.Error(err))
+
+		return nil, err
+	}
+
+	var embedResponse EmbedResponse
+
+	err = json.Unmarshal(bodyBytes, &embedResponse)
+	if err != nil {
+		c.logger.Error(
+			"Failed to unmarshal embed response",
+			zap.ByteString("rawResponse", bodyBytes),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	if len(embedResponse.Data) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	return NewEmbedResponse(providerName, &embedResponse), nil
+}
+
+// NewEmbedResponse maps OpenAI embedding response to Glide's one. It's shared with OpenAI-compatible providers
+func NewEmbedResponse(provider string, embedResponse *EmbedResponse) *schemas.EmbedResponse {
+	embeddings := make([]schemas.Embedding, 0, len(embedResponse.Data))
+
+	for _, entry := range embedResponse.Data {
+		embeddings = append(embeddings, schemas.Embedding{
+			Index:     entry.Index,
+			Embedding: entry.Embedding,
+		})
+	}
+
+	// entries are not guaranteed to come in the input order
+	sort.Slice(embeddings, func(i, j int) bool {
+		return embeddings[i].Index < embeddings[j].Index
+	})
+
+	return &schemas.EmbedResponse{
+		Created:    int(time.Now().UTC().Unix()),
+		Provider:   provider,
+		ModelName:  embedResponse.Model,
+		Embeddings: embeddings,
+		TokenUsage: schemas.EmbedTokenUsage{
+			PromptTokens: embedResponse.Usage.PromptTokens,
+		},
+	}
+}
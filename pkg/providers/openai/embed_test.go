@@ -0,0 +1,71 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_EmbedRequest(t *testing.T) {
+	// OpenAI Embeddings API: https://platform.openai.com/docs/api-reference/embeddings/create
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/embeddings", r.URL.Path)
+
+		rawPayload, _ := io.ReadAll(r.Body)
+
+		var data EmbedRequest
+
+		err := json.Unmarshal(rawPayload, &data)
+		if err != nil {
+			t.Errorf("error decoding payload (%q): %v", string(rawPayload), err)
+		}
+
+		require.Equal(t, "text-embedding-3-small", data.Model)
+		require.Equal(t, []string{"hello", "goodbye"}, data.Input)
+
+		embedResponse, err := os.ReadFile(filepath.Clean("./testdata/embed.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai embed mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(embedResponse)
+		if err != nil {
+			t.Errorf("error on sending embed response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+	providerCfg.ModelName = "text-embedding-3-small"
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	response, err := client.Embed(context.Background(), &schemas.EmbedParams{Input: []string{"hello", "goodbye"}})
+	require.NoError(t, err)
+
+	require.Equal(t, providerName, response.Provider)
+	require.Equal(t, 8, response.TokenUsage.PromptTokens)
+	require.Len(t, response.Embeddings, 2)
+	// entries are ordered by their input index
+	require.Equal(t, 0, response.Embeddings[0].Index)
+	require.Equal(t, []float64{0.0012301, 0.0231234, -0.0103214}, response.Embeddings[0].Embedding)
+}
@@ -1,9 +1,11 @@
 package openai
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/EinStack/glide/pkg/telemetry"
@@ -60,6 +62,32 @@ func (m *ErrorMapper) Map(resp *http.Response) error {
 		return clients.ErrUnauthorized
 	}
 
-	// Server & client errors result in the same error to keep gateway resilient
-	return clients.ErrProviderUnavailable
+	// Server & client errors are still reported as the provider unavailability,
+	//  but the status code is kept to decide if the request is worth sending to other models
+	providerErr := clients.NewResponseError(resp.StatusCode, bodyBytes)
+	providerErr.ContentFiltered = IsContentFilterErr(bodyBytes)
+
+	return providerErr
+}
+
+// ErrorResponse is an error response of OpenAI-compatible APIs
+type ErrorResponse struct {
+	Error struct {
+		Message string  `json:"message"`
+		Code    *string `json:"code"`
+	} `json:"error"`
+}
+
+// contentFilterCodes are error codes of requests rejected by the content filter (the latter is used by Azure OpenAI)
+var contentFilterCodes = []string{"content_policy_violation", "content_filter"}
+
+// IsContentFilterErr checks if the error response tells about the request rejected by the content filter
+func IsContentFilterErr(body []byte) bool {
+	var errResp ErrorResponse
+
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code == nil {
+		return false
+	}
+
+	return slices.Contains(contentFilterCodes, *errResp.Error.Code)
 }
@@ -0,0 +1,15 @@
+package openai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsContentFilterErr(t *testing.T) {
+	require.True(t, IsContentFilterErr([]byte(`{"error": {"message": "rejected", "code": "content_policy_violation"}}`)))
+	require.True(t, IsContentFilterErr([]byte(`{"error": {"message": "rejected", "code": "content_filter"}}`)))
+	require.False(t, IsContentFilterErr([]byte(`{"error": {"message": "too long", "code": "context_length_exceeded"}}`)))
+	require.False(t, IsContentFilterErr([]byte(`{"error": {"message": "failed", "code": null}}`)))
+	require.False(t, IsContentFilterErr([]byte(`Bad Gateway`)))
+}
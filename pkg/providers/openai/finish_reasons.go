@@ -13,6 +13,7 @@ var (
 	CompleteReason  = "stop"
 	MaxTokensReason = "length"
 	FilteredReason  = "content_filter"
+	ToolCallsReason = "tool_calls"
 )
 
 func NewFinishReasonMapper(tel *telemetry.Telemetry) *FinishReasonMapper {
@@ -39,6 +40,8 @@ func (m *FinishReasonMapper) Map(finishReason string) *schemas.FinishReason {
 		reason = &schemas.ReasonMaxTokens
 	case FilteredReason:
 		reason = &schemas.ReasonContentFiltered
+	case ToolCallsReason:
+		reason = &schemas.ReasonToolCalls
 	default:
 		m.tel.Logger.Warn(
 			"Unknown finish reason, other is going to used",
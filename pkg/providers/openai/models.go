@@ -0,0 +1,77 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+)
+
+// ModelsResponse is a list of models served by OpenAI-compatible APIs
+// Ref: https://platform.openai.com/docs/api-reference/models/list
+type ModelsResponse struct {
+	Object string       `json:"object"`
+	Data   []ModelEntry `json:"data"`
+}
+
+type ModelEntry struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// ModelIDs returns IDs of listed models
+func (r *ModelsResponse) ModelIDs() []string {
+	modelIDs := make([]string, 0, len(r.Data))
+
+	for _, model := range r.Data {
+		modelIDs = append(modelIDs, model.ID)
+	}
+
+	return modelIDs
+}
+
+// ListModels returns names of models available for the API key. It's a cheap way to check the API is reachable
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	modelsURL, err := url.JoinPath(c.baseURL, "/models")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai list models request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", string(c.config.APIKey)))
+
+	c.logger.Debug("List Models Request", zap.String("modelsURL", modelsURL))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai list models request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read openai list models response: %w", err)
+	}
+
+	var modelsResp ModelsResponse
+
+	if err := json.Unmarshal(bodyBytes, &modelsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse openai list models response: %w", err)
+	}
+
+	return modelsResp.ModelIDs(), nil
+}
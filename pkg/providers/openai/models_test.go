@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_ListModels(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/models", r.URL.Path)
+		require.Equal(t, http.MethodGet, r.Method)
+
+		modelsResponse, err := os.ReadFile(filepath.Clean("./testdata/models.success.json"))
+		if err != nil {
+			t.Errorf("error reading openai models mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		_, err = w.Write(modelsResponse)
+		if err != nil {
+			t.Errorf("error on sending models response: %v", err)
+		}
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	models, err := client.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"gpt-3.5-turbo", "gpt-4o"}, models)
+}
+
+func TestOpenAIClient_ListModelsUnauthorized(t *testing.T) {
+	openAIMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	openAIServer := httptest.NewServer(openAIMock)
+	defer openAIServer.Close()
+
+	providerCfg := DefaultConfig()
+	providerCfg.BaseURL = openAIServer.URL
+
+	client, err := NewClient(providerCfg, clients.DefaultClientConfig(), telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	_, err = client.ListModels(context.Background())
+	require.ErrorIs(t, err, clients.ErrUnauthorized)
+}
@@ -0,0 +1,37 @@
+package openai
+
+import "github.com/EinStack/glide/pkg/api/schemas"
+
+// ResponseFormat is an OpenAI-specific response format. It's also accepted by most of OpenAI-compatible APIs
+// Ref: https://platform.openai.com/docs/api-reference/chat/create#chat-create-response_format
+type ResponseFormat struct {
+	Type       schemas.ResponseFormatType `yaml:"type" json:"type"`
+	JSONSchema *schemas.JSONSchemaFormat  `yaml:"json_schema,omitempty" json:"json_schema,omitempty"`
+}
+
+// NewResponseFormat maps Glide's response format to OpenAI one passing the JSON schema natively
+func NewResponseFormat(format *schemas.ResponseFormat) *ResponseFormat {
+	if format == nil {
+		return nil
+	}
+
+	return &ResponseFormat{
+		Type:       format.Type,
+		JSONSchema: format.JSONSchema,
+	}
+}
+
+// NewJSONObjectFormat maps Glide's response format for APIs that support the JSON object mode only.
+//
+//	The JSON schema is requested via the format instruction in that case, so the JSON object mode is used to enforce a valid JSON
+func NewJSONObjectFormat(format *schemas.ResponseFormat) *ResponseFormat {
+	if format == nil {
+		return nil
+	}
+
+	if !format.IsJSON() {
+		return &ResponseFormat{Type: format.Type}
+	}
+
+	return &ResponseFormat{Type: schemas.ResponseFormatJSONObject}
+}
@@ -6,25 +6,46 @@ import "github.com/EinStack/glide/pkg/api/schemas"
 type ChatRequest struct {
 	Model            string                `json:"model"`
 	Messages         []schemas.ChatMessage `json:"messages"`
-	Temperature      float64               `json:"temperature,omitempty"`
-	TopP             float64               `json:"top_p,omitempty"`
+	Temperature      *float64              `json:"temperature,omitempty"`
+	TopP             *float64              `json:"top_p,omitempty"`
 	MaxTokens        int                   `json:"max_tokens,omitempty"`
 	N                int                   `json:"n,omitempty"`
 	StopWords        []string              `json:"stop,omitempty"`
 	Stream           bool                  `json:"stream,omitempty"`
-	FrequencyPenalty int                   `json:"frequency_penalty,omitempty"`
-	PresencePenalty  int                   `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64              `json:"frequency_penalty,omitempty"`
+	PresencePenalty  *float64              `json:"presence_penalty,omitempty"`
 	LogitBias        *map[int]float64      `json:"logit_bias,omitempty"`
 	User             *string               `json:"user,omitempty"`
 	Seed             *int                  `json:"seed,omitempty"`
-	Tools            []string              `json:"tools,omitempty"`
+	Tools            []schemas.Tool        `json:"tools,omitempty"`
 	ToolChoice       interface{}           `json:"tool_choice,omitempty"`
-	ResponseFormat   interface{}           `json:"response_format,omitempty"`
+	ResponseFormat   *ResponseFormat       `json:"response_format,omitempty"`
 }
 
 func (r *ChatRequest) ApplyParams(params *schemas.ChatParams) {
-	// TODO(185): set other params
 	r.Messages = params.Messages
+
+	params.GenerationParams.ApplyTo(schemas.ParamFields{
+		Temperature:      &r.Temperature,
+		TopP:             &r.TopP,
+		MaxTokens:        &r.MaxTokens,
+		Stop:             &r.StopWords,
+		Seed:             &r.Seed,
+		FrequencyPenalty: &r.FrequencyPenalty,
+		PresencePenalty:  &r.PresencePenalty,
+	})
+
+	if len(params.Tools) > 0 {
+		r.Tools = params.Tools
+	}
+
+	if params.ToolChoice != nil {
+		r.ToolChoice = params.ToolChoice
+	}
+
+	if params.ResponseFormat != nil {
+		r.ResponseFormat = NewResponseFormat(params.ResponseFormat)
+	}
 }
 
 // ChatCompletion
@@ -61,6 +82,7 @@ type ChatCompletionChunk struct {
 	ModelName         string         `json:"model"`
 	SystemFingerprint string         `json:"system_fingerprint"`
 	Choices           []StreamChoice `json:"choices"`
+	Usage             *Usage         `json:"usage,omitempty"`
 }
 
 type StreamChoice struct {
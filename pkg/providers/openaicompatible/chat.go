@@ -0,0 +1,179 @@
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/providers/openai"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"go.uber.org/zap"
+)
+
+// NewChatRequestFromConfig fills the struct from the config. Not using reflection because of performance penalty it gives
+func NewChatRequestFromConfig(cfg *Config) *openai.ChatRequest {
+	return &openai.ChatRequest{
+		Model:            cfg.ModelName,
+		Temperature:      schemas.DefaultParam(cfg.DefaultParams.Temperature),
+		TopP:             schemas.DefaultParam(cfg.DefaultParams.TopP),
+		MaxTokens:        cfg.DefaultParams.MaxTokens,
+		StopWords:        cfg.DefaultParams.StopWords,
+		Stream:           false,
+		FrequencyPenalty: schemas.DefaultParam(float64(cfg.DefaultParams.FrequencyPenalty)),
+		PresencePenalty:  schemas.DefaultParam(float64(cfg.DefaultParams.PresencePenalty)),
+		Seed:             cfg.DefaultParams.Seed,
+	}
+}
+
+// Chat sends a chat request to the specified OpenAI-compatible model.
+func (c *Client) Chat(ctx context.Context, params *schemas.ChatParams) (*schemas.ChatResponse, error) {
+	// Create a new chat request
+	// TODO: consider using objectpool to optimize memory allocation
+	chatReq := *c.chatRequestTemplate // hoping to get a copy of the template
+	chatReq.ApplyParams(params)
+
+	chatReq.Stream = false
+
+	chatResponse, err := c.doChatRequest(ctx, &chatReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return chatResponse, nil
+}
+
+func (c *Client) doChatRequest(ctx context.Context, payload *openai.ChatRequest) (*schemas.ChatResponse, error) {
+	// Build request payload
+	rawPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal openai-compatible chat request payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.chatURL, bytes.NewBuffer(rawPayload))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openai-compatible chat request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req)
+
+	// TODO: this could leak information from messages which may not be a desired thing to have
+	c.logger.Debug(
+		"Chat Request",
+		zap.String("chatURL", c.chatURL),
+		zap.Any("payload", payload),
+	)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send openai-compatible chat request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	// Read the response body into a byte slice
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.Error(
+			"Failed to read chat response",
+			zap.Error(err),
+			zap.ByteString("rawResponse", bodyBytes),
+		)
+
+		return nil, err
+	}
+
+	c.logger.Debug(
+		"Raw chat response",
+		zap.ByteString("resp", bodyBytes),
+	)
+
+	// Parse the response JSON
+	var chatCompletion openai.ChatCompletion
+
+	err = json.Unmarshal(bodyBytes, &chatCompletion)
+	if err != nil {
+		c.logger.Error(
+			"Failed to unmarshal chat response",
+			zap.ByteString("rawResponse", bodyBytes),
+			zap.Error(err),
+		)
+
+		return nil, err
+	}
+
+	if len(chatCompletion.Choices) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	modelChoice := chatCompletion.Choices[0]
+
+	if len(modelChoice.Message.Content) == 0 {
+		return nil, clients.ErrEmptyResponse
+	}
+
+	// Map response to ChatResponse schema
+	response := schemas.ChatResponse{
+		ID:        chatCompletion.ID,
+		Created:   chatCompletion.Created,
+		Provider:  providerName,
+		ModelName: chatCompletion.ModelName,
+		Cached:    false,
+		ModelResponse: schemas.ModelResponse{
+			Metadata: map[string]string{
+				"system_fingerprint": chatCompletion.SystemFingerprint,
+			},
+			Message: schemas.ChatMessage{
+				Role:    modelChoice.Message.Role,
+				Content: modelChoice.Message.Content,
+			},
+			TokenUsage: schemas.TokenUsage{
+				PromptTokens:   chatCompletion.Usage.PromptTokens,
+				ResponseTokens: chatCompletion.Usage.CompletionTokens,
+				TotalTokens:    chatCompletion.Usage.TotalTokens,
+			},
+		},
+		FinishReason: c.finishReasonMapper.Map(modelChoice.FinishReason),
+	}
+
+	fillMissingFields(&response, c.config.ModelName)
+
+	return &response, nil
+}
+
+// fillMissingFields tolerates responses of servers that don't fill all fields of the OpenAI response schema
+func fillMissingFields(response *schemas.ChatResponse, modelName string) {
+	if len(response.ID) == 0 {
+		response.ID = uuid.NewString()
+	}
+
+	if response.Created == 0 {
+		response.Created = int(time.Now().UTC().Unix())
+	}
+
+	if len(response.ModelName) == 0 {
+		response.ModelName = modelName
+	}
+
+	if len(response.ModelResponse.Message.Role) == 0 {
+		response.ModelResponse.Message.Role = "assistant"
+	}
+
+	tokenUsage := &response.ModelResponse.TokenUsage
+
+	if tokenUsage.TotalTokens == 0 {
+		tokenUsage.TotalTokens = tokenUsage.PromptTokens + tokenUsage.ResponseTokens
+	}
+}
@@ -0,0 +1,128 @@
+package openaicompatible
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAICompatibleClient_ChatStreamRequest(t *testing.T) {
+	serverMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat_stream.success.txt"))
+		if err != nil {
+			t.Errorf("error reading chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	server := httptest.NewServer(serverMock)
+	defer server.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = server.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	require.True(t, client.SupportChatStream())
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	var lastChunk *schemas.ChatStreamChunk
+
+	for {
+		chunk, err := stream.Recv()
+
+		if err == io.EOF {
+			require.NotNil(t, lastChunk)
+			require.Equal(t, &schemas.ReasonComplete, lastChunk.FinishReason)
+
+			return
+		}
+
+		require.NoError(t, err)
+		require.NotNil(t, chunk)
+		require.Equal(t, "openai_compatible", chunk.Provider)
+
+		lastChunk = chunk
+	}
+}
+
+func TestOpenAICompatibleClient_ChatStreamRequestInterrupted(t *testing.T) {
+	serverMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat_stream.nodone.txt"))
+		if err != nil {
+			t.Errorf("error reading chat mock response: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		_, err = w.Write(chatResponse)
+		if err != nil {
+			t.Errorf("error on sending chat response: %v", err)
+		}
+	})
+
+	server := httptest.NewServer(serverMock)
+	defer server.Close()
+
+	ctx := context.Background()
+	providerCfg := DefaultConfig()
+	clientCfg := clients.DefaultClientConfig()
+
+	providerCfg.BaseURL = server.URL
+
+	client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+	require.NoError(t, err)
+
+	chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+		Role:    "user",
+		Content: "What's the capital of the United Kingdom?",
+	}}}
+
+	stream, err := client.ChatStream(ctx, &chatParams)
+	require.NoError(t, err)
+
+	err = stream.Open()
+	require.NoError(t, err)
+
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			require.ErrorIs(t, err, clients.ErrProviderUnavailable)
+			return
+		}
+
+		require.NotNil(t, chunk)
+	}
+}
@@ -0,0 +1,109 @@
+package openaicompatible
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/EinStack/glide/pkg/providers/openai"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+const (
+	providerName = "openai_compatible"
+)
+
+// Client is a client for accessing self-hosted servers that speak the OpenAI wire format
+type Client struct {
+	baseURL             string
+	chatURL             string
+	chatRequestTemplate *openai.ChatRequest
+	errMapper           *ErrorMapper
+	finishReasonMapper  *openai.FinishReasonMapper
+	config              *Config
+	httpClient          *http.Client
+	tel                 *telemetry.Telemetry
+	logger              *zap.Logger
+}
+
+// NewClient creates a new client for the OpenAI-compatible API.
+func NewClient(providerConfig *Config, clientConfig *clients.ClientConfig, tel *telemetry.Telemetry) (*Client, error) {
+	chatURL, err := url.JoinPath(providerConfig.BaseURL, providerConfig.ChatEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := tel.L().With(
+		zap.String("provider", providerName),
+	)
+
+	c := &Client{
+		baseURL:             providerConfig.BaseURL,
+		chatURL:             chatURL,
+		config:              providerConfig,
+		chatRequestTemplate: NewChatRequestFromConfig(providerConfig),
+		finishReasonMapper:  openai.NewFinishReasonMapper(tel),
+		errMapper:           NewErrorMapper(tel),
+		httpClient: &http.Client{
+			Timeout: time.Duration(*clientConfig.Timeout),
+			Transport: &http.Transport{
+				MaxIdleConns:        *clientConfig.MaxIdleConns,
+				MaxIdleConnsPerHost: *clientConfig.MaxIdleConnsPerHost,
+			},
+		},
+		tel:    tel,
+		logger: logger,
+	}
+
+	return c, nil
+}
+
+// setHeaders adds the static headers and the API key (if configured) to the request
+func (c *Client) setHeaders(req *http.Request) {
+	for name, value := range c.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if len(c.config.APIKey) == 0 {
+		return
+	}
+
+	authValue := string(c.config.APIKey)
+
+	if len(c.config.AuthScheme) > 0 {
+		authValue = c.config.AuthScheme + " " + authValue
+	}
+
+	req.Header.Set(c.config.AuthHeader, authValue)
+}
+
+func (c *Client) Provider() string {
+	return providerName
+}
+
+func (c *Client) ModelName() string {
+	return c.config.ModelName
+}
+
+// SupportModality checks if the provider can translate the given content modality to its API
+func (c *Client) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || modality == schemas.ModalityImage
+}
+
+// SupportParam checks if the generation param can be passed to the provider
+func (c *Client) SupportParam(param schemas.GenerationParam) bool {
+	switch param {
+	case schemas.ParamTemperature, schemas.ParamTopP, schemas.ParamMaxTokens, schemas.ParamStop,
+		schemas.ParamSeed, schemas.ParamFrequencyPenalty, schemas.ParamPresencePenalty:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,167 @@
+package openaicompatible
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAICompatibleClient_ChatRequest(t *testing.T) {
+	tests := map[string]struct {
+		responseFile string
+		modelName    string
+		totalTokens  int
+	}{
+		"vllm":      {"./testdata/chat.vllm.json", "meta-llama/Meta-Llama-3-8B-Instruct", 28},
+		"llama.cpp": {"./testdata/chat.llamacpp.json", "llama3", 28},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Empty(t, r.Header.Get("Authorization"))
+
+				chatResponse, err := os.ReadFile(filepath.Clean(tt.responseFile))
+				if err != nil {
+					t.Errorf("error reading chat mock response: %v", err)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+
+				_, err = w.Write(chatResponse)
+				if err != nil {
+					t.Errorf("error on sending chat response: %v", err)
+				}
+			})
+
+			server := httptest.NewServer(serverMock)
+			defer server.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = server.URL
+			providerCfg.ModelName = "llama3"
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			response, err := client.Chat(ctx, &chatParams)
+			require.NoError(t, err)
+
+			require.Equal(t, "openai_compatible", response.Provider)
+			require.Equal(t, tt.modelName, response.ModelName)
+			require.NotEmpty(t, response.ID)
+			require.Equal(t, "The blue whale is the biggest animal on Earth.", response.ModelResponse.Message.Content)
+			require.Equal(t, &schemas.ReasonComplete, response.FinishReason)
+			require.Equal(t, tt.totalTokens, response.ModelResponse.TokenUsage.TotalTokens)
+		})
+	}
+}
+
+func TestOpenAICompatibleClient_Headers(t *testing.T) {
+	tests := map[string]struct {
+		authHeader string
+		authScheme string
+		expected   string
+	}{
+		"bearer token":      {"Authorization", "Bearer", "Bearer test-key"},
+		"custom header key": {"X-API-Key", "", "test-key"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverMock := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, tt.expected, r.Header.Get(tt.authHeader))
+				require.Equal(t, "team-a", r.Header.Get("X-Tenant"))
+
+				chatResponse, err := os.ReadFile(filepath.Clean("./testdata/chat.vllm.json"))
+				if err != nil {
+					t.Errorf("error reading chat mock response: %v", err)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write(chatResponse)
+			})
+
+			server := httptest.NewServer(serverMock)
+			defer server.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = server.URL
+			providerCfg.ModelName = "llama3"
+			providerCfg.APIKey = "test-key"
+			providerCfg.AuthHeader = tt.authHeader
+			providerCfg.AuthScheme = tt.authScheme
+			providerCfg.Headers = map[string]string{"X-Tenant": "team-a"}
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			_, err = client.Chat(ctx, &chatParams)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOpenAICompatibleClient_Chat_Error(t *testing.T) {
+	tests := map[int]error{
+		http.StatusUnauthorized:        clients.ErrUnauthorized,
+		http.StatusNotFound:            clients.ErrProviderUnavailable,
+		http.StatusInternalServerError: clients.ErrProviderUnavailable,
+	}
+
+	for statusCode, expectedErr := range tests {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			serverMock := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, `{"object": "error", "message": "Error"}`, statusCode)
+			})
+
+			server := httptest.NewServer(serverMock)
+			defer server.Close()
+
+			ctx := context.Background()
+			providerCfg := DefaultConfig()
+			clientCfg := clients.DefaultClientConfig()
+
+			providerCfg.BaseURL = server.URL
+
+			client, err := NewClient(providerCfg, clientCfg, telemetry.NewTelemetryMock())
+			require.NoError(t, err)
+
+			chatParams := schemas.ChatParams{Messages: []schemas.ChatMessage{{
+				Role:    "user",
+				Content: "What's the biggest animal?",
+			}}}
+
+			response, err := client.Chat(ctx, &chatParams)
+			require.Nil(t, response)
+			require.ErrorIs(t, err, expectedErr)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package openaicompatible
+
+import (
+	"github.com/EinStack/glide/pkg/config/fields"
+)
+
+// Params defines model params supported by most OpenAI-compatible servers
+// TODO: Add validations
+type Params struct {
+	Temperature      float64  `yaml:"temperature,omitempty" json:"temperature"`
+	TopP             float64  `yaml:"top_p,omitempty" json:"top_p"`
+	MaxTokens        int      `yaml:"max_tokens,omitempty" json:"max_tokens"`
+	StopWords        []string `yaml:"stop,omitempty" json:"stop"`
+	FrequencyPenalty int      `yaml:"frequency_penalty,omitempty" json:"frequency_penalty"`
+	PresencePenalty  int      `yaml:"presence_penalty,omitempty" json:"presence_penalty"`
+	Seed             *int     `yaml:"seed,omitempty" json:"seed"`
+}
+
+func DefaultParams() Params {
+	return Params{
+		Temperature: 0.8,
+		TopP:        1,
+		MaxTokens:   100,
+		StopWords:   []string{},
+	}
+}
+
+func (p *Params) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*p = DefaultParams()
+
+	type plain Params // to avoid recursion
+
+	return unmarshal((*plain)(p))
+}
+
+// Config for self-hosted servers that speak the OpenAI wire format (e.g. vLLM, TGI, llama.cpp, LM Studio).
+//
+//	The API key is optional. When it's set, it's sent in the AuthHeader prefixed by the AuthScheme (if any)
+type Config struct {
+	BaseURL       string            `yaml:"base_url" json:"base_url" validate:"required"`
+	ChatEndpoint  string            `yaml:"chat_endpoint" json:"chat_endpoint" validate:"required"`
+	ModelName     string            `yaml:"model" json:"model" validate:"required"`
+	APIKey        fields.Secret     `yaml:"api_key,omitempty" json:"-"`
+	AuthHeader    string            `yaml:"auth_header,omitempty" json:"auth_header"`
+	AuthScheme    string            `yaml:"auth_scheme,omitempty" json:"auth_scheme"`
+	Headers       map[string]string `yaml:"headers,omitempty" json:"-"` // static headers sent with every request
+	DefaultParams *Params           `yaml:"default_params,omitempty" json:"default_params"`
+}
+
+// DefaultConfig for OpenAI-compatible models
+func DefaultConfig() *Config {
+	defaultParams := DefaultParams()
+
+	return &Config{
+		BaseURL:       "http://localhost:8000/v1",
+		ChatEndpoint:  "/chat/completions",
+		AuthHeader:    "Authorization",
+		AuthScheme:    "Bearer",
+		DefaultParams: &defaultParams,
+	}
+}
+
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultConfig()
+
+	type plain Config // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -0,0 +1,54 @@
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/EinStack/glide/pkg/providers/openai"
+	"go.uber.org/zap"
+)
+
+// ListModels returns names of models the server serves. It's a cheap way to check the server is up
+func (c *Client) ListModels(ctx context.Context) ([]string, error) {
+	modelsURL, err := url.JoinPath(c.baseURL, "/models")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create list models request: %w", err)
+	}
+
+	c.setHeaders(req)
+
+	c.logger.Debug("List Models Request", zap.String("modelsURL", modelsURL))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send list models request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.errMapper.Map(resp)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list models response: %w", err)
+	}
+
+	var modelsResp openai.ModelsResponse
+
+	if err := json.Unmarshal(bodyBytes, &modelsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse list models response: %w", err)
+	}
+
+	return modelsResp.ModelIDs(), nil
+}
@@ -0,0 +1,73 @@
+package testing
+
+import (
+	"context"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// EmbedRespMock mocks an embedding response. Each input text is embedded into the same vector
+type EmbedRespMock struct {
+	Vector []float64
+	Err    error
+}
+
+// EmbedProviderMock mocks an embedding model provider
+type EmbedProviderMock struct {
+	idx       int
+	resps     *[]EmbedRespMock
+	modelName *string
+	batchSize int
+	// Batches keeps the number of texts in each request the provider received
+	Batches []int
+}
+
+func NewEmbedProviderMock(modelName *string, batchSize int, responses []EmbedRespMock) *EmbedProviderMock {
+	return &EmbedProviderMock{
+		idx:       0,
+		resps:     &responses,
+		modelName: modelName,
+		batchSize: batchSize,
+	}
+}
+
+func (c *EmbedProviderMock) Embed(_ context.Context, params *schemas.EmbedParams) (*schemas.EmbedResponse, error) {
+	resps := *c.resps
+
+	response := resps[c.idx]
+	c.idx++
+
+	c.Batches = append(c.Batches, len(params.Input))
+
+	if response.Err != nil {
+		return nil, response.Err
+	}
+
+	embeddings := make([]schemas.Embedding, 0, len(params.Input))
+
+	for idx := range params.Input {
+		embeddings = append(embeddings, schemas.Embedding{Index: idx, Embedding: response.Vector})
+	}
+
+	return &schemas.EmbedResponse{
+		ID:         "emb0001",
+		Embeddings: embeddings,
+		TokenUsage: schemas.EmbedTokenUsage{PromptTokens: len(params.Input)},
+	}, nil
+}
+
+func (c *EmbedProviderMock) MaxEmbedBatchSize() int {
+	return c.batchSize
+}
+
+func (c *EmbedProviderMock) Provider() string {
+	return "provider_mock"
+}
+
+func (c *EmbedProviderMock) ModelName() string {
+	if c.modelName == nil {
+		return "embed_model_mock"
+	}
+
+	return *c.modelName
+}
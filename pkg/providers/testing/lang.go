@@ -3,6 +3,8 @@ package testing
 import (
 	"context"
 	"io"
+	"slices"
+	"time"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 
@@ -11,8 +13,9 @@ import (
 
 // RespMock mocks a chat response or a streaming chat chunk
 type RespMock struct {
-	Msg string
-	Err error
+	Msg   string
+	Err   error
+	Delay time.Duration // how long the chat response takes. The request may be cancelled meanwhile
 }
 
 func (m *RespMock) Resp() *schemas.ChatResponse {
@@ -98,6 +101,10 @@ type ProviderMock struct {
 	chatStreams      *[]RespStreamMock
 	supportStreaming bool
 	modelName        *string
+	// Modalities the provider supports besides text
+	Modalities []schemas.Modality
+	// ChatStreamParams keeps params of streaming chat requests the provider received
+	ChatStreamParams []*schemas.ChatParams
 }
 
 func NewProviderMock(modelName *string, responses []RespMock) *ProviderMock {
@@ -122,7 +129,15 @@ func (c *ProviderMock) SupportChatStream() bool {
 	return c.supportStreaming
 }
 
-func (c *ProviderMock) Chat(_ context.Context, _ *schemas.ChatParams) (*schemas.ChatResponse, error) {
+func (c *ProviderMock) SupportModality(modality schemas.Modality) bool {
+	return modality == schemas.ModalityText || slices.Contains(c.Modalities, modality)
+}
+
+func (c *ProviderMock) SupportParam(_ schemas.GenerationParam) bool {
+	return true
+}
+
+func (c *ProviderMock) Chat(ctx context.Context, _ *schemas.ChatParams) (*schemas.ChatResponse, error) {
 	if c.chatResps == nil {
 		return nil, clients.ErrProviderUnavailable
 	}
@@ -132,6 +147,14 @@ func (c *ProviderMock) Chat(_ context.Context, _ *schemas.ChatParams) (*schemas.
 	response := responses[c.idx]
 	c.idx++
 
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
 	if response.Err != nil {
 		return nil, response.Err
 	}
@@ -139,7 +162,9 @@ func (c *ProviderMock) Chat(_ context.Context, _ *schemas.ChatParams) (*schemas.
 	return response.Resp(), nil
 }
 
-func (c *ProviderMock) ChatStream(_ context.Context, _ *schemas.ChatParams) (clients.ChatStream, error) {
+func (c *ProviderMock) ChatStream(_ context.Context, params *schemas.ChatParams) (clients.ChatStream, error) {
+	c.ChatStreamParams = append(c.ChatStreamParams, params)
+
 	if c.chatStreams == nil || c.idx >= len(*c.chatStreams) {
 		return nil, clients.ErrProviderUnavailable
 	}
@@ -0,0 +1,173 @@
+package routers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/cache"
+	"go.uber.org/zap"
+)
+
+// Cache failures are never propagated to clients. The request is served by models as if there was no cache
+
+// initSemanticCache enables the semantic cache of the router if it's configured.
+// Messages are embedded by one of the given embedding routers
+func (r *LangRouter) initSemanticCache(embedRouters map[string]*EmbedRouter) error {
+	cacheConfig := r.Config.Cache
+
+	if cacheConfig == nil || !cacheConfig.Enabled || cacheConfig.Semantic == nil {
+		return nil
+	}
+
+	embedRouter, found := embedRouters[cacheConfig.Semantic.EmbeddingRouter]
+	if !found {
+		return fmt.Errorf(
+			"router \"%v\": semantic cache refers to unknown embedding router \"%v\"",
+			r.routerID,
+			cacheConfig.Semantic.EmbeddingRouter,
+		)
+	}
+
+	r.semanticCache = cacheConfig.ToSemanticCache(embedRouter)
+
+	return nil
+}
+
+// chatCacheKey returns the cache key of the request if the router caches responses and the request allows it
+func (r *LangRouter) chatCacheKey(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	keyFunc func(string, *schemas.ChatRequest) (string, error),
+) (string, cache.Control, bool) {
+	control := cache.ControlFromContext(ctx)
+
+	if r.cache == nil || (control.NoCache && control.NoStore) {
+		return "", control, false
+	}
+
+	key, err := keyFunc(r.cacheScope, req)
+	if err != nil {
+		r.logger.Warn("Failed to build cache key", zap.Error(err))
+
+		return "", control, false
+	}
+
+	return key, control, true
+}
+
+func (r *LangRouter) cachedChat(ctx context.Context, key string, control cache.Control) *schemas.ChatResponse {
+	if control.NoCache {
+		return nil
+	}
+
+	resp, err := r.cache.GetChat(ctx, key)
+	if err != nil {
+		r.logger.Warn("Failed to get chat response from cache", zap.Error(err))
+
+		return nil
+	}
+
+	return resp
+}
+
+func (r *LangRouter) cacheChat(ctx context.Context, key string, control cache.Control, resp *schemas.ChatResponse) {
+	if control.NoStore {
+		return
+	}
+
+	if err := r.cache.SetChat(ctx, key, resp); err != nil {
+		r.logger.Warn("Failed to cache chat response", zap.Error(err))
+	}
+}
+
+func (r *LangRouter) cachedChatStream(ctx context.Context, key string, control cache.Control) []*schemas.ChatStreamChunk {
+	if control.NoCache {
+		return nil
+	}
+
+	chunks, err := r.cache.GetChatStream(ctx, key)
+	if err != nil {
+		r.logger.Warn("Failed to get streaming chat chunks from cache", zap.Error(err))
+
+		return nil
+	}
+
+	return chunks
+}
+
+func (r *LangRouter) cacheChatStream(
+	ctx context.Context,
+	key string,
+	control cache.Control,
+	chunks []*schemas.ChatStreamChunk,
+) {
+	if control.NoStore || len(chunks) == 0 {
+		return
+	}
+
+	if err := r.cache.SetChatStream(ctx, key, chunks); err != nil {
+		r.logger.Warn("Failed to cache streaming chat chunks", zap.Error(err))
+	}
+}
+
+// semanticCacheEntry locates the request in the semantic cache
+type semanticCacheEntry struct {
+	namespace string
+	vector    []float64
+}
+
+// cachedSemanticChat returns the cached response of a similar request.
+// The entry is returned to cache the response of the request later, so the message is embedded once
+func (r *LangRouter) cachedSemanticChat(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	control cache.Control,
+) (*schemas.ChatResponse, *semanticCacheEntry) {
+	if r.semanticCache == nil || (control.NoCache && control.NoStore) {
+		return nil, nil
+	}
+
+	namespace, cacheable, err := r.semanticCache.Namespace(r.cacheScope, req)
+	if err != nil {
+		r.logger.Warn("Failed to build semantic cache namespace", zap.Error(err))
+
+		return nil, nil
+	}
+
+	if !cacheable {
+		return nil, nil
+	}
+
+	vector, err := r.semanticCache.Embed(ctx, req)
+	if err != nil {
+		r.logger.Warn("Failed to embed chat message for semantic cache", zap.Error(err))
+
+		return nil, nil
+	}
+
+	entry := &semanticCacheEntry{namespace: namespace, vector: vector}
+
+	if control.NoCache {
+		return nil, entry
+	}
+
+	resp, err := r.semanticCache.GetChat(namespace, vector)
+	if err != nil {
+		r.logger.Warn("Failed to get chat response from semantic cache", zap.Error(err))
+
+		return nil, entry
+	}
+
+	return resp, entry
+}
+
+func (r *LangRouter) cacheSemanticChat(entry *semanticCacheEntry, control cache.Control, resp *schemas.ChatResponse) {
+	if entry == nil || control.NoStore {
+		return
+	}
+
+	if err := r.semanticCache.SetChat(entry.namespace, entry.vector, resp); err != nil {
+		r.logger.Warn("Failed to cache chat response in semantic cache", zap.Error(err))
+	}
+}
@@ -0,0 +1,218 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/cache"
+	"github.com/EinStack/glide/pkg/providers"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newCachedLangRouter(langModels []*providers.LanguageModel) *LangRouter {
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:          "test_router",
+		Config:            &LangRouterConfig{},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewPriority(models),
+		chatModels:        langModels,
+		chatStreamRouting: routing.NewPriority(models),
+		chatStreamModels:  langModels,
+		cache:             cache.DefaultConfig().ToCache(),
+		cacheScope:        "test_router",
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_Cached(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newCachedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	ctx := context.Background()
+
+	resp, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, "1", resp.ModelResponse.Message.Content)
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke "))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, "test_router", resp.RouterID)
+	require.Equal(t, "1", resp.ModelResponse.Message.Content)
+
+	// the cache is bypassed on client request
+	resp, err = router.Chat(cache.WithControl(ctx, cache.Control{NoCache: true}), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+}
+
+func TestLangRouter_Chat_NoStore(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newCachedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	ctx := context.Background()
+
+	_, err := router.Chat(cache.WithControl(ctx, cache.Control{NoStore: true}), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+
+	resp, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+}
+
+func TestLangRouter_ChatStream_CachedReplay(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newCachedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewStreamProviderMock(nil, []ptesting.RespStreamMock{
+				ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: "Knock"}, {Msg: "Knock"}}),
+			}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	streamChat := func() []*schemas.ChatStreamMessage {
+		respC := make(chan *schemas.ChatStreamMessage)
+
+		go func() {
+			defer close(respC)
+
+			router.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), respC)
+		}()
+
+		messages := make([]*schemas.ChatStreamMessage, 0, 2)
+
+		for message := range respC {
+			messages = append(messages, message)
+		}
+
+		return messages
+	}
+
+	messages := streamChat()
+	require.Len(t, messages, 2)
+	require.False(t, messages[0].Chunk.Cached)
+
+	// the model has no more streams to serve, so the chunks must come from the cache
+	messages = streamChat()
+	require.Len(t, messages, 2)
+
+	for _, message := range messages {
+		require.Nil(t, message.Error)
+		require.True(t, message.Chunk.Cached)
+		require.Equal(t, "Knock", message.Chunk.ModelResponse.Message.Content)
+	}
+}
+
+func TestLangRouter_Chat_SemanticCached(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newCachedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	embedRouter := newEmbedRouter([]*providers.EmbeddingModel{
+		providers.NewEmbedModel(
+			"embedder",
+			ptesting.NewEmbedProviderMock(nil, 1, []ptesting.EmbedRespMock{
+				{Vector: []float64{0.9, 0.1, 0}},
+				{Vector: []float64{0.88, 0.12, 0.01}},
+				{Vector: []float64{0, 0.1, 0.9}},
+			}),
+			3,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	cacheConfig := cache.DefaultConfig()
+	cacheConfig.Semantic = cache.DefaultSemanticConfig()
+	cacheConfig.Semantic.EmbeddingRouter = embedRouter.ID()
+	router.Config.Cache = cacheConfig
+
+	require.NoError(t, router.initSemanticCache(map[string]*EmbedRouter{embedRouter.ID(): embedRouter}))
+
+	ctx := context.Background()
+
+	resp, err := router.Chat(ctx, schemas.NewChatFromStr("how do I reset my password?"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, "1", resp.ModelResponse.Message.Content)
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("how can I reset the password?"))
+	require.NoError(t, err)
+	require.True(t, resp.Cached)
+	require.Equal(t, "test_router", resp.RouterID)
+	require.Equal(t, "1", resp.ModelResponse.Message.Content)
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("what is the price of the plan?"))
+	require.NoError(t, err)
+	require.False(t, resp.Cached)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+}
+
+func TestLangRouter_InitSemanticCache_UnknownEmbedRouter(t *testing.T) {
+	router := newCachedLangRouter(nil)
+
+	cacheConfig := cache.DefaultConfig()
+	cacheConfig.Semantic = cache.DefaultSemanticConfig()
+	cacheConfig.Semantic.EmbeddingRouter = "embedder"
+	router.Config.Cache = cacheConfig
+
+	require.Error(t, router.initSemanticCache(map[string]*EmbedRouter{}))
+}
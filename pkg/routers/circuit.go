@@ -0,0 +1,86 @@
+package routers
+
+import (
+	"context"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// circuitStateMetric is the gauge of model circuit states (0 - closed, 1 - half-open, 2 - open)
+const circuitStateMetric = "glide.model.circuit_state"
+
+var circuitStateValues = map[health.CircuitState]int64{
+	health.CircuitClosed:   0,
+	health.CircuitHalfOpen: 1,
+	health.CircuitOpen:     2,
+}
+
+// trackedModel is a model with its health tracked
+type trackedModel interface {
+	ID() string
+	HealthTracker() *health.Tracker
+}
+
+// Circuit returns the circuit breaker of the router model (e.g. to reset or force-open it)
+func (r *LangRouter) Circuit(modelID string) (*health.CircuitBreaker, error) {
+	return findCircuit(r.chatModels, modelID)
+}
+
+// Circuit returns the circuit breaker of the router model (e.g. to reset or force-open it)
+func (r *EmbedRouter) Circuit(modelID string) (*health.CircuitBreaker, error) {
+	return findCircuit(r.models, modelID)
+}
+
+func findCircuit[M trackedModel](models []M, modelID string) (*health.CircuitBreaker, error) {
+	for _, model := range models {
+		if model.ID() == modelID {
+			return model.HealthTracker().Circuit(), nil
+		}
+	}
+
+	return nil, &schemas.ErrModelNotFound
+}
+
+// registerCircuitMetrics exports circuit states of models of the given routers.
+//
+//	It's done once per manager and the registration must be unregistered once the routers are not used anymore
+func registerCircuitMetrics(langRouters []*LangRouter, embedRouters []*EmbedRouter) (metric.Registration, error) {
+	meter := otel.Meter("github.com/EinStack/glide/pkg/routers")
+
+	circuitGauge, err := meter.Int64ObservableGauge(
+		circuitStateMetric,
+		metric.WithDescription("Circuit state of the model: 0 - closed, 1 - half-open, 2 - open"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for _, router := range langRouters {
+			observeCircuits(observer, circuitGauge, router.ID(), router.chatModels)
+		}
+
+		for _, router := range embedRouters {
+			observeCircuits(observer, circuitGauge, router.ID(), router.models)
+		}
+
+		return nil
+	}, circuitGauge)
+}
+
+func observeCircuits[M trackedModel](observer metric.Observer, gauge metric.Int64Observable, routerID RouterID, models []M) {
+	for _, model := range models {
+		observer.ObserveInt64(
+			gauge,
+			circuitStateValues[model.HealthTracker().Circuit().State()],
+			metric.WithAttributes(
+				attribute.String("router_id", routerID),
+				attribute.String("model_id", model.ID()),
+			),
+		)
+	}
+}
@@ -0,0 +1,86 @@
+package routers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestLangRouter_Chat_ForcedOpenCircuit(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}), budget, nil, *latConfig, 1),
+		providers.NewLangModel("second", ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "3"}}), budget, nil, *latConfig, 1),
+	}, &LangRouterConfig{})
+
+	circuit, err := router.Circuit("first")
+	require.NoError(t, err)
+
+	circuit.ForceOpen()
+
+	ctx := context.Background()
+
+	resp, err := router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	circuit.Reset()
+
+	resp, err = router.Chat(ctx, schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
+
+func TestLangRouter_Circuit_ModelNotFound(t *testing.T) {
+	router := newTimeoutLangRouter([]*providers.LanguageModel{}, &LangRouterConfig{})
+
+	_, err := router.Circuit("unknown")
+	require.ErrorIs(t, err, &schemas.ErrModelNotFound)
+}
+
+func TestRegisterCircuitMetrics_Unregister(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(noop.NewMeterProvider()) })
+
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel("first", ptesting.NewProviderMock(nil, nil), budget, nil, *latConfig, 1),
+	}, &LangRouterConfig{})
+
+	// routers are rebuilt, so metrics are registered again
+	oldMetrics, err := registerCircuitMetrics([]*LangRouter{router}, nil)
+	require.NoError(t, err)
+
+	newMetrics, err := registerCircuitMetrics([]*LangRouter{router}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, oldMetrics.Unregister())
+
+	var collected metricdata.ResourceMetrics
+
+	require.NoError(t, reader.Collect(context.Background(), &collected))
+	require.Len(t, collected.ScopeMetrics, 1)
+	require.Len(t, collected.ScopeMetrics[0].Metrics, 1)
+
+	gauge := collected.ScopeMetrics[0].Metrics[0].Data.(metricdata.Gauge[int64])
+	require.Len(t, gauge.DataPoints, 1)
+	require.Equal(t, int64(0), gauge.DataPoints[0].Value)
+
+	require.NoError(t, newMetrics.Unregister())
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/EinStack/glide/pkg/cache"
+	"github.com/EinStack/glide/pkg/config/fields"
 	"github.com/EinStack/glide/pkg/telemetry"
 
 	"github.com/EinStack/glide/pkg/routers/routing"
@@ -16,7 +18,8 @@ import (
 )
 
 type Config struct {
-	LanguageRouters []LangRouterConfig `yaml:"language" validate:"required,gte=1,dive"` // the list of language routers
+	LanguageRouters  []LangRouterConfig  `yaml:"language" validate:"required,gte=1,dive"` // the list of language routers
+	EmbeddingRouters []EmbedRouterConfig `yaml:"embedding" validate:"omitempty,dive"`     // the list of embedding routers
 }
 
 func (c *Config) BuildLangRouters(tel *telemetry.Telemetry) ([]*LangRouter, error) {
@@ -55,15 +58,56 @@ func (c *Config) BuildLangRouters(tel *telemetry.Telemetry) ([]*LangRouter, erro
 	return routers, nil
 }
 
-// TODO: how to specify other backoff strategies?
+func (c *Config) BuildEmbedRouters(tel *telemetry.Telemetry) ([]*EmbedRouter, error) {
+	seenIDs := make(map[string]bool, len(c.EmbeddingRouters))
+	routers := make([]*EmbedRouter, 0, len(c.EmbeddingRouters))
+
+	var errs error
+
+	for idx, routerConfig := range c.EmbeddingRouters {
+		if _, ok := seenIDs[routerConfig.ID]; ok {
+			return nil, fmt.Errorf("ID \"%v\" is specified for more than one embedding router while each ID should be unique", routerConfig.ID)
+		}
+
+		seenIDs[routerConfig.ID] = true
+
+		if !routerConfig.Enabled {
+			tel.L().Info(fmt.Sprintf("Embedding router \"%v\" is disabled, skipping", routerConfig.ID))
+			continue
+		}
+
+		tel.L().Debug("Init embedding router", zap.String("routerID", routerConfig.ID))
+
+		router, err := NewEmbedRouter(&c.EmbeddingRouters[idx], tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		routers = append(routers, router)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	return routers, nil
+}
+
 // TODO: Had to keep RoutingStrategy because of https://github.com/swaggo/swag/issues/1738
 // LangRouterConfig
 type LangRouterConfig struct {
-	ID              string                      `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
-	Enabled         bool                        `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
-	Retry           *retry.ExpRetryConfig       `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
-	RoutingStrategy routing.Strategy            `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
-	Models          []providers.LangModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+	ID              string                      `yaml:"id" json:"routers" validate:"required"`                                                     // Unique router ID
+	Enabled         bool                        `yaml:"enabled" json:"enabled" validate:"required"`                                                // Is router enabled?
+	Retry           *retry.Config               `yaml:"retry" json:"retry" validate:"required"`                                                    // retry when no healthy model is available to router
+	RetryPolicy     *retry.PolicyConfig         `yaml:"retry_policy" json:"retry_policy" validate:"required"`                                      // what to do when the model fails to serve the chat request
+	RoutingStrategy routing.Strategy            `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"`               // strategy on picking the next model to serve the request
+	Models          []providers.LangModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                                       // the list of models that could handle requests
+	Cache           *cache.Config               `yaml:"cache,omitempty" json:"cache,omitempty"`                                                    // cache responses of identical requests (disabled by default)
+	StreamRecovery  *StreamRecoveryConfig       `yaml:"stream_recovery" json:"stream_recovery" validate:"required"`                                // how to recover streaming chats when the model fails in the middle of the stream
+	Hedging         *HedgingConfig              `yaml:"hedging,omitempty" json:"hedging,omitempty"`                                                // send slow chat requests to one more model (disabled by default)
+	AttemptTimeout  *fields.Duration            `yaml:"attempt_timeout,omitempty" json:"attempt_timeout,omitempty" swaggertype:"primitive,string"` // how long to wait for one model to answer the chat request
+	TotalTimeout    *fields.Duration            `yaml:"total_timeout,omitempty" json:"total_timeout,omitempty" swaggertype:"primitive,string"`     // how long the chat request may take including all fallbacks & retries
 }
 
 // BuildModels creates LanguageModel slice out of the given config
@@ -163,32 +207,16 @@ func (c *LangRouterConfig) BuildModels(tel *telemetry.Telemetry) ([]*providers.L
 	return chatModels, chatStreamModels, nil
 }
 
-func (c *LangRouterConfig) BuildRetry() *retry.ExpRetry {
-	retryConfig := c.Retry
-	maxDelay := time.Duration(*retryConfig.MaxDelay)
-
-	return retry.NewExpRetry(
-		retryConfig.MaxRetries,
-		retryConfig.BaseMultiplier,
-		time.Duration(retryConfig.MinDelay),
-		&maxDelay,
-	)
+func (c *LangRouterConfig) BuildRetry() (*retry.Retry, error) {
+	return c.Retry.ToRetry()
 }
 
 func (c *LangRouterConfig) BuildRouting(
 	chatModels []*providers.LanguageModel,
 	chatStreamModels []*providers.LanguageModel,
 ) (routing.LangModelRouting, routing.LangModelRouting, error) {
-	chatModelPool := make([]providers.Model, 0, len(chatModels))
-	chatStreamModelPool := make([]providers.Model, 0, len(chatStreamModels))
-
-	for _, model := range chatModels {
-		chatModelPool = append(chatModelPool, model)
-	}
-
-	for _, model := range chatStreamModels {
-		chatStreamModelPool = append(chatStreamModelPool, model)
-	}
+	chatModelPool := toModelPool(chatModels)
+	chatStreamModelPool := toModelPool(chatStreamModels)
 
 	switch c.RoutingStrategy {
 	case routing.Priority:
@@ -210,7 +238,9 @@ func DefaultLangRouterConfig() LangRouterConfig {
 	return LangRouterConfig{
 		Enabled:         true,
 		RoutingStrategy: routing.Priority,
-		Retry:           retry.DefaultExpRetryConfig(),
+		Retry:           retry.DefaultConfig(),
+		RetryPolicy:     retry.DefaultPolicyConfig(),
+		StreamRecovery:  DefaultStreamRecoveryConfig(),
 	}
 }
 
@@ -221,3 +251,181 @@ func (c *LangRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 
 	return unmarshal((*plain)(c))
 }
+
+// StreamRecoveryConfig defines what clients receive when the model fails in the middle of the streaming chat
+type StreamRecoveryConfig struct {
+	Mode StreamRecoveryMode `yaml:"mode" json:"mode" swaggertype:"primitive,string" validate:"required,oneof=restart buffer continue none"`
+	// BufferChunks is the number of first chunks held back until the model is committed to serve the stream (the buffer mode only).
+	//  Models usually stream one token per chunk
+	BufferChunks int `yaml:"buffer_chunks,omitempty" json:"buffer_chunks" validate:"gte=0"`
+}
+
+func DefaultStreamRecoveryConfig() *StreamRecoveryConfig {
+	return &StreamRecoveryConfig{
+		Mode:         RestartRecovery,
+		BufferChunks: 20,
+	}
+}
+
+func (c *StreamRecoveryConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultStreamRecoveryConfig()
+
+	type plain StreamRecoveryConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// HedgingConfig defines hedged chat requests.
+//
+//	If the model has not answered in time, the request is sent to the next model as well and the first answer is served,
+//	while the other request is cancelled. It cuts the tail latency at the cost of extra requests
+type HedgingConfig struct {
+	Delay fields.Duration `yaml:"delay" json:"delay" swaggertype:"primitive,string" validate:"required"` // how long to wait for the model before sending the request to the next one
+	// Percentile makes the delay adaptive. Once the model has served enough requests,
+	//  the percentile (e.g. 95) of its latency is used instead of the delay
+	Percentile float64 `yaml:"percentile,omitempty" json:"percentile,omitempty" validate:"gte=0,lt=100"`
+}
+
+func DefaultHedgingConfig() *HedgingConfig {
+	return &HedgingConfig{
+		Delay: fields.Duration(2 * time.Second),
+	}
+}
+
+func (c *HedgingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultHedgingConfig()
+
+	type plain HedgingConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// EmbedRouterConfig defines a pool of embedding models.
+//
+//	Vectors of different lengths cannot be used interchangeably, so all models of the router must have the same dimensionality
+type EmbedRouterConfig struct {
+	ID              string                       `yaml:"id" json:"routers" validate:"required"`                                       // Unique router ID
+	Enabled         bool                         `yaml:"enabled" json:"enabled" validate:"required"`                                  // Is router enabled?
+	Retry           *retry.Config                `yaml:"retry" json:"retry" validate:"required"`                                      // retry when no healthy model is available to router
+	RoutingStrategy routing.Strategy             `yaml:"strategy" json:"strategy" swaggertype:"primitive,string" validate:"required"` // strategy on picking the next model to serve the request
+	Models          []providers.EmbedModelConfig `yaml:"models" json:"models" validate:"required,min=1,dive"`                         // the list of models that could handle requests
+}
+
+// BuildModels creates EmbeddingModel slice out of the given config
+func (c *EmbedRouterConfig) BuildModels(tel *telemetry.Telemetry) ([]*providers.EmbeddingModel, error) {
+	var errs error
+
+	seenIDs := make(map[string]bool, len(c.Models))
+	models := make([]*providers.EmbeddingModel, 0, len(c.Models))
+
+	for _, modelConfig := range c.Models {
+		if _, ok := seenIDs[modelConfig.ID]; ok {
+			return nil, fmt.Errorf(
+				"ID \"%v\" is specified for more than one model in router \"%v\", while it should be unique in scope of that pool",
+				modelConfig.ID,
+				c.ID,
+			)
+		}
+
+		seenIDs[modelConfig.ID] = true
+
+		if !modelConfig.Enabled {
+			tel.L().Info(
+				"ModelName is disabled, skipping",
+				zap.String("router", c.ID),
+				zap.String("model", modelConfig.ID),
+			)
+
+			continue
+		}
+
+		tel.L().Debug(
+			"Init embedding model",
+			zap.String("router", c.ID),
+			zap.String("model", modelConfig.ID),
+		)
+
+		model, err := modelConfig.ToModel(tel)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+
+		if len(models) > 0 && models[0].Dimensions() != model.Dimensions() {
+			errs = multierr.Append(errs, fmt.Errorf(
+				"model \"%v\" produces %v-dimensional vectors, while model \"%v\" produces %v-dimensional ones. "+
+					"All models of embedding router \"%v\" must have the same dimensionality",
+				model.ID(),
+				model.Dimensions(),
+				models[0].ID(),
+				models[0].Dimensions(),
+				c.ID,
+			))
+
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	if len(models) == 0 {
+		return nil, fmt.Errorf("router \"%v\" must have at least one active model, zero defined", c.ID)
+	}
+
+	if len(models) == 1 {
+		tel.L().WithOptions(zap.AddStacktrace(zap.ErrorLevel)).Warn(
+			fmt.Sprintf("Embedding router \"%v\" has only one active model defined. "+
+				"This is not recommended for production setups. "+
+				"Define at least a few models to leverage resiliency logic Glide provides",
+				c.ID,
+			),
+		)
+	}
+
+	return models, nil
+}
+
+func (c *EmbedRouterConfig) BuildRetry() (*retry.Retry, error) {
+	return c.Retry.ToRetry()
+}
+
+func (c *EmbedRouterConfig) BuildRouting(models []*providers.EmbeddingModel) (routing.LangModelRouting, error) {
+	modelPool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		modelPool = append(modelPool, model)
+	}
+
+	switch c.RoutingStrategy {
+	case routing.Priority:
+		return routing.NewPriority(modelPool), nil
+	case routing.RoundRobin:
+		return routing.NewRoundRobinRouting(modelPool), nil
+	case routing.WeightedRoundRobin:
+		return routing.NewWeightedRoundRobin(modelPool), nil
+	case routing.LeastLatency:
+		return routing.NewLeastLatencyRouting(providers.EmbedLatency, modelPool), nil
+	}
+
+	return nil, fmt.Errorf("routing strategy \"%v\" is not supported, please make sure there is no typo", c.RoutingStrategy)
+}
+
+func DefaultEmbedRouterConfig() EmbedRouterConfig {
+	return EmbedRouterConfig{
+		Enabled:         true,
+		RoutingStrategy: routing.Priority,
+		Retry:           retry.DefaultConfig(),
+	}
+}
+
+func (c *EmbedRouterConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultEmbedRouterConfig()
+
+	type plain EmbedRouterConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
@@ -33,7 +33,7 @@ func TestRouterConfig_BuildModels(t *testing.T) {
 				ID:              "first_router",
 				Enabled:         true,
 				RoutingStrategy: routing.Priority,
-				Retry:           retry.DefaultExpRetryConfig(),
+				Retry:           retry.DefaultConfig(),
 				Models: []providers.LangModelConfig{
 					{
 						ID:          "first_model",
@@ -52,7 +52,7 @@ func TestRouterConfig_BuildModels(t *testing.T) {
 				ID:              "second_router",
 				Enabled:         true,
 				RoutingStrategy: routing.LeastLatency,
-				Retry:           retry.DefaultExpRetryConfig(),
+				Retry:           retry.DefaultConfig(),
 				Models: []providers.LangModelConfig{
 					{
 						ID:          "first_model",
@@ -89,7 +89,7 @@ func TestRouterConfig_BuildModelsPerType(t *testing.T) {
 		ID:              "first_router",
 		Enabled:         true,
 		RoutingStrategy: routing.Priority,
-		Retry:           retry.DefaultExpRetryConfig(),
+		Retry:           retry.DefaultConfig(),
 		Models: []providers.LangModelConfig{
 			{
 				ID:          "first_model",
@@ -138,7 +138,7 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 						ID:              "first_router",
 						Enabled:         true,
 						RoutingStrategy: routing.Priority,
-						Retry:           retry.DefaultExpRetryConfig(),
+						Retry:           retry.DefaultConfig(),
 						Models: []providers.LangModelConfig{
 							{
 								ID:          "first_model",
@@ -157,7 +157,7 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 						ID:              "first_router",
 						Enabled:         true,
 						RoutingStrategy: routing.LeastLatency,
-						Retry:           retry.DefaultExpRetryConfig(),
+						Retry:           retry.DefaultConfig(),
 						Models: []providers.LangModelConfig{
 							{
 								ID:          "first_model",
@@ -183,7 +183,7 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 						ID:              "first_router",
 						Enabled:         true,
 						RoutingStrategy: routing.Priority,
-						Retry:           retry.DefaultExpRetryConfig(),
+						Retry:           retry.DefaultConfig(),
 						Models: []providers.LangModelConfig{
 							{
 								ID:          "first_model",
@@ -220,7 +220,7 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 						ID:              "first_router",
 						Enabled:         true,
 						RoutingStrategy: routing.Priority,
-						Retry:           retry.DefaultExpRetryConfig(),
+						Retry:           retry.DefaultConfig(),
 						Models:          []providers.LangModelConfig{},
 					},
 				},
@@ -234,3 +234,91 @@ func TestRouterConfig_InvalidSetups(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestEmbedRouterConfig_BuildModels(t *testing.T) {
+	openAIConfig := openai.DefaultConfig()
+	openAIConfig.APIKey = "ABC"
+
+	cohereConfig := cohere.DefaultConfig()
+	cohereConfig.APIKey = "ABC"
+
+	cfg := Config{
+		EmbeddingRouters: []EmbedRouterConfig{
+			{
+				ID:              "first_router",
+				Enabled:         true,
+				RoutingStrategy: routing.LeastLatency,
+				Retry:           retry.DefaultConfig(),
+				Models: []providers.EmbedModelConfig{
+					{
+						ID:          "first_model",
+						Enabled:     true,
+						Client:      clients.DefaultClientConfig(),
+						ErrorBudget: health.DefaultErrorBudget(),
+						Latency:     latency.DefaultConfig(),
+						Dimensions:  1024,
+						OpenAI:      openAIConfig,
+					},
+					{
+						ID:          "second_model",
+						Enabled:     true,
+						Client:      clients.DefaultClientConfig(),
+						ErrorBudget: health.DefaultErrorBudget(),
+						Latency:     latency.DefaultConfig(),
+						Dimensions:  1024,
+						BatchSize:   10,
+						Cohere:      cohereConfig,
+					},
+				},
+			},
+		},
+	}
+
+	routers, err := cfg.BuildEmbedRouters(telemetry.NewTelemetryMock())
+
+	require.NoError(t, err)
+	require.Len(t, routers, 1)
+	require.Len(t, routers[0].models, 2)
+	require.Equal(t, 2048, routers[0].models[0].BatchSize())
+	require.Equal(t, 10, routers[0].models[1].BatchSize())
+	require.IsType(t, &routing.LeastLatencyRouting{}, routers[0].routing)
+}
+
+func TestEmbedRouterConfig_DimensionsMismatch(t *testing.T) {
+	openAIConfig := openai.DefaultConfig()
+	openAIConfig.APIKey = "ABC"
+
+	cohereConfig := cohere.DefaultConfig()
+	cohereConfig.APIKey = "ABC"
+
+	cfg := EmbedRouterConfig{
+		ID:              "first_router",
+		Enabled:         true,
+		RoutingStrategy: routing.Priority,
+		Retry:           retry.DefaultConfig(),
+		Models: []providers.EmbedModelConfig{
+			{
+				ID:          "first_model",
+				Enabled:     true,
+				Client:      clients.DefaultClientConfig(),
+				ErrorBudget: health.DefaultErrorBudget(),
+				Latency:     latency.DefaultConfig(),
+				Dimensions:  1536,
+				OpenAI:      openAIConfig,
+			},
+			{
+				ID:          "second_model",
+				Enabled:     true,
+				Client:      clients.DefaultClientConfig(),
+				ErrorBudget: health.DefaultErrorBudget(),
+				Latency:     latency.DefaultConfig(),
+				Dimensions:  1024,
+				Cohere:      cohereConfig,
+			},
+		},
+	}
+
+	_, err := cfg.BuildModels(telemetry.NewTelemetryMock())
+
+	require.ErrorContains(t, err, "same dimensionality")
+}
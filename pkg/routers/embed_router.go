@@ -0,0 +1,123 @@
+package routers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"go.uber.org/zap"
+
+	"github.com/EinStack/glide/pkg/providers"
+
+	"github.com/EinStack/glide/pkg/telemetry"
+
+	"github.com/EinStack/glide/pkg/routers/routing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+type EmbedRouter struct {
+	routerID RouterID
+	Config   *EmbedRouterConfig
+	models   []*providers.EmbeddingModel
+	routing  routing.LangModelRouting
+	retry    *retry.Retry
+	tel      *telemetry.Telemetry
+	logger   *zap.Logger
+}
+
+func NewEmbedRouter(cfg *EmbedRouterConfig, tel *telemetry.Telemetry) (*EmbedRouter, error) {
+	models, err := cfg.BuildModels(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	modelRouting, err := cfg.BuildRouting(models)
+	if err != nil {
+		return nil, err
+	}
+
+	retryer, err := cfg.BuildRetry()
+	if err != nil {
+		return nil, err
+	}
+
+	router := &EmbedRouter{
+		routerID: cfg.ID,
+		Config:   cfg,
+		models:   models,
+		routing:  modelRouting,
+		retry:    retryer,
+		tel:      tel,
+		logger:   tel.L().With(zap.String("routerID", cfg.ID)),
+	}
+
+	return router, nil
+}
+
+func (r *EmbedRouter) ID() RouterID {
+	return r.routerID
+}
+
+func (r *EmbedRouter) Embed(ctx context.Context, req *schemas.EmbedRequest) (*schemas.EmbedResponse, error) {
+	if len(r.models) == 0 {
+		return nil, ErrNoModels
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	retryIterator := r.retry.Iterator()
+
+	for retryIterator.HasNext() {
+		// models that failed to serve the request are not picked again until the next retry
+		failedModels := make(map[string]struct{}, len(r.models))
+		modelIterator := r.routing.Iterator(skipModels(failedModels))
+
+		for {
+			model, err := modelIterator.Next()
+
+			if errors.Is(err, routing.ErrNoHealthyModels) {
+				// no healthy model in the pool. Let's retry after some time
+				break
+			}
+
+			embedModel := model.(providers.EmbedModel)
+
+			resp, err := embedModel.Embed(ctx, req.Params())
+			if err != nil {
+				r.logger.Warn(
+					"Embedding model failed processing embed request",
+					zap.String("modelID", embedModel.ID()),
+					zap.String("provider", embedModel.Provider()),
+					zap.Error(err),
+				)
+
+				failedModels[embedModel.ID()] = struct{}{}
+				retryIterator.ObserveErr(err)
+
+				continue
+			}
+
+			resp.RouterID = r.routerID
+
+			return resp, nil
+		}
+
+		// no providers were available to handle the request,
+		//  so we have to wait a bit with a hope there is some available next time
+		r.logger.Warn("No healthy model found to serve embed request, wait and retry")
+
+		err := retryIterator.WaitNext(ctx)
+		if err != nil {
+			// something has cancelled the context
+			return nil, err
+		}
+	}
+
+	// if we reach this part, then we are in trouble
+	r.logger.Error("No model was available to handle embed request")
+
+	return nil, &schemas.ErrNoModelAvailable
+}
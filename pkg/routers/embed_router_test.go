@@ -0,0 +1,140 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newEmbedRouter(models []*providers.EmbeddingModel) *EmbedRouter {
+	pool := make([]providers.Model, 0, len(models))
+	for _, model := range models {
+		pool = append(pool, model)
+	}
+
+	return &EmbedRouter{
+		routerID: "test_router",
+		Config:   &EmbedRouterConfig{},
+		retry:    retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		routing:  routing.NewPriority(pool),
+		models:   models,
+		tel:      telemetry.NewTelemetryMock(),
+		logger:   telemetry.NewLoggerMock(),
+	}
+}
+
+func TestEmbedRouter_Embed_Batching(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	provider := ptesting.NewEmbedProviderMock(nil, 2, []ptesting.EmbedRespMock{
+		{Vector: []float64{0.1, 0.2}},
+		{Vector: []float64{0.3, 0.4}},
+		{Vector: []float64{0.5, 0.6}},
+	})
+
+	router := newEmbedRouter([]*providers.EmbeddingModel{
+		providers.NewEmbedModel("first", provider, 2, budget, nil, *latConfig, 1),
+	})
+
+	req := &schemas.EmbedRequest{Input: schemas.EmbedInput{"a", "b", "c", "d", "e"}}
+
+	resp, err := router.Embed(context.Background(), req)
+	require.NoError(t, err)
+
+	require.Equal(t, []int{2, 2, 1}, provider.Batches)
+	require.Equal(t, "first", resp.ModelID)
+	require.Equal(t, "test_router", resp.RouterID)
+	require.Equal(t, 2, resp.Dimensions)
+	require.Equal(t, 5, resp.TokenUsage.PromptTokens)
+	require.Len(t, resp.Embeddings, 5)
+
+	for idx, embedding := range resp.Embeddings {
+		require.Equal(t, idx, embedding.Index)
+	}
+
+	require.Equal(t, []float64{0.5, 0.6}, resp.Embeddings[4].Embedding)
+}
+
+func TestEmbedRouter_Embed_PickNextHealthy(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newEmbedRouter([]*providers.EmbeddingModel{
+		providers.NewEmbedModel(
+			"first",
+			ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Err: clients.ErrProviderUnavailable}}),
+			2,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewEmbedModel(
+			"second",
+			ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Vector: []float64{0.1, 0.2}}}),
+			2,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	resp, err := router.Embed(context.Background(), &schemas.EmbedRequest{Input: schemas.EmbedInput{"a"}})
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+}
+
+func TestEmbedRouter_Embed_FallbackOnDimensionsMismatch(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newEmbedRouter([]*providers.EmbeddingModel{
+		providers.NewEmbedModel(
+			"first",
+			ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Vector: []float64{0.1, 0.2, 0.3}}}),
+			2,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewEmbedModel(
+			"second",
+			ptesting.NewEmbedProviderMock(nil, 10, []ptesting.EmbedRespMock{{Vector: []float64{0.1, 0.2}}}),
+			2,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	})
+
+	resp, err := router.Embed(context.Background(), &schemas.EmbedRequest{Input: schemas.EmbedInput{"a"}})
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+}
+
+func TestEmbedRouter_Embed_NoInput(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newEmbedRouter([]*providers.EmbeddingModel{
+		providers.NewEmbedModel("first", ptesting.NewEmbedProviderMock(nil, 10, nil), 2, budget, nil, *latConfig, 1),
+	})
+
+	_, err := router.Embed(context.Background(), &schemas.EmbedRequest{})
+	require.ErrorIs(t, err, &schemas.ErrNoEmbedInput)
+}
@@ -62,6 +62,11 @@ func (b *TokenBucket) Take(tokens uint64) error {
 	}
 }
 
+// Reset refills the bucket
+func (b *TokenBucket) Reset() {
+	atomic.StoreUint64(&b.timePointer, 0)
+}
+
 func (b *TokenBucket) HasTokens() bool {
 	return b.Tokens() >= 1.0
 }
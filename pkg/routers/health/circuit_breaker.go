@@ -0,0 +1,337 @@
+package health
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+)
+
+var ErrCircuitOpen = errors.New("model circuit is open, requests are not sent to the model for now")
+
+// CircuitState tells if requests are sent to the model
+type CircuitState = string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // the model serves all requests
+	CircuitOpen     CircuitState = "open"      // the model serves no requests until the open duration is over
+	CircuitHalfOpen CircuitState = "half_open" // the model serves a few probe requests to find out if it has recovered
+)
+
+// circuitWindowBuckets is the number of buckets the failure rate window is split into
+const circuitWindowBuckets = 10
+
+type CircuitBreakerConfig struct {
+	// FailureRate is the share of failed requests in the window that opens the circuit
+	FailureRate float64 `yaml:"failure_rate" json:"failure_rate" validate:"gt=0,lte=1"`
+	// MinRequests is the number of requests in the window needed to judge the failure rate
+	MinRequests uint            `yaml:"min_requests" json:"min_requests" validate:"gt=0"`
+	Window      fields.Duration `yaml:"window" json:"window" swaggertype:"primitive,string" validate:"required"`
+	// HalfOpenProbes is the number of requests sent to the model in half-open state. All of them must succeed to close the circuit
+	HalfOpenProbes uint `yaml:"half_open_probes" json:"half_open_probes" validate:"gt=0"`
+	// OpenDuration is how long the circuit stays open first time. It's doubled each time probes fail up to MaxOpenDuration
+	OpenDuration    fields.Duration `yaml:"open_duration" json:"open_duration" swaggertype:"primitive,string" validate:"required"`
+	MaxOpenDuration fields.Duration `yaml:"max_open_duration" json:"max_open_duration" swaggertype:"primitive,string" validate:"required"`
+}
+
+func DefaultCircuitBreakerConfig() *CircuitBreakerConfig {
+	return &CircuitBreakerConfig{
+		FailureRate:     0.5,
+		MinRequests:     10,
+		Window:          fields.Duration(1 * time.Minute),
+		HalfOpenProbes:  3,
+		OpenDuration:    fields.Duration(5 * time.Second),
+		MaxOpenDuration: fields.Duration(5 * time.Minute),
+	}
+}
+
+func (c *CircuitBreakerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultCircuitBreakerConfig()
+
+	type plain CircuitBreakerConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
+
+// CircuitBreaker stops sending requests to the model that keeps failing & lets traffic back gradually once it has recovered
+//
+//	closed -> open: the failure rate in the window is exceeded or the tracker trips the circuit (e.g. the error budget is drained)
+//	open -> half-open: the open duration is over
+//	half-open -> closed: all probe requests have succeeded
+//	half-open -> open: any probe request has failed. The open duration is doubled
+type CircuitBreaker struct {
+	mu             sync.Mutex
+	config         *CircuitBreakerConfig
+	state          CircuitState
+	outcomes       *outcomeWindow
+	openDuration   time.Duration
+	openUntil      time.Time
+	forced         bool // the circuit was opened by admin & stays open until it's reset
+	probes         uint
+	probeSuccesses uint
+	now            func() time.Time
+}
+
+func NewCircuitBreaker(config *CircuitBreakerConfig) *CircuitBreaker {
+	if config == nil {
+		config = DefaultCircuitBreakerConfig()
+	}
+
+	return &CircuitBreaker{
+		config:       config,
+		state:        CircuitClosed,
+		outcomes:     newOutcomeWindow(time.Duration(config.Window), circuitWindowBuckets),
+		openDuration: time.Duration(config.OpenDuration),
+		now:          time.Now,
+	}
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	return b.state
+}
+
+// OpenUntil returns when the open circuit lets probe requests through. It's nil if the circuit is not open
+//
+//	or it has been opened by admin and waits to be reset
+func (b *CircuitBreaker) OpenUntil() *time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	if b.state != CircuitOpen || b.forced {
+		return nil
+	}
+
+	openUntil := b.openUntil
+
+	return &openUntil
+}
+
+// Available checks if the circuit would let a request through
+func (b *CircuitBreaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		return b.probes < b.config.HalfOpenProbes
+	default:
+		return false
+	}
+}
+
+// Allow lets the request through the circuit. In half-open state, it takes one of the probe slots
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		if b.probes >= b.config.HalfOpenProbes {
+			return false
+		}
+
+		b.probes++
+
+		return true
+	default:
+		return false
+	}
+}
+
+// RecordSuccess counts the successful request. It returns true if the request has closed the circuit
+func (b *CircuitBreaker) RecordSuccess() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case CircuitClosed:
+		b.outcomes.add(b.now(), false)
+	case CircuitHalfOpen:
+		b.probeSuccesses++
+
+		if b.probeSuccesses >= b.config.HalfOpenProbes {
+			b.close()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// RecordFailure counts the failed request and opens the circuit if the model fails too often
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	switch b.state {
+	case CircuitClosed:
+		now := b.now()
+
+		b.outcomes.add(now, true)
+
+		requests, failures := b.outcomes.count(now)
+
+		if requests >= b.config.MinRequests && float64(failures)/float64(requests) >= b.config.FailureRate {
+			b.open()
+		}
+	case CircuitHalfOpen:
+		// the model has not recovered yet, so it's given more time
+		b.openDuration = min(2*b.openDuration, time.Duration(b.config.MaxOpenDuration))
+		b.open()
+	}
+}
+
+// Release frees the probe slot of the request that has finished neither successfully nor with a model failure
+// (e.g. it has been cancelled by the client)
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen && b.probes > b.probeSuccesses {
+		b.probes--
+	}
+}
+
+// Trip opens the closed circuit right away (e.g. the model has drained its error budget)
+func (b *CircuitBreaker) Trip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	if b.state == CircuitClosed {
+		b.open()
+	}
+}
+
+// TripFor opens the closed circuit right away for the given time instead of the configured open duration
+func (b *CircuitBreaker) TripFor(openDuration time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+
+	if b.state == CircuitClosed {
+		b.openDuration = openDuration
+		b.open()
+	}
+}
+
+// ForceOpen opens the circuit until it's reset, so the model serves no requests (e.g. during the provider maintenance)
+func (b *CircuitBreaker) ForceOpen() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.open()
+	b.forced = true
+}
+
+// Reset closes the circuit and forgets the failure history (e.g. the provider issue has been fixed)
+func (b *CircuitBreaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.close()
+}
+
+func (b *CircuitBreaker) open() {
+	b.state = CircuitOpen
+	b.openUntil = b.now().Add(b.openDuration)
+	b.probes = 0
+	b.probeSuccesses = 0
+}
+
+func (b *CircuitBreaker) close() {
+	b.state = CircuitClosed
+	b.forced = false
+	b.openDuration = time.Duration(b.config.OpenDuration)
+	b.probes = 0
+	b.probeSuccesses = 0
+	b.outcomes.reset()
+}
+
+// refresh moves the open circuit to half-open once the open duration is over
+func (b *CircuitBreaker) refresh() {
+	if b.state == CircuitOpen && !b.forced && !b.now().Before(b.openUntil) {
+		b.state = CircuitHalfOpen
+	}
+}
+
+type outcomeBucket struct {
+	startedAt time.Time
+	requests  uint
+	failures  uint
+}
+
+// outcomeWindow counts requests & failures in the sliding time window split into buckets
+type outcomeWindow struct {
+	bucketSize time.Duration
+	buckets    []outcomeBucket
+}
+
+func newOutcomeWindow(window time.Duration, buckets int) *outcomeWindow {
+	return &outcomeWindow{
+		bucketSize: max(window/time.Duration(buckets), 1),
+		buckets:    make([]outcomeBucket, buckets),
+	}
+}
+
+func (w *outcomeWindow) add(now time.Time, failed bool) {
+	startedAt := now.Truncate(w.bucketSize)
+	bucket := &w.buckets[(startedAt.UnixNano()/int64(w.bucketSize))%int64(len(w.buckets))]
+
+	if !bucket.startedAt.Equal(startedAt) {
+		// the bucket is reused for the new time span
+		*bucket = outcomeBucket{startedAt: startedAt}
+	}
+
+	bucket.requests++
+
+	if failed {
+		bucket.failures++
+	}
+}
+
+// count returns the number of requests & failures in the window
+func (w *outcomeWindow) count(now time.Time) (uint, uint) {
+	var requests, failures uint
+
+	windowStart := now.Truncate(w.bucketSize).Add(-w.bucketSize * time.Duration(len(w.buckets)-1))
+
+	for _, bucket := range w.buckets {
+		if bucket.startedAt.Before(windowStart) {
+			continue
+		}
+
+		requests += bucket.requests
+		failures += bucket.failures
+	}
+
+	return requests, failures
+}
+
+func (w *outcomeWindow) reset() {
+	clear(w.buckets)
+}
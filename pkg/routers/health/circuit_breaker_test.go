@@ -0,0 +1,144 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/stretchr/testify/require"
+)
+
+type clockMock struct {
+	now time.Time
+}
+
+func (c *clockMock) Now() time.Time {
+	return c.now
+}
+
+func (c *clockMock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newCircuitBreakerMock(config *CircuitBreakerConfig) (*CircuitBreaker, *clockMock) {
+	clock := &clockMock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	breaker := NewCircuitBreaker(config)
+	breaker.now = clock.Now
+
+	return breaker, clock
+}
+
+func TestCircuitBreaker_OpensOnFailureRate(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.MinRequests = 4
+	config.FailureRate = 0.5
+
+	breaker, _ := newCircuitBreakerMock(config)
+
+	breaker.RecordSuccess()
+	breaker.RecordFailure()
+	breaker.RecordSuccess()
+	require.Equal(t, CircuitClosed, breaker.State())
+
+	breaker.RecordFailure()
+
+	require.Equal(t, CircuitOpen, breaker.State())
+	require.False(t, breaker.Available())
+	require.False(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_OldFailuresLeaveWindow(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.MinRequests = 2
+	config.Window = fields.Duration(10 * time.Second)
+
+	breaker, clock := newCircuitBreakerMock(config)
+
+	breaker.RecordFailure()
+	clock.Advance(20 * time.Second)
+	breaker.RecordFailure()
+
+	require.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_HalfOpenProbes(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.HalfOpenProbes = 2
+	config.OpenDuration = fields.Duration(5 * time.Second)
+
+	breaker, clock := newCircuitBreakerMock(config)
+
+	breaker.Trip()
+	require.Equal(t, CircuitOpen, breaker.State())
+
+	clock.Advance(5 * time.Second)
+	require.Equal(t, CircuitHalfOpen, breaker.State())
+
+	require.True(t, breaker.Allow())
+	require.True(t, breaker.Allow())
+	// probe slots are taken
+	require.False(t, breaker.Allow())
+	require.False(t, breaker.Available())
+
+	require.False(t, breaker.RecordSuccess())
+	require.True(t, breaker.RecordSuccess())
+
+	require.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestCircuitBreaker_ReleasedProbe(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.HalfOpenProbes = 1
+
+	breaker, clock := newCircuitBreakerMock(config)
+
+	breaker.Trip()
+	clock.Advance(time.Duration(config.OpenDuration))
+
+	require.True(t, breaker.Allow())
+	require.False(t, breaker.Allow())
+
+	breaker.Release()
+
+	require.True(t, breaker.Allow())
+}
+
+func TestCircuitBreaker_OpenDurationGrows(t *testing.T) {
+	config := DefaultCircuitBreakerConfig()
+	config.OpenDuration = fields.Duration(5 * time.Second)
+	config.MaxOpenDuration = fields.Duration(15 * time.Second)
+
+	breaker, clock := newCircuitBreakerMock(config)
+
+	breaker.Trip()
+
+	expectedDurations := []time.Duration{5 * time.Second, 10 * time.Second, 15 * time.Second, 15 * time.Second}
+
+	for _, expectedDuration := range expectedDurations {
+		openUntil := breaker.OpenUntil()
+		require.NotNil(t, openUntil)
+		require.Equal(t, expectedDuration, openUntil.Sub(clock.Now()))
+
+		clock.Advance(expectedDuration)
+		require.True(t, breaker.Allow())
+
+		// the probe fails
+		breaker.RecordFailure()
+	}
+}
+
+func TestCircuitBreaker_ForceOpenAndReset(t *testing.T) {
+	breaker, clock := newCircuitBreakerMock(DefaultCircuitBreakerConfig())
+
+	breaker.ForceOpen()
+	clock.Advance(time.Hour)
+
+	require.Equal(t, CircuitOpen, breaker.State())
+	require.Nil(t, breaker.OpenUntil())
+
+	breaker.Reset()
+
+	require.Equal(t, CircuitClosed, breaker.State())
+	require.True(t, breaker.Allow())
+}
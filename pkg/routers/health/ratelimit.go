@@ -1,10 +1,14 @@
 package health
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // RateLimitTracker handles rate/quota limits that often represented via 429 errors and
 // has some well-defined cooldown period
 type RateLimitTracker struct {
+	mu      sync.Mutex
 	resetAt *time.Time
 }
 
@@ -15,14 +19,31 @@ func NewRateLimitTracker() *RateLimitTracker {
 }
 
 func (t *RateLimitTracker) Limited() bool {
+	return t.ResetAt() != nil
+}
+
+// ResetAt returns when the rate limit is reset. It's nil if the model is not rate limited
+func (t *RateLimitTracker) ResetAt() *time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	if t.resetAt != nil && time.Now().After(*t.resetAt) {
 		t.resetAt = nil
 	}
 
-	return t.resetAt != nil
+	if t.resetAt == nil {
+		return nil
+	}
+
+	resetAt := *t.resetAt
+
+	return &resetAt
 }
 
 func (t *RateLimitTracker) SetLimited(untilReset time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	resetAt := time.Now().Add(untilReset)
 
 	t.resetAt = &resetAt
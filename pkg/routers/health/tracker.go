@@ -2,43 +2,110 @@ package health
 
 import (
 	"errors"
+	"sync/atomic"
+	"time"
 
 	"github.com/EinStack/glide/pkg/providers/clients"
 )
 
 // Tracker tracks errors and general health of model provider
 type Tracker struct {
-	unauthorized bool
-	errBudget    *TokenBucket
-	rateLimit    *RateLimitTracker
+	circuit   *CircuitBreaker
+	errBudget *TokenBucket
+	refill    time.Duration // time to get one error token back
+	rateLimit *RateLimitTracker
+	timeouts  atomic.Uint64
+	// unauthorized tells that the model has been failing because of the API key since the last successful request
+	unauthorized atomic.Bool
 }
 
-func NewTracker(budget *ErrorBudget) *Tracker {
+func NewTracker(budget *ErrorBudget, circuitConfig *CircuitBreakerConfig) *Tracker {
 	return &Tracker{
-		unauthorized: false,
-		rateLimit:    NewRateLimitTracker(),
-		errBudget:    NewTokenBucket(budget.TimePerTokenMicro(), budget.Budget()),
+		circuit:   NewCircuitBreaker(circuitConfig),
+		rateLimit: NewRateLimitTracker(),
+		errBudget: NewTokenBucket(budget.TimePerTokenMicro(), budget.Budget()),
+		refill:    time.Duration(budget.TimePerTokenMicro()) * time.Microsecond,
 	}
 }
 
 func (t *Tracker) Healthy() bool {
-	return !t.unauthorized && !t.rateLimit.Limited() && t.errBudget.HasTokens()
+	return !t.rateLimit.Limited() && t.circuit.Available()
+}
+
+// Allow checks if the request can be sent to the model. It takes a probe slot if the model circuit is half-open
+func (t *Tracker) Allow() bool {
+	return t.circuit.Allow()
+}
+
+// Circuit returns the circuit breaker of the model
+func (t *Tracker) Circuit() *CircuitBreaker {
+	return t.circuit
+}
+
+func (t *Tracker) TrackSuccess() {
+	t.unauthorized.Store(false)
+
+	if t.circuit.RecordSuccess() {
+		// the model has recovered, so it gets the full budget back
+		t.errBudget.Reset()
+	}
 }
 
 func (t *Tracker) TrackErr(err error) {
 	var rateLimitErr *clients.RateLimitError
 
 	if errors.Is(err, clients.ErrUnauthorized) {
-		t.unauthorized = true
+		// the key may be fixed or rotated, so the model is probed again once the circuit is half-open
+		t.unauthorized.Store(true)
+		t.circuit.RecordFailure()
+		t.circuit.Trip()
 
 		return
 	}
 
 	if errors.As(err, &rateLimitErr) {
+		// the model is fine, it just has to cool down
 		t.rateLimit.SetLimited(rateLimitErr.UntilReset())
+		t.circuit.Release()
 
 		return
 	}
 
-	_ = t.errBudget.Take(1)
+	if errors.Is(err, clients.ErrTimeout) {
+		// the model may be overloaded rather than broken, but it's equally useless for clients waiting for the answer
+		t.timeouts.Add(1)
+	}
+
+	if err := t.errBudget.Take(1); err != nil || !t.errBudget.HasTokens() {
+		// the model gets probed as soon as it would have some budget again
+		t.circuit.TripFor(t.refill)
+	}
+
+	t.circuit.RecordFailure()
+}
+
+// Release frees the probe slot of the request that has finished neither successfully nor with a model failure
+// (e.g. it was cancelled by the caller)
+func (t *Tracker) Release() {
+	t.circuit.Release()
+}
+
+// Timeouts returns the number of requests the model has not answered in time
+func (t *Tracker) Timeouts() uint64 {
+	return t.timeouts.Load()
+}
+
+// Unauthorized checks if the model has been failing because of the API key since the last successful request
+func (t *Tracker) Unauthorized() bool {
+	return t.unauthorized.Load()
+}
+
+// RateLimitResetAt returns when the model rate limit is reset. It's nil if the model is not rate limited
+func (t *Tracker) RateLimitResetAt() *time.Time {
+	return t.rateLimit.ResetAt()
+}
+
+// ErrBudgetTokens returns the number of errors the model can make before its circuit is opened
+func (t *Tracker) ErrBudgetTokens() float64 {
+	return t.errBudget.Tokens()
 }
@@ -4,20 +4,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/EinStack/glide/pkg/config/fields"
 	"github.com/EinStack/glide/pkg/providers/clients"
 	"github.com/stretchr/testify/require"
 )
 
 func TestHealthTracker_HealthyByDefault(t *testing.T) {
 	budget := NewErrorBudget(3, SEC)
-	tracker := NewTracker(budget)
+	tracker := NewTracker(budget, DefaultCircuitBreakerConfig())
 
 	require.True(t, tracker.Healthy())
 }
 
 func TestHealthTracker_UnhealthyWhenBugetExceeds(t *testing.T) {
 	budget := NewErrorBudget(3, SEC)
-	tracker := NewTracker(budget)
+	tracker := NewTracker(budget, DefaultCircuitBreakerConfig())
 
 	for range 3 {
 		tracker.TrackErr(clients.ErrProviderUnavailable)
@@ -28,10 +29,61 @@ func TestHealthTracker_UnhealthyWhenBugetExceeds(t *testing.T) {
 
 func TestHealthTracker_RateLimited(t *testing.T) {
 	budget := NewErrorBudget(3, SEC)
-	tracker := NewTracker(budget)
+	tracker := NewTracker(budget, DefaultCircuitBreakerConfig())
 
 	limitedUntil := 10 * time.Minute
 	tracker.TrackErr(clients.NewRateLimitError(&limitedUntil))
 
 	require.False(t, tracker.Healthy())
 }
+
+func TestHealthTracker_Timeouts(t *testing.T) {
+	budget := NewErrorBudget(1, SEC)
+	tracker := NewTracker(budget, DefaultCircuitBreakerConfig())
+
+	tracker.TrackErr(clients.ErrTimeout)
+
+	require.Equal(t, uint64(1), tracker.Timeouts())
+	require.False(t, tracker.Healthy())
+}
+
+func TestHealthTracker_UnauthorizedRecovers(t *testing.T) {
+	budget := NewErrorBudget(3, SEC)
+	circuitConfig := DefaultCircuitBreakerConfig()
+	circuitConfig.OpenDuration = fields.Duration(10 * time.Millisecond)
+	circuitConfig.HalfOpenProbes = 1
+
+	tracker := NewTracker(budget, circuitConfig)
+
+	tracker.TrackErr(clients.ErrUnauthorized)
+	require.False(t, tracker.Healthy())
+
+	// the key has been fixed, so the probe request succeeds
+	require.Eventually(t, tracker.Healthy, 100*time.Millisecond, 5*time.Millisecond)
+	require.True(t, tracker.Allow())
+
+	tracker.TrackSuccess()
+
+	require.Equal(t, CircuitClosed, tracker.Circuit().State())
+}
+
+func TestHealthTracker_BudgetRestoredOnRecovery(t *testing.T) {
+	budget := NewErrorBudget(10, SEC)
+	circuitConfig := DefaultCircuitBreakerConfig()
+	circuitConfig.HalfOpenProbes = 1
+
+	tracker := NewTracker(budget, circuitConfig)
+
+	for range 10 {
+		tracker.TrackErr(clients.ErrProviderUnavailable)
+	}
+
+	require.False(t, tracker.Healthy())
+
+	// the model is probed once it would have one error token back
+	require.Eventually(t, tracker.Allow, 500*time.Millisecond, 5*time.Millisecond)
+	tracker.TrackSuccess()
+
+	require.True(t, tracker.Healthy())
+	require.InDelta(t, 10.0, tracker.errBudget.Tokens(), 0.5)
+}
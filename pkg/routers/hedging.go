@@ -0,0 +1,108 @@
+package routers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"go.uber.org/zap"
+)
+
+type hedgedChatResult struct {
+	model    providers.LangModel
+	resp     *schemas.ChatResponse
+	attempts []schemas.ChatAttempt
+	err      error
+}
+
+// hedgedChat sends the chat request to the model and, if it's slow to answer, to the next model from the iterator as well.
+//
+//	The first successful answer is served and the other request is cancelled.
+//	Models that failed are marked to be skipped by the iterator. Attempts of the cancelled request are not reported
+func (r *LangRouter) hedgedChat(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	langModel providers.LangModel,
+	modelIterator routing.LangModelIterator,
+	failedModels map[string]struct{},
+) (*schemas.ChatResponse, []schemas.ChatAttempt, error) {
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel() // the request that has not finished is not needed anymore
+
+	// buffered, so the late request doesn't block when nobody waits for it
+	resultC := make(chan hedgedChatResult, 2)
+
+	sendChat := func(model providers.LangModel) {
+		go func() {
+			resp, attempts, err := r.policyChat(hedgeCtx, req, model)
+			resultC <- hedgedChatResult{model: model, resp: resp, attempts: attempts, err: err}
+		}()
+	}
+
+	sendChat(langModel)
+
+	inFlight := 1
+
+	hedgeTimer := time.NewTimer(r.hedgeDelay(langModel))
+	defer hedgeTimer.Stop()
+
+	var (
+		attempts []schemas.ChatAttempt
+		lastErr  error
+	)
+
+	for inFlight > 0 {
+		select {
+		case <-hedgeTimer.C:
+			// the model is busy serving the request, so the iterator should not pick it again
+			failedModels[langModel.ID()] = struct{}{}
+
+			model, err := modelIterator.Next()
+			if errors.Is(err, routing.ErrNoHealthyModels) {
+				// there is no model to hedge with, so wait for the first one
+				continue
+			}
+
+			hedgeModel := model.(providers.LangModel)
+
+			r.logger.Debug(
+				"Lang model is slow to answer, hedge chat request",
+				zap.String("modelID", langModel.ID()),
+				zap.String("hedgeModelID", hedgeModel.ID()),
+			)
+
+			sendChat(hedgeModel)
+			inFlight++
+		case result := <-resultC:
+			inFlight--
+			attempts = append(attempts, result.attempts...)
+
+			if result.err == nil {
+				return result.resp, attempts, nil
+			}
+
+			failedModels[result.model.ID()] = struct{}{}
+			lastErr = result.err
+		case <-ctx.Done():
+			return nil, attempts, ctx.Err()
+		}
+	}
+
+	return nil, attempts, lastErr
+}
+
+// hedgeDelay returns how long to wait for the model before hedging the request
+func (r *LangRouter) hedgeDelay(langModel providers.LangModel) time.Duration {
+	hedging := r.Config.Hedging
+
+	if hedging.Percentile > 0 {
+		if delay, ok := langModel.ChatLatencyPercentile(hedging.Percentile); ok {
+			return delay
+		}
+	}
+
+	return time.Duration(hedging.Delay)
+}
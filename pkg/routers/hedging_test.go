@@ -0,0 +1,132 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newHedgedLangRouter(langModels []*providers.LanguageModel, delay time.Duration) *LangRouter {
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:          "test_router",
+		Config:            &LangRouterConfig{Hedging: &HedgingConfig{Delay: fields.Duration(delay)}},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewPriority(models),
+		chatModels:        langModels,
+		chatStreamRouting: routing.NewPriority(models),
+		chatStreamModels:  langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_Hedged(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	slowModel := providers.NewLangModel(
+		"slow",
+		ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "slow", Delay: 10 * time.Second}}),
+		budget,
+		nil,
+		*latConfig,
+		1,
+	)
+
+	router := newHedgedLangRouter([]*providers.LanguageModel{
+		slowModel,
+		providers.NewLangModel(
+			"fast",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "fast"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, 10*time.Millisecond)
+
+	startedAt := time.Now()
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "fast", resp.ModelID)
+	require.Equal(t, "fast", resp.ModelResponse.Message.Content)
+	require.Less(t, time.Since(startedAt), 5*time.Second)
+
+	// the cancelled request is not a failure of the slow model
+	require.Never(t, func() bool { return !slowModel.Healthy() }, 50*time.Millisecond, 5*time.Millisecond)
+}
+
+func TestLangRouter_Chat_HedgedAnsweredInTime(t *testing.T) {
+	budget := health.NewErrorBudget(1, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newHedgedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "first"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "second"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, 1*time.Second)
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
+
+func TestLangRouter_Chat_HedgeFailed(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newHedgedLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "first", Delay: 50 * time.Millisecond}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: clients.ErrProviderUnavailable}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, 10*time.Millisecond)
+
+	// the hedged request has failed, so the answer of the first model is awaited
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
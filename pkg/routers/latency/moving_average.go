@@ -56,7 +56,8 @@ func (e *MovingAverage) Value() float64 {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
-	if !e.WarmedUp() {
+	// the lock is not re-acquired via WarmedUp() as recursive read locking may deadlock with a pending writer
+	if e.count <= e.warmupSamples {
 		return 0.0
 	}
 
@@ -0,0 +1,68 @@
+package latency
+
+import (
+	"math"
+	"slices"
+	"sync"
+)
+
+// Window keeps the most recent samples of a series to estimate its percentiles.
+//
+//	Unlike the moving average, percentiles show how slow the tail of the series is
+type Window struct {
+	mu sync.RWMutex
+	// The ring buffer of the most recent samples
+	samples []float64
+	// The position of the next sample in the ring buffer
+	next int
+	// The number of samples in the ring buffer
+	count int
+	// The number of samples required to start estimating percentiles
+	warmupSamples int
+}
+
+func NewWindow(size int, warmupSamples uint8) *Window {
+	return &Window{
+		samples:       make([]float64, size),
+		warmupSamples: int(warmupSamples),
+	}
+}
+
+// Add a value to the series. The oldest value is dropped when the window is full
+func (w *Window) Add(value float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.samples[w.next] = value
+	w.next = (w.next + 1) % len(w.samples)
+
+	if w.count < len(w.samples) {
+		w.count++
+	}
+}
+
+func (w *Window) WarmedUp() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.count > 0 && w.count >= w.warmupSamples
+}
+
+// Percentile returns the given percentile (e.g. 95) of the samples in the window using the nearest-rank method.
+// It's false if the series hasn't warmed up yet
+func (w *Window) Percentile(percentile float64) (float64, bool) {
+	if !w.WarmedUp() {
+		return 0, false
+	}
+
+	w.mu.RLock()
+	samples := slices.Clone(w.samples[:w.count])
+	w.mu.RUnlock()
+
+	slices.Sort(samples)
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(samples))))
+	rank = min(max(rank, 1), len(samples))
+
+	return samples[rank-1], true
+}
@@ -0,0 +1,41 @@
+package latency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow_Percentile(t *testing.T) {
+	window := NewWindow(10, 3)
+
+	window.Add(100)
+	window.Add(200)
+
+	_, ok := window.Percentile(95)
+	require.False(t, ok)
+
+	for value := 300; value <= 1000; value += 100 {
+		window.Add(float64(value))
+	}
+
+	p95, ok := window.Percentile(95)
+	require.True(t, ok)
+	require.InDelta(t, 1000, p95, 0.0001)
+
+	p50, _ := window.Percentile(50)
+	require.InDelta(t, 500, p50, 0.0001)
+}
+
+func TestWindow_DropsOldestSamples(t *testing.T) {
+	window := NewWindow(3, 1)
+
+	window.Add(1000)
+	window.Add(10)
+	window.Add(20)
+	window.Add(30)
+
+	p100, ok := window.Percentile(100)
+	require.True(t, ok)
+	require.InDelta(t, 30, p100, 0.0001)
+}
@@ -1,19 +1,41 @@
 package routers
 
 import (
+	"context"
+	"sync"
+
 	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
 	"github.com/EinStack/glide/pkg/telemetry"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
 )
 
 type RouterManager struct {
-	Config        *Config
-	tel           *telemetry.Telemetry
-	langRouterMap *map[string]*LangRouter
-	langRouters   []*LangRouter
+	Config         *Config
+	tel            *telemetry.Telemetry
+	langRouterMap  *map[string]*LangRouter
+	langRouters    []*LangRouter
+	embedRouterMap *map[string]*EmbedRouter
+	embedRouters   []*EmbedRouter
+	healthCheckers []*providers.HealthChecker
+	circuitMetrics metric.Registration
 }
 
 // NewManager creates a new instance of Router Manager that creates, holds and returns all routers
 func NewManager(cfg *Config, tel *telemetry.Telemetry) (*RouterManager, error) {
+	// embedding routers go first as language routers may use them in semantic caches
+	embedRouters, err := cfg.BuildEmbedRouters(tel)
+	if err != nil {
+		return nil, err
+	}
+
+	embedRouterMap := make(map[string]*EmbedRouter, len(embedRouters))
+
+	for _, router := range embedRouters {
+		embedRouterMap[router.ID()] = router
+	}
+
 	langRouters, err := cfg.BuildLangRouters(tel)
 	if err != nil {
 		return nil, err
@@ -22,14 +44,32 @@ func NewManager(cfg *Config, tel *telemetry.Telemetry) (*RouterManager, error) {
 	langRouterMap := make(map[string]*LangRouter, len(langRouters))
 
 	for _, router := range langRouters {
+		if err := router.initSemanticCache(embedRouterMap); err != nil {
+			return nil, err
+		}
+
 		langRouterMap[router.ID()] = router
 	}
 
+	healthCheckers, err := buildHealthCheckers(langRouters, tel)
+	if err != nil {
+		return nil, err
+	}
+
+	circuitMetrics, err := registerCircuitMetrics(langRouters, embedRouters)
+	if err != nil {
+		return nil, err
+	}
+
 	manager := RouterManager{
-		Config:        cfg,
-		tel:           tel,
-		langRouters:   langRouters,
-		langRouterMap: &langRouterMap,
+		Config:         cfg,
+		tel:            tel,
+		langRouters:    langRouters,
+		langRouterMap:  &langRouterMap,
+		embedRouters:   embedRouters,
+		embedRouterMap: &embedRouterMap,
+		healthCheckers: healthCheckers,
+		circuitMetrics: circuitMetrics,
 	}
 
 	return &manager, err
@@ -47,3 +87,95 @@ func (r *RouterManager) GetLangRouter(routerID string) (*LangRouter, error) {
 
 	return nil, &schemas.ErrRouterNotFound
 }
+
+func (r *RouterManager) GetEmbedRouters() []*EmbedRouter {
+	return r.embedRouters
+}
+
+// GetEmbedRouter returns an embedding router by ID
+func (r *RouterManager) GetEmbedRouter(routerID string) (*EmbedRouter, error) {
+	if router, found := (*r.embedRouterMap)[routerID]; found {
+		return router, nil
+	}
+
+	return nil, &schemas.ErrRouterNotFound
+}
+
+// DegradedRouters returns IDs of routers that have no healthy models to serve requests
+func (r *RouterManager) DegradedRouters() []string {
+	var routerIDs []string
+
+	for _, router := range r.langRouters {
+		if !router.Healthy() {
+			routerIDs = append(routerIDs, router.ID())
+		}
+	}
+
+	for _, router := range r.embedRouters {
+		if !router.Healthy() {
+			routerIDs = append(routerIDs, router.ID())
+		}
+	}
+
+	return routerIDs
+}
+
+// StartHealthChecks probes models with active health checks right away, so the first requests are not sent to unhealthy models.
+// Then, models are probed in background until the manager is shut down
+func (r *RouterManager) StartHealthChecks(ctx context.Context) {
+	var checkWG sync.WaitGroup
+
+	for _, checker := range r.healthCheckers {
+		checkWG.Add(1)
+
+		go func() {
+			defer checkWG.Done()
+
+			_ = checker.Check(ctx)
+		}()
+	}
+
+	checkWG.Wait()
+
+	for _, checker := range r.healthCheckers {
+		checker.Start()
+	}
+}
+
+// Shutdown stops background health checks and exporting metrics of managed routers
+func (r *RouterManager) Shutdown() {
+	for _, checker := range r.healthCheckers {
+		checker.Stop()
+	}
+
+	if r.circuitMetrics != nil {
+		if err := r.circuitMetrics.Unregister(); err != nil {
+			r.tel.L().Warn("Failed to unregister circuit metrics", zap.Error(err))
+		}
+	}
+}
+
+func buildHealthCheckers(langRouters []*LangRouter, tel *telemetry.Telemetry) ([]*providers.HealthChecker, error) {
+	var checkers []*providers.HealthChecker
+
+	for _, router := range langRouters {
+		for _, model := range router.chatModels {
+			if model.HealthCheck() == nil {
+				continue
+			}
+
+			checker, err := providers.NewHealthChecker(
+				model,
+				model.HealthCheck(),
+				tel.L().With(zap.String("routerID", router.ID())),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			checkers = append(checkers, checker)
+		}
+	}
+
+	return checkers, nil
+}
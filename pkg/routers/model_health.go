@@ -0,0 +1,97 @@
+package routers
+
+import (
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/routers/latency"
+)
+
+// Health returns the health state of the router models
+func (r *LangRouter) Health() schemas.RouterHealthSchema {
+	models := make([]schemas.ModelHealthSchema, 0, len(r.chatModels))
+
+	for _, model := range r.chatModels {
+		modelHealth := newModelHealth(model, model.Provider(), model.ModelName())
+		modelHealth.ChatLatency = newLatency(model.ChatLatency())
+
+		if model.SupportChatStream() {
+			modelHealth.ChatStreamLatency = newLatency(model.ChatStreamLatency())
+		}
+
+		models = append(models, modelHealth)
+	}
+
+	return newRouterHealth(r.routerID, models)
+}
+
+// Health returns the health state of the router models
+func (r *EmbedRouter) Health() schemas.RouterHealthSchema {
+	models := make([]schemas.ModelHealthSchema, 0, len(r.models))
+
+	for _, model := range r.models {
+		modelHealth := newModelHealth(model, model.Provider(), model.ModelName())
+		modelHealth.EmbedLatency = newLatency(model.EmbedLatency())
+
+		models = append(models, modelHealth)
+	}
+
+	return newRouterHealth(r.routerID, models)
+}
+
+// Healthy checks if the router has at least one healthy model to serve requests
+func (r *LangRouter) Healthy() bool {
+	return anyHealthy(r.chatModels)
+}
+
+// Healthy checks if the router has at least one healthy model to serve requests
+func (r *EmbedRouter) Healthy() bool {
+	return anyHealthy(r.models)
+}
+
+func anyHealthy[M interface{ Healthy() bool }](models []M) bool {
+	for _, model := range models {
+		if model.Healthy() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newRouterHealth(routerID RouterID, models []schemas.ModelHealthSchema) schemas.RouterHealthSchema {
+	healthy := false
+
+	for _, model := range models {
+		healthy = healthy || model.Healthy
+	}
+
+	return schemas.RouterHealthSchema{
+		RouterID: routerID,
+		Healthy:  healthy,
+		Models:   models,
+	}
+}
+
+func newModelHealth(model trackedModel, provider string, modelName string) schemas.ModelHealthSchema {
+	tracker := model.HealthTracker()
+	circuit := tracker.Circuit()
+
+	return schemas.ModelHealthSchema{
+		ModelID:           model.ID(),
+		Provider:          provider,
+		ModelName:         modelName,
+		Healthy:           tracker.Healthy(),
+		Unauthorized:      tracker.Unauthorized(),
+		RateLimitResetAt:  tracker.RateLimitResetAt(),
+		ErrorBudgetTokens: tracker.ErrBudgetTokens(),
+		CircuitState:      circuit.State(),
+		CircuitOpenUntil:  circuit.OpenUntil(),
+		Timeouts:          tracker.Timeouts(),
+	}
+}
+
+func newLatency(movingAverage *latency.MovingAverage) *schemas.LatencySchema {
+	return &schemas.LatencySchema{
+		Value:    movingAverage.Value(),
+		WarmedUp: movingAverage.WarmedUp(),
+	}
+}
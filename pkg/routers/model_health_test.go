@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangRouter_Health(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel("limited", ptesting.NewProviderMock(nil, nil), budget, nil, *latConfig, 1),
+		providers.NewLangModel("unauthorized", ptesting.NewProviderMock(nil, nil), budget, nil, *latConfig, 1),
+	}, &LangRouterConfig{})
+
+	untilReset := 10 * time.Minute
+	router.chatModels[0].HealthTracker().TrackErr(clients.NewRateLimitError(&untilReset))
+	router.chatModels[1].HealthTracker().TrackErr(clients.ErrUnauthorized)
+
+	routerHealth := router.Health()
+
+	require.Equal(t, "test_router", routerHealth.RouterID)
+	require.False(t, routerHealth.Healthy)
+	require.False(t, router.Healthy())
+	require.Len(t, routerHealth.Models, 2)
+
+	limited := routerHealth.Models[0]
+	require.False(t, limited.Healthy)
+	require.NotNil(t, limited.RateLimitResetAt)
+	require.WithinDuration(t, time.Now().Add(untilReset), *limited.RateLimitResetAt, time.Second)
+	require.Equal(t, health.CircuitClosed, limited.CircuitState)
+
+	unauthorized := routerHealth.Models[1]
+	require.False(t, unauthorized.Healthy)
+	require.True(t, unauthorized.Unauthorized)
+	require.Equal(t, health.CircuitOpen, unauthorized.CircuitState)
+	require.NotNil(t, unauthorized.CircuitOpenUntil)
+}
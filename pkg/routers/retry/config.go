@@ -1,25 +1,69 @@
 package retry
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/EinStack/glide/pkg/config/fields"
 )
 
-type ExpRetryConfig struct {
+// StrategyName defines how wait time grows between retries
+type StrategyName = string
+
+const (
+	Exp                StrategyName = "exp"
+	ExpJitter          StrategyName = "exp_jitter"
+	DecorrelatedJitter StrategyName = "decorrelated_jitter"
+	Constant           StrategyName = "constant"
+	Linear             StrategyName = "linear"
+)
+
+type Config struct {
+	Strategy       StrategyName     `yaml:"strategy,omitempty" json:"strategy" validate:"oneof=exp exp_jitter decorrelated_jitter constant linear"`
 	MaxRetries     int              `yaml:"max_retries,omitempty" json:"max_retries"`
-	BaseMultiplier int              `yaml:"base_multiplier,omitempty" json:"base_multiplier"`
+	BaseMultiplier int              `yaml:"base_multiplier,omitempty" json:"base_multiplier"` // exp & exp_jitter strategies only
 	MinDelay       fields.Duration  `yaml:"min_delay,omitempty" json:"min_delay" swaggertype:"primitive,string"`
 	MaxDelay       *fields.Duration `yaml:"max_delay,omitempty" json:"max_delay" swaggertype:"primitive,string"`
 }
 
-func DefaultExpRetryConfig() *ExpRetryConfig {
+func DefaultConfig() *Config {
 	maxDelay := fields.Duration(5 * time.Second)
 
-	return &ExpRetryConfig{
+	return &Config{
+		Strategy:       Exp,
 		MaxRetries:     3,
 		BaseMultiplier: 2,
 		MinDelay:       fields.Duration(2 * time.Second),
 		MaxDelay:       &maxDelay,
 	}
 }
+
+func (c *Config) ToRetry() (*Retry, error) {
+	minDelay := time.Duration(c.MinDelay)
+
+	var maxDelay *time.Duration
+
+	if c.MaxDelay != nil {
+		delay := time.Duration(*c.MaxDelay)
+		maxDelay = &delay
+	}
+
+	var strategy Strategy
+
+	switch c.Strategy {
+	case Exp:
+		strategy = NewExpStrategy(c.BaseMultiplier, minDelay, maxDelay)
+	case ExpJitter:
+		strategy = NewExpJitterStrategy(c.BaseMultiplier, minDelay, maxDelay)
+	case DecorrelatedJitter:
+		strategy = NewDecorrelatedJitterStrategy(minDelay, maxDelay)
+	case Constant:
+		strategy = NewConstantStrategy(minDelay)
+	case Linear:
+		strategy = NewLinearStrategy(minDelay, maxDelay)
+	default:
+		return nil, fmt.Errorf("retry strategy \"%v\" is not supported, please make sure there is no typo", c.Strategy)
+	}
+
+	return NewRetry(c.MaxRetries, strategy), nil
+}
@@ -8,15 +8,16 @@ import (
 )
 
 func TestRetryConfig_DefaultConfig(t *testing.T) {
-	config := DefaultExpRetryConfig()
+	config := DefaultConfig()
 
 	require.NotNil(t, config)
 }
 
 func TestRetryConfig_JSONMarshal(t *testing.T) {
-	defaultConfig := DefaultExpRetryConfig()
+	defaultConfig := DefaultConfig()
 
 	expectedJSON := `{
+		"strategy": "exp",
 		"max_retries": 3,
 		"base_multiplier": 2,
 		"min_delay": "2s",
@@ -27,3 +28,24 @@ func TestRetryConfig_JSONMarshal(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, expectedJSON, string(marshaledJSON))
 }
+
+func TestRetryConfig_ToRetry(t *testing.T) {
+	strategies := []StrategyName{Exp, ExpJitter, DecorrelatedJitter, Constant, Linear}
+
+	for _, strategy := range strategies {
+		config := DefaultConfig()
+		config.Strategy = strategy
+
+		retry, err := config.ToRetry()
+		require.NoError(t, err, strategy)
+		require.NotNil(t, retry, strategy)
+	}
+}
+
+func TestRetryConfig_UnsupportedStrategy(t *testing.T) {
+	config := DefaultConfig()
+	config.Strategy = "fibonacci"
+
+	_, err := config.ToRetry()
+	require.Error(t, err)
+}
@@ -0,0 +1,32 @@
+package retry
+
+import "time"
+
+// DecorrelatedJitterStrategy picks a random wait time between minDelay and three times the previous delay
+//
+//	(delay = random(minDelay, prevDelay * 3)), so delays grow, but requests spread out over time
+type DecorrelatedJitterStrategy struct {
+	minDelay time.Duration
+	maxDelay *time.Duration
+}
+
+func NewDecorrelatedJitterStrategy(minDelay time.Duration, maxDelay *time.Duration) *DecorrelatedJitterStrategy {
+	return &DecorrelatedJitterStrategy{
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+	}
+}
+
+func (s *DecorrelatedJitterStrategy) Delay(_ int, prevDelay time.Duration) time.Duration {
+	if prevDelay < s.minDelay {
+		prevDelay = s.minDelay
+	}
+
+	delay := randDelay(s.minDelay, prevDelay*3)
+
+	if s.maxDelay != nil && delay > *s.maxDelay {
+		delay = *s.maxDelay
+	}
+
+	return delay
+}
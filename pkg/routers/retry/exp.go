@@ -1,77 +1,69 @@
 package retry
 
 import (
-	"context"
+	"math/rand/v2"
 	"time"
 )
 
-// ExpRetry increase wait time exponentially with try number (delay = minDelay * baseMultiplier ^ attempt)
-type ExpRetry struct {
-	maxRetries     int
+// ExpStrategy increase wait time exponentially with try number (delay = minDelay * baseMultiplier ^ attempt)
+type ExpStrategy struct {
 	baseMultiplier int
 	minDelay       time.Duration
 	maxDelay       *time.Duration
 }
 
-func NewExpRetry(maxRetries int, baseMultiplier int, minDelay time.Duration, maxDelay *time.Duration) *ExpRetry {
-	return &ExpRetry{
-		maxRetries:     maxRetries,
+func NewExpStrategy(baseMultiplier int, minDelay time.Duration, maxDelay *time.Duration) *ExpStrategy {
+	return &ExpStrategy{
 		baseMultiplier: baseMultiplier,
 		minDelay:       minDelay,
 		maxDelay:       maxDelay,
 	}
 }
 
-func (r *ExpRetry) Iterator() *ExpRetryIterator {
-	return &ExpRetryIterator{
-		attempt:        0,
-		maxRetries:     r.maxRetries,
-		baseMultiplier: r.baseMultiplier,
-		minDelay:       r.minDelay,
-		maxDelay:       r.maxDelay,
-	}
-}
-
-type ExpRetryIterator struct {
-	attempt        int
-	maxRetries     int
-	baseMultiplier int
-	minDelay       time.Duration
-	maxDelay       *time.Duration
-}
-
-func (i *ExpRetryIterator) HasNext() bool {
-	return i.attempt < i.maxRetries
+func NewExpRetry(maxRetries int, baseMultiplier int, minDelay time.Duration, maxDelay *time.Duration) *Retry {
+	return NewRetry(maxRetries, NewExpStrategy(baseMultiplier, minDelay, maxDelay))
 }
 
-func (i *ExpRetryIterator) getNextWaitDuration(attempt int) time.Duration {
-	delay := i.minDelay
+func (s *ExpStrategy) Delay(attempt int, _ time.Duration) time.Duration {
+	delay := s.minDelay
 
 	if attempt > 0 {
-		delay = time.Duration(float64(delay) * float64(i.baseMultiplier<<(attempt-1)))
+		delay = time.Duration(float64(delay) * float64(s.baseMultiplier<<(attempt-1)))
 	}
 
-	if delay < i.minDelay {
-		delay = i.minDelay
+	if delay < s.minDelay {
+		delay = s.minDelay
 	}
 
-	if i.maxDelay != nil && delay > *i.maxDelay {
-		delay = *i.maxDelay
+	if s.maxDelay != nil && delay > *s.maxDelay {
+		delay = *s.maxDelay
 	}
 
 	return delay
 }
 
-func (i *ExpRetryIterator) WaitNext(ctx context.Context) error {
-	t := time.NewTimer(i.getNextWaitDuration(i.attempt))
-	i.attempt++
+// ExpJitterStrategy picks a random wait time between zero and the exponential delay ("full jitter"),
+//
+//	so requests that have been waiting for the same outage don't come back all at once
+type ExpJitterStrategy struct {
+	exp *ExpStrategy
+}
 
-	defer t.Stop()
+func NewExpJitterStrategy(baseMultiplier int, minDelay time.Duration, maxDelay *time.Duration) *ExpJitterStrategy {
+	return &ExpJitterStrategy{
+		exp: NewExpStrategy(baseMultiplier, minDelay, maxDelay),
+	}
+}
 
-	select {
-	case <-t.C:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+func (s *ExpJitterStrategy) Delay(attempt int, prevDelay time.Duration) time.Duration {
+	return randDelay(0, s.exp.Delay(attempt, prevDelay))
+}
+
+// randDelay picks a random delay in the [minDelay, maxDelay] range
+func randDelay(minDelay time.Duration, maxDelay time.Duration) time.Duration {
+	if maxDelay <= minDelay {
+		return minDelay
 	}
+
+	return minDelay + rand.N(maxDelay-minDelay+1)
 }
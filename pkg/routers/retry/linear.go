@@ -0,0 +1,41 @@
+package retry
+
+import "time"
+
+// ConstantStrategy waits the same time before each retry
+type ConstantStrategy struct {
+	delay time.Duration
+}
+
+func NewConstantStrategy(delay time.Duration) *ConstantStrategy {
+	return &ConstantStrategy{
+		delay: delay,
+	}
+}
+
+func (s *ConstantStrategy) Delay(_ int, _ time.Duration) time.Duration {
+	return s.delay
+}
+
+// LinearStrategy increases wait time linearly with try number (delay = minDelay * (attempt + 1))
+type LinearStrategy struct {
+	minDelay time.Duration
+	maxDelay *time.Duration
+}
+
+func NewLinearStrategy(minDelay time.Duration, maxDelay *time.Duration) *LinearStrategy {
+	return &LinearStrategy{
+		minDelay: minDelay,
+		maxDelay: maxDelay,
+	}
+}
+
+func (s *LinearStrategy) Delay(attempt int, _ time.Duration) time.Duration {
+	delay := s.minDelay * time.Duration(attempt+1)
+
+	if s.maxDelay != nil && delay > *s.maxDelay {
+		delay = *s.maxDelay
+	}
+
+	return delay
+}
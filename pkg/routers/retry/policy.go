@@ -0,0 +1,70 @@
+package retry
+
+import "github.com/EinStack/glide/pkg/providers/clients"
+
+// Action defines what the router does when the model fails to serve the request
+type Action = string
+
+const (
+	RetryModel Action = "retry"    // send the request to the same model again right away
+	Fallback   Action = "fallback" // send the request to the next model
+	FailFast   Action = "fail"     // return the error to the client as other models are not likely to do better
+)
+
+// PolicyConfig defines actions per class of model errors
+type PolicyConfig struct {
+	// MaxModelRetries is how many times the request is sent to the same model again before falling back (the retry action only)
+	MaxModelRetries int    `yaml:"max_model_retries,omitempty" json:"max_model_retries" validate:"gte=0"`
+	Timeout         Action `yaml:"timeout,omitempty" json:"timeout" validate:"oneof=retry fallback fail"`
+	Server          Action `yaml:"server,omitempty" json:"server" validate:"oneof=retry fallback fail"`
+	RateLimit       Action `yaml:"rate_limit,omitempty" json:"rate_limit" validate:"oneof=retry fallback fail"`
+	ContentFilter   Action `yaml:"content_filter,omitempty" json:"content_filter" validate:"oneof=retry fallback fail"`
+	BadRequest      Action `yaml:"bad_request,omitempty" json:"bad_request" validate:"oneof=retry fallback fail"`
+	Client          Action `yaml:"client,omitempty" json:"client" validate:"oneof=retry fallback fail"`
+	Auth            Action `yaml:"auth,omitempty" json:"auth" validate:"oneof=retry fallback fail"`
+	Other           Action `yaml:"other,omitempty" json:"other" validate:"oneof=retry fallback fail"` // errors that are not reported by providers (e.g. unusable answers)
+}
+
+func DefaultPolicyConfig() *PolicyConfig {
+	return &PolicyConfig{
+		MaxModelRetries: 1,
+		Timeout:         Fallback,
+		Server:          Fallback,
+		RateLimit:       Fallback,
+		ContentFilter:   Fallback,
+		BadRequest:      FailFast, // the same invalid request would just burn the budget & latency of other models
+		Client:          Fallback,
+		Auth:            Fallback,
+		Other:           Fallback,
+	}
+}
+
+// Action returns the action for the class of the error
+func (c *PolicyConfig) Action(err error) Action {
+	switch clients.ClassifyErr(err) {
+	case clients.ErrorClassTimeout:
+		return c.Timeout
+	case clients.ErrorClassServer:
+		return c.Server
+	case clients.ErrorClassRateLimit:
+		return c.RateLimit
+	case clients.ErrorClassContentFilter:
+		return c.ContentFilter
+	case clients.ErrorClassBadRequest:
+		return c.BadRequest
+	case clients.ErrorClassClient:
+		return c.Client
+	case clients.ErrorClassAuth:
+		return c.Auth
+	default:
+		return c.Other
+	}
+}
+
+func (c *PolicyConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = *DefaultPolicyConfig()
+
+	type plain PolicyConfig // to avoid recursion
+
+	return unmarshal((*plain)(c))
+}
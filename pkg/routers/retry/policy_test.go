@@ -0,0 +1,31 @@
+package retry
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyConfig_Action(t *testing.T) {
+	policy := DefaultPolicyConfig()
+	policy.Server = RetryModel
+
+	require.Equal(t, RetryModel, policy.Action(clients.NewProviderError(http.StatusBadGateway)))
+	require.Equal(t, Fallback, policy.Action(clients.NewProviderError(http.StatusBadRequest)))
+	require.Equal(t, Fallback, policy.Action(clients.NewProviderError(http.StatusNotFound)))
+	require.Equal(t, Fallback, policy.Action(clients.ErrUnauthorized))
+	require.Equal(t, Fallback, policy.Action(clients.ErrEmptyResponse))
+}
+
+func TestPolicyConfig_FailFastOnPromptTooLong(t *testing.T) {
+	policy := DefaultPolicyConfig()
+
+	promptTooLongErr := clients.NewProviderError(http.StatusBadRequest)
+	promptTooLongErr.PromptTooLong = true
+
+	require.Equal(t, FailFast, policy.Action(promptTooLongErr))
+	require.Equal(t, Fallback, policy.Action(clients.NewProviderError(http.StatusBadRequest)))
+	require.Equal(t, Fallback, policy.Action(clients.NewProviderError(http.StatusNotFound)))
+}
@@ -0,0 +1,94 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+)
+
+// Strategy defines how long to wait before the next retry
+type Strategy interface {
+	// Delay returns the wait time before the retry attempt (counted from zero).
+	//	prevDelay is the wait time before the previous attempt (zero before the first one)
+	Delay(attempt int, prevDelay time.Duration) time.Duration
+}
+
+// Retry retries requests up to maxRetries times waiting as long as the strategy says between attempts
+type Retry struct {
+	maxRetries int
+	strategy   Strategy
+}
+
+func NewRetry(maxRetries int, strategy Strategy) *Retry {
+	return &Retry{
+		maxRetries: maxRetries,
+		strategy:   strategy,
+	}
+}
+
+func (r *Retry) Iterator() *Iterator {
+	return &Iterator{
+		attempt:    0,
+		maxRetries: r.maxRetries,
+		strategy:   r.strategy,
+	}
+}
+
+type Iterator struct {
+	attempt    int
+	maxRetries int
+	strategy   Strategy
+	prevDelay  time.Duration
+	untilReset *time.Duration
+}
+
+func (i *Iterator) HasNext() bool {
+	return i.attempt < i.maxRetries
+}
+
+// ObserveErr remembers when the rate limit of the model is reset,
+//
+//	so the next wait is cut short if the model is going to be available earlier than the strategy expects
+func (i *Iterator) ObserveErr(err error) {
+	var rateLimitErr *clients.RateLimitError
+
+	if !errors.As(err, &rateLimitErr) {
+		return
+	}
+
+	untilReset := rateLimitErr.UntilReset()
+
+	if i.untilReset == nil || untilReset < *i.untilReset {
+		i.untilReset = &untilReset
+	}
+}
+
+func (i *Iterator) getNextWaitDuration(attempt int) time.Duration {
+	return i.strategy.Delay(attempt, i.prevDelay)
+}
+
+func (i *Iterator) WaitNext(ctx context.Context) error {
+	delay := i.getNextWaitDuration(i.attempt)
+
+	// strategies that depend on the previous delay don't see the delay cut by rate limits
+	i.prevDelay = delay
+	i.attempt++
+
+	if i.untilReset != nil && *i.untilReset < delay {
+		delay = *i.untilReset
+	}
+
+	i.untilReset = nil // rate limits are observed again during the next attempt
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
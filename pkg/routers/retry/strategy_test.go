@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpJitterStrategy_Delay(t *testing.T) {
+	maxDelay := 10 * time.Millisecond
+	strategy := NewExpJitterStrategy(2, 2*time.Millisecond, &maxDelay)
+	exp := NewExpStrategy(2, 2*time.Millisecond, &maxDelay)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		delay := strategy.Delay(attempt, 0)
+
+		require.GreaterOrEqual(t, delay, time.Duration(0))
+		require.LessOrEqual(t, delay, exp.Delay(attempt, 0))
+	}
+}
+
+func TestDecorrelatedJitterStrategy_Delay(t *testing.T) {
+	minDelay := 2 * time.Millisecond
+	maxDelay := 20 * time.Millisecond
+	strategy := NewDecorrelatedJitterStrategy(minDelay, &maxDelay)
+
+	prevDelay := time.Duration(0)
+
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := strategy.Delay(attempt, prevDelay)
+
+		require.GreaterOrEqual(t, delay, minDelay)
+		require.LessOrEqual(t, delay, max(prevDelay, minDelay)*3)
+		require.LessOrEqual(t, delay, maxDelay)
+
+		prevDelay = delay
+	}
+}
+
+func TestConstantStrategy_Delay(t *testing.T) {
+	strategy := NewConstantStrategy(3 * time.Millisecond)
+
+	for attempt := 0; attempt < 3; attempt++ {
+		require.Equal(t, 3*time.Millisecond, strategy.Delay(attempt, 0))
+	}
+}
+
+func TestLinearStrategy_Delay(t *testing.T) {
+	maxDelay := 5 * time.Millisecond
+	strategy := NewLinearStrategy(2*time.Millisecond, &maxDelay)
+
+	expectedDelays := []time.Duration{
+		2 * time.Millisecond,
+		4 * time.Millisecond,
+		5 * time.Millisecond,
+	}
+
+	for attempt, expectedDelay := range expectedDelays {
+		require.Equal(t, expectedDelay, strategy.Delay(attempt, 0))
+	}
+}
+
+func TestRetryIterator_RateLimitResetsEarlier(t *testing.T) {
+	untilReset := 1 * time.Millisecond
+	iterator := NewRetry(1, NewConstantStrategy(10*time.Second)).Iterator()
+
+	iterator.ObserveErr(clients.ErrProviderUnavailable)
+	iterator.ObserveErr(clients.NewRateLimitError(&untilReset))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	require.NoError(t, iterator.WaitNext(ctx))
+	require.False(t, iterator.HasNext())
+}
+
+func TestRetryIterator_RateLimitResetsLater(t *testing.T) {
+	untilReset := 1 * time.Minute
+	iterator := NewRetry(1, NewConstantStrategy(1*time.Millisecond)).Iterator()
+
+	iterator.ObserveErr(clients.NewRateLimitError(&untilReset))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	require.NoError(t, iterator.WaitNext(ctx))
+}
@@ -0,0 +1,60 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	"github.com/EinStack/glide/pkg/routers/retry"
+)
+
+// failFastStatuses are HTTP statuses of model errors returned to clients right away
+var failFastStatuses = map[clients.ErrorClass]int{
+	clients.ErrorClassTimeout:       http.StatusGatewayTimeout,
+	clients.ErrorClassRateLimit:     http.StatusTooManyRequests,
+	clients.ErrorClassContentFilter: http.StatusUnprocessableEntity,
+	clients.ErrorClassBadRequest:    http.StatusBadRequest,
+}
+
+func (r *LangRouter) retryPolicy() *retry.PolicyConfig {
+	if r.Config.RetryPolicy == nil {
+		return retry.DefaultPolicyConfig()
+	}
+
+	return r.Config.RetryPolicy
+}
+
+// policyChat sends the chat request to the model and sends it again if the retry policy says so
+func (r *LangRouter) policyChat(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	langModel providers.LangModel,
+) (*schemas.ChatResponse, []schemas.ChatAttempt, error) {
+	policy := r.retryPolicy()
+	attempts := make([]schemas.ChatAttempt, 0, 1)
+
+	for retries := 0; ; retries++ {
+		resp, attempt, err := r.chatModel(ctx, req, langModel)
+		attempts = append(attempts, attempt)
+
+		if err == nil || retries >= policy.MaxModelRetries || ctx.Err() != nil || policy.Action(err) != retry.RetryModel {
+			return resp, attempts, err
+		}
+	}
+}
+
+// failFastErr returns the error to send to the client if the retry policy doesn't let other models try to serve the request
+func (r *LangRouter) failFastErr(err error) (error, bool) {
+	if r.retryPolicy().Action(err) != retry.FailFast {
+		return nil, false
+	}
+
+	status, found := failFastStatuses[clients.ClassifyErr(err)]
+	if !found {
+		status = http.StatusBadGateway
+	}
+
+	return schemas.NewModelRequestFailedErr(status, err), true
+}
@@ -0,0 +1,118 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLangRouter_Chat_FailFastOnBadRequest(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	policy := retry.DefaultPolicyConfig()
+
+	promptTooLongErr := clients.NewProviderError(http.StatusBadRequest)
+	promptTooLongErr.PromptTooLong = true
+
+	fallbackProvider := ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "2"}})
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: promptTooLongErr}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel("second", fallbackProvider, budget, nil, *latConfig, 1),
+	}, &LangRouterConfig{RetryPolicy: policy})
+
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+
+	var apiErr *schemas.Error
+
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, schemas.ModelRequestFailed, apiErr.Name)
+	require.Equal(t, http.StatusBadRequest, apiErr.Status)
+
+	// the request has not been sent to the fallback model, so it's still able to answer
+	resp, err := fallbackProvider.Chat(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, "2", resp.ModelResponse.Message.Content)
+}
+
+func TestLangRouter_Chat_FallbackOnClientErrByDefault(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: clients.NewProviderError(http.StatusNotFound)}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "2"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, &LangRouterConfig{})
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+}
+
+func TestLangRouter_Chat_RetrySameModel(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	policy := retry.DefaultPolicyConfig()
+	policy.Server = retry.RetryModel
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{
+				{Err: clients.NewProviderError(http.StatusBadGateway)},
+				{Msg: "1"},
+			}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "2"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, &LangRouterConfig{RetryPolicy: policy})
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+	require.Len(t, resp.Attempts, 2)
+	require.Equal(t, "first", resp.Attempts[0].ModelID)
+	require.NotEmpty(t, resp.Attempts[0].Error)
+}
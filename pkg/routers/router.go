@@ -3,11 +3,16 @@ package routers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
+	"github.com/EinStack/glide/pkg/cache"
 	"github.com/EinStack/glide/pkg/routers/retry"
 	"go.uber.org/zap"
 
 	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
 
 	"github.com/EinStack/glide/pkg/telemetry"
 
@@ -27,7 +32,10 @@ type LangRouter struct {
 	chatStreamModels  []*providers.LanguageModel
 	chatRouting       routing.LangModelRouting
 	chatStreamRouting routing.LangModelRouting
-	retry             *retry.ExpRetry
+	retry             *retry.Retry
+	cache             *cache.ResponseCache
+	semanticCache     *cache.SemanticCache
+	cacheScope        string
 	tel               *telemetry.Telemetry
 	logger            *zap.Logger
 }
@@ -43,18 +51,28 @@ func NewLangRouter(cfg *LangRouterConfig, tel *telemetry.Telemetry) (*LangRouter
 		return nil, err
 	}
 
+	retryer, err := cfg.BuildRetry()
+	if err != nil {
+		return nil, err
+	}
+
 	router := &LangRouter{
 		routerID:          cfg.ID,
 		Config:            cfg,
 		chatModels:        chatModels,
 		chatStreamModels:  chatStreamModels,
-		retry:             cfg.BuildRetry(),
+		retry:             retryer,
 		chatRouting:       chatRouting,
 		chatStreamRouting: chatStreamRouting,
+		cacheScope:        cfg.ID,
 		tel:               tel,
 		logger:            tel.L().With(zap.String("routerID", cfg.ID)),
 	}
 
+	if cfg.Cache != nil && cfg.Cache.Enabled {
+		router.cache = cfg.Cache.ToCache()
+	}
+
 	return router, err
 }
 
@@ -62,17 +80,95 @@ func (r *LangRouter) ID() RouterID {
 	return r.routerID
 }
 
+// WithModel returns a view of the router that serves requests by the given model only.
+//
+//	The model is shared with the original router, so health & latency stats are tracked as usual
+func (r *LangRouter) WithModel(modelID string) (*LangRouter, error) {
+	chatModels := filterModels(r.chatModels, modelID)
+	if len(chatModels) == 0 {
+		return nil, &schemas.ErrModelNotFound
+	}
+
+	chatStreamModels := filterModels(r.chatStreamModels, modelID)
+
+	router := *r
+	router.chatModels = chatModels
+	router.chatStreamModels = chatStreamModels
+	router.chatRouting = routing.NewPriority(toModelPool(chatModels))
+	router.chatStreamRouting = routing.NewPriority(toModelPool(chatStreamModels))
+	router.logger = r.logger.With(zap.String("pinnedModelID", modelID))
+	// responses of the pinned model must not be served to requests routed to any model and vice versa
+	router.cacheScope = r.routerID + "/" + modelID
+
+	return &router, nil
+}
+
+// WithStreamRecovery returns a view of the router that recovers streaming chats in the given way
+// (e.g. for clients that can't handle restarts of the stream)
+func (r *LangRouter) WithStreamRecovery(recoveryConfig *StreamRecoveryConfig) *LangRouter {
+	config := *r.Config
+	config.StreamRecovery = recoveryConfig
+
+	router := *r
+	router.Config = &config
+
+	return &router
+}
+
 func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schemas.ChatResponse, error) {
 	if len(r.chatModels) == 0 {
 		return nil, ErrNoModels
 	}
 
+	if err := req.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	modelFilters, err := r.contentFilters(r.chatModels, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Config.TotalTimeout != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*r.Config.TotalTimeout))
+		defer cancel()
+	}
+
+	cacheKey, cacheControl, cacheable := r.chatCacheKey(ctx, req, r.cache.ChatKey)
+
+	if cacheable {
+		if resp := r.cachedChat(ctx, cacheKey, cacheControl); resp != nil {
+			resp.RouterID = r.routerID
+
+			return resp, nil
+		}
+	}
+
+	resp, semanticEntry := r.cachedSemanticChat(ctx, req, cacheControl)
+	if resp != nil {
+		resp.RouterID = r.routerID
+
+		return resp, nil
+	}
+
 	retryIterator := r.retry.Iterator()
 
+	var attempts []schemas.ChatAttempt
+
 	for retryIterator.HasNext() {
-		modelIterator := r.chatRouting.Iterator()
+		// models that failed to serve the request are not picked again until the next retry
+		//  (e.g. a model may stay healthy, but give an answer that doesn't match the requested format)
+		failedModels := make(map[string]struct{}, len(r.chatModels))
+		modelIterator := r.chatRouting.Iterator(append(slices.Clip(modelFilters), skipModels(failedModels))...)
 
 		for {
+			if err := ctxErr(ctx); err != nil {
+				// there is no time left to try other models
+				return nil, err
+			}
+
 			model, err := modelIterator.Next()
 
 			if errors.Is(err, routing.ErrNoHealthyModels) {
@@ -82,22 +178,44 @@ func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schem
 
 			langModel := model.(providers.LangModel)
 
-			chatParams := req.Params(langModel.ID(), langModel.ModelName())
+			var (
+				resp          *schemas.ChatResponse
+				modelAttempts []schemas.ChatAttempt
+			)
+
+			if r.Config.Hedging != nil {
+				resp, modelAttempts, err = r.hedgedChat(ctx, req, langModel, modelIterator, failedModels)
+			} else {
+				resp, modelAttempts, err = r.policyChat(ctx, req, langModel)
+				if err != nil {
+					failedModels[langModel.ID()] = struct{}{}
+				}
+			}
+
+			attempts = append(attempts, modelAttempts...)
 
-			resp, err := langModel.Chat(ctx, chatParams)
 			if err != nil {
-				r.logger.Warn(
-					"Lang model failed processing chat request",
-					zap.String("modelID", langModel.ID()),
-					zap.String("provider", langModel.Provider()),
-					zap.Error(err),
-				)
+				if failErr, failFast := r.failFastErr(err); failFast {
+					return nil, failErr
+				}
+
+				// the next retry may come sooner if the model is going to be available again
+				retryIterator.ObserveErr(err)
 
 				continue
 			}
 
 			resp.RouterID = r.routerID
 
+			if cacheable {
+				r.cacheChat(ctx, cacheKey, cacheControl, resp)
+			}
+
+			r.cacheSemanticChat(semanticEntry, cacheControl, resp)
+
+			// attempts are not cached as they describe how this very request was served
+			resp.Attempts = attempts
+
 			return resp, nil
 		}
 
@@ -108,7 +226,7 @@ func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schem
 		err := retryIterator.WaitNext(ctx)
 		if err != nil {
 			// something has cancelled the context
-			return nil, err
+			return nil, ctxErr(ctx)
 		}
 	}
 
@@ -118,6 +236,154 @@ func (r *LangRouter) Chat(ctx context.Context, req *schemas.ChatRequest) (*schem
 	return nil, &schemas.ErrNoModelAvailable
 }
 
+// contentFilters restricts routing to models that support content modalities of the request.
+//
+//	Text-only requests can be served by any model, so no filters are needed for them.
+//	Params are resolved per model as overrides may change the message content
+func (r *LangRouter) contentFilters(models []*providers.LanguageModel, req *schemas.ChatRequest) ([]routing.ModelFilter, error) {
+	if !req.IsMultimodal() {
+		return nil, nil
+	}
+
+	supportContent := func(model providers.Model) bool {
+		langModel := model.(providers.LangModel)
+
+		return langModel.SupportModalities(req.Params(langModel.ID(), langModel.ModelName()).Modalities())
+	}
+
+	for _, model := range models {
+		if supportContent(model) {
+			return []routing.ModelFilter{supportContent}, nil
+		}
+	}
+
+	r.logger.Warn("No model in the router supports content modalities of the request")
+
+	return nil, &schemas.ErrUnsupportedModality
+}
+
+// logIgnoredParams reports generation params the model's provider doesn't support, so they are not sent to it
+func (r *LangRouter) logIgnoredParams(langModel providers.LangModel, params *schemas.ChatParams) {
+	ignoredParams := params.GenerationParams.Unsupported(langModel.SupportParam)
+	if len(ignoredParams) == 0 {
+		return
+	}
+
+	r.logger.Debug(
+		"Lang model doesn't support some of the request params, ignoring them",
+		zap.String("modelID", langModel.ID()),
+		zap.String("provider", langModel.Provider()),
+		zap.Strings("params", ignoredParams),
+	)
+}
+
+// chatModel sends the chat request to the model within the attempt timeout
+func (r *LangRouter) chatModel(
+	ctx context.Context,
+	req *schemas.ChatRequest,
+	langModel providers.LangModel,
+) (*schemas.ChatResponse, schemas.ChatAttempt, error) {
+	if r.Config.AttemptTimeout != nil {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*r.Config.AttemptTimeout))
+		defer cancel()
+	}
+
+	chatParams := req.Params(langModel.ID(), langModel.ModelName())
+	r.logIgnoredParams(langModel, chatParams)
+
+	startedAt := time.Now()
+	resp, err := langModel.Chat(ctx, chatParams)
+
+	attempt := schemas.ChatAttempt{
+		ModelID:    langModel.ID(),
+		DurationMs: time.Since(startedAt).Milliseconds(),
+	}
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			attempt.TimedOut = true
+			// providers report deadlines in many ways, so the error is marked to be classified as timeout
+			err = fmt.Errorf("%w: %w", clients.ErrTimeout, err)
+		}
+
+		attempt.Error = err.Error()
+
+		r.logger.Warn(
+			"Lang model failed processing chat request",
+			zap.String("modelID", langModel.ID()),
+			zap.String("provider", langModel.Provider()),
+			zap.Bool("timedOut", attempt.TimedOut),
+			zap.Error(err),
+		)
+
+		return nil, attempt, err
+	}
+
+	return resp, attempt, nil
+}
+
+// ctxErr explains why the request context is done. Deadlines are reported to clients as request timeouts
+func ctxErr(ctx context.Context) error {
+	err := ctx.Err()
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &schemas.ErrRequestTimeout
+	}
+
+	return err
+}
+
+// sendChunks streams chunks to the client. The restart of the answer is announced before its first chunk
+func (r *LangRouter) sendChunks(
+	req *schemas.ChatStreamRequest,
+	recovery *streamRecovery,
+	chunks []*schemas.ChatStreamChunk,
+	respC chan<- *schemas.ChatStreamMessage,
+) {
+	if len(chunks) == 0 {
+		return
+	}
+
+	if reason, restarted := recovery.takeRestart(); restarted {
+		respC <- schemas.NewChatStreamRestart(req.ID, r.routerID, req.Metadata, reason)
+	}
+
+	for _, chunk := range chunks {
+		respC <- schemas.NewChatStreamChunk(req.ID, r.routerID, req.Metadata, chunk)
+	}
+}
+
+// skipModels filters out models with the given IDs
+func skipModels(modelIDs map[string]struct{}) routing.ModelFilter {
+	return func(model providers.Model) bool {
+		_, skipped := modelIDs[model.ID()]
+
+		return !skipped
+	}
+}
+
+func filterModels(models []*providers.LanguageModel, modelID string) []*providers.LanguageModel {
+	for _, model := range models {
+		if model.ID() == modelID {
+			return []*providers.LanguageModel{model}
+		}
+	}
+
+	return nil
+}
+
+func toModelPool(models []*providers.LanguageModel) []providers.Model {
+	pool := make([]providers.Model, 0, len(models))
+
+	for _, model := range models {
+		pool = append(pool, model)
+	}
+
+	return pool
+}
+
 func (r *LangRouter) ChatStream(
 	ctx context.Context,
 	req *schemas.ChatStreamRequest,
@@ -136,10 +402,54 @@ func (r *LangRouter) ChatStream(
 		return
 	}
 
+	if err := req.ValidateParams(); err != nil {
+		apiErr := schemas.FromErr(err)
+
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			apiErr.Name,
+			apiErr.Message,
+			req.Metadata,
+			&schemas.ReasonError,
+		)
+
+		return
+	}
+
+	modelFilters, err := r.contentFilters(r.chatStreamModels, req.ChatRequest)
+	if err != nil {
+		respC <- schemas.NewChatStreamError(
+			req.ID,
+			r.routerID,
+			schemas.ErrUnsupportedModality.Name,
+			schemas.ErrUnsupportedModality.Message,
+			req.Metadata,
+			&schemas.ReasonError,
+		)
+
+		return
+	}
+
+	cacheKey, cacheControl, cacheable := r.chatCacheKey(ctx, req.ChatRequest, r.cache.ChatStreamKey)
+
+	if cacheable {
+		if chunks := r.cachedChatStream(ctx, cacheKey, cacheControl); chunks != nil {
+			for _, chunk := range chunks {
+				respC <- schemas.NewChatStreamChunk(req.ID, r.routerID, req.Metadata, chunk)
+			}
+
+			return
+		}
+	}
+
 	retryIterator := r.retry.Iterator()
+	recovery := newStreamRecovery(r.Config.StreamRecovery)
 
 	for retryIterator.HasNext() {
-		modelIterator := r.chatStreamRouting.Iterator()
+		// models that failed to serve the request are not picked again until the next retry
+		failedModels := make(map[string]struct{}, len(r.chatStreamModels))
+		modelIterator := r.chatStreamRouting.Iterator(append(slices.Clip(modelFilters), skipModels(failedModels))...)
 
 	NextModel:
 		for {
@@ -151,7 +461,8 @@ func (r *LangRouter) ChatStream(
 			}
 
 			langModel := model.(providers.LangModel)
-			chatParams := req.Params(langModel.ID(), langModel.ModelName())
+			chatParams := recovery.params(req.Params(langModel.ID(), langModel.ModelName()))
+			r.logIgnoredParams(langModel, chatParams)
 
 			modelRespC, err := langModel.ChatStream(ctx, chatParams)
 			if err != nil {
@@ -162,6 +473,9 @@ func (r *LangRouter) ChatStream(
 					zap.Error(err),
 				)
 
+				failedModels[langModel.ID()] = struct{}{}
+				retryIterator.ObserveErr(err)
+
 				continue
 			}
 
@@ -175,29 +489,45 @@ func (r *LangRouter) ChatStream(
 						zap.Error(err),
 					)
 
+					failedModels[langModel.ID()] = struct{}{}
+					retryIterator.ObserveErr(err)
+
 					// It's challenging to hide an error in case of streaming chat as consumer apps
 					//  may have already used all chunks we streamed this far (e.g. showed them to their users like OpenAI UI does),
-					//  so we cannot easily restart that process from scratch
-					respC <- schemas.NewChatStreamError(
-						req.ID,
-						r.routerID,
-						schemas.ModelUnavailable,
-						err.Error(),
-						req.Metadata,
-						nil,
-					)
+					//  so unless the recovery mode can hide it, clients are told about the failure & the restart of the answer
+					if recovery.fail(err) {
+						var finishReason *schemas.FinishReason
+
+						if recovery.isStopped() {
+							// the error is the last message of the stream
+							finishReason = &schemas.ReasonError
+						}
+
+						respC <- schemas.NewChatStreamError(
+							req.ID,
+							r.routerID,
+							schemas.ModelUnavailable,
+							err.Error(),
+							req.Metadata,
+							finishReason,
+						)
+					}
+
+					if recovery.isStopped() {
+						return
+					}
 
 					continue NextModel
 				}
 
-				chunk := chunkResult.Chunk()
+				r.sendChunks(req, recovery, recovery.push(chunkResult.Chunk()), respC)
+			}
+
+			r.sendChunks(req, recovery, recovery.flush(), respC)
 
-				respC <- schemas.NewChatStreamChunk(
-					req.ID,
-					r.routerID,
-					req.Metadata,
-					chunk,
-				)
+			// the cached stream is the whole answer the client has received
+			if cacheable {
+				r.cacheChatStream(ctx, cacheKey, cacheControl, recovery.sentChunks)
 			}
 
 			return
@@ -216,7 +546,7 @@ func (r *LangRouter) ChatStream(
 				schemas.UnknownError,
 				err.Error(),
 				req.Metadata,
-				nil,
+				&schemas.ReasonError,
 			)
 
 			return
@@ -26,6 +26,7 @@ func TestLangRouter_Chat_PickFistHealthy(t *testing.T) {
 			"first",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -33,6 +34,7 @@ func TestLangRouter_Chat_PickFistHealthy(t *testing.T) {
 			"second",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -73,6 +75,7 @@ func TestLangRouter_Chat_PickThirdHealthy(t *testing.T) {
 			"first",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Msg: "3"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -80,6 +83,7 @@ func TestLangRouter_Chat_PickThirdHealthy(t *testing.T) {
 			"second",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Msg: "4"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -87,6 +91,7 @@ func TestLangRouter_Chat_PickThirdHealthy(t *testing.T) {
 			"third",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -131,6 +136,7 @@ func TestLangRouter_Chat_SuccessOnRetry(t *testing.T) {
 			"first",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Msg: "2"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -138,6 +144,7 @@ func TestLangRouter_Chat_SuccessOnRetry(t *testing.T) {
 			"second",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Msg: "1"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -175,6 +182,7 @@ func TestLangRouter_Chat_UnhealthyModelInThePool(t *testing.T) {
 			"first",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: clients.ErrProviderUnavailable}, {Msg: "3"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -182,6 +190,7 @@ func TestLangRouter_Chat_UnhealthyModelInThePool(t *testing.T) {
 			"second",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}, {Msg: "2"}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -221,6 +230,7 @@ func TestLangRouter_Chat_AllModelsUnavailable(t *testing.T) {
 			"first",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Err: &schemas.ErrNoModelAvailable}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -228,6 +238,7 @@ func TestLangRouter_Chat_AllModelsUnavailable(t *testing.T) {
 			"second",
 			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Err: &schemas.ErrNoModelAvailable}, {Err: &schemas.ErrNoModelAvailable}}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -272,6 +283,7 @@ func TestLangRouter_ChatStream(t *testing.T) {
 				}),
 			}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -285,6 +297,7 @@ func TestLangRouter_ChatStream(t *testing.T) {
 				}),
 			}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -340,6 +353,7 @@ func TestLangRouter_ChatStream_FailOnFirst(t *testing.T) {
 			"first",
 			ptesting.NewStreamProviderMock(nil, nil),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -355,6 +369,7 @@ func TestLangRouter_ChatStream_FailOnFirst(t *testing.T) {
 				),
 			}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -414,6 +429,7 @@ func TestLangRouter_ChatStream_AllModelsUnavailable(t *testing.T) {
 				}),
 			}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -425,6 +441,7 @@ func TestLangRouter_ChatStream_AllModelsUnavailable(t *testing.T) {
 				}),
 			}),
 			budget,
+			nil,
 			*latConfig,
 			1,
 		),
@@ -464,3 +481,181 @@ func TestLangRouter_ChatStream_AllModelsUnavailable(t *testing.T) {
 
 	require.Equal(t, []string{schemas.ModelUnavailable, schemas.ModelUnavailable, schemas.AllModelsUnavailable}, errs)
 }
+
+func TestLangRouter_Chat_SkipModelsWithoutModality(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	visionProvider := ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "It's a cat"}})
+	visionProvider.Modalities = []schemas.Modality{schemas.ModalityImage}
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"text-only",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"vision",
+			visionProvider,
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	tel := telemetry.NewTelemetryMock()
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              tel,
+		logger:           tel.L(),
+	}
+
+	ctx := context.Background()
+	req := schemas.ChatRequest{Message: schemas.ChatMessage{
+		Role: "user",
+		ContentParts: []schemas.ContentPart{
+			{Type: schemas.ContentPartText, Text: "What's in the image?"},
+			{Type: schemas.ContentPartImageURL, ImageURL: &schemas.ImageURL{URL: "https://example.com/cat.png"}},
+		},
+	}}
+
+	resp, err := router.Chat(ctx, &req)
+	require.NoError(t, err)
+	require.Equal(t, "vision", resp.ModelID)
+
+	req.Message.ContentParts = append(req.Message.ContentParts, schemas.ContentPart{
+		Type:     schemas.ContentPartDocument,
+		Document: &schemas.Document{URL: "https://example.com/report.pdf"},
+	})
+
+	_, err = router.Chat(ctx, &req)
+	require.ErrorIs(t, err, &schemas.ErrUnsupportedModality)
+}
+
+func TestLangRouter_Chat_InvalidParams(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "1"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	tel := telemetry.NewTelemetryMock()
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              tel,
+		logger:           tel.L(),
+	}
+
+	temperature := 3.0
+
+	ctx := context.Background()
+	req := schemas.NewChatFromStr("tell me a dad joke")
+	req.GenerationParams = &schemas.GenerationParams{Temperature: &temperature}
+
+	_, err := router.Chat(ctx, req)
+	require.Error(t, err)
+	require.Equal(t, schemas.InvalidParams, schemas.FromErr(err).Name)
+
+	temperature = 1.0
+
+	resp, err := router.Chat(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "first", resp.ModelID)
+}
+
+func TestLangRouter_Chat_FallbackOnResponseFormatMismatch(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "The answer is 42"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"second",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: `{"answer": 42}`}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := LangRouter{
+		routerID:         "test_router",
+		Config:           &LangRouterConfig{},
+		retry:            retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:      routing.NewPriority(models),
+		chatModels:       langModels,
+		chatStreamModels: langModels,
+		tel:              telemetry.NewTelemetryMock(),
+		logger:           telemetry.NewLoggerMock(),
+	}
+
+	ctx := context.Background()
+	req := schemas.NewChatFromStr("What's the answer? Respond in JSON")
+	req.ResponseFormat = &schemas.ResponseFormat{
+		Type: schemas.ResponseFormatJSONSchema,
+		JSONSchema: &schemas.JSONSchemaFormat{
+			Name: "answer",
+			Schema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"answer": map[string]any{"type": "integer"}},
+				"required":   []any{"answer"},
+			},
+		},
+		Validate: true,
+	}
+
+	resp, err := router.Chat(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "second", resp.ModelID)
+
+	// the first model is still healthy as it did respond
+	require.True(t, langModels[0].Healthy())
+}
@@ -80,8 +80,12 @@ func NewLeastLatencyRouting(latencyGetter LatencyGetter, models []providers.Mode
 	}
 }
 
-func (r *LeastLatencyRouting) Iterator() LangModelIterator {
-	return r
+func (r *LeastLatencyRouting) Iterator(filters ...ModelFilter) LangModelIterator {
+	if len(filters) == 0 {
+		return r
+	}
+
+	return FilteredIterator{next: r.next, filters: filters}
 }
 
 // Next picks a model with the least average latency over time
@@ -95,8 +99,12 @@ func (r *LeastLatencyRouting) Iterator() LangModelIterator {
 // other model latencies that might have improved over time).
 // For that, we introduced expiration time after which the model receives a request
 // even if it was not the fastest to respond
-func (r *LeastLatencyRouting) Next() (providers.Model, error) { //nolint:cyclop
-	coldSchedules := r.getColdModelSchedules()
+func (r *LeastLatencyRouting) Next() (providers.Model, error) {
+	return r.next(nil)
+}
+
+func (r *LeastLatencyRouting) next(filters []ModelFilter) (providers.Model, error) { //nolint:cyclop
+	coldSchedules := r.getColdModelSchedules(filters)
 
 	if len(coldSchedules) > 0 {
 		// warm up models
@@ -112,7 +120,7 @@ func (r *LeastLatencyRouting) Next() (providers.Model, error) { //nolint:cyclop
 	var nextSchedule *ModelSchedule
 
 	for _, schedule := range r.schedules {
-		if !schedule.model.Healthy() {
+		if !acceptModel(schedule.model, filters) {
 			// cannot do much with unavailable model
 			continue
 		}
@@ -146,11 +154,11 @@ func (r *LeastLatencyRouting) Next() (providers.Model, error) { //nolint:cyclop
 	return nil, ErrNoHealthyModels
 }
 
-func (r *LeastLatencyRouting) getColdModelSchedules() []*ModelSchedule {
+func (r *LeastLatencyRouting) getColdModelSchedules(filters []ModelFilter) []*ModelSchedule {
 	coldModels := make([]*ModelSchedule, 0, len(r.schedules))
 
 	for _, schedule := range r.schedules {
-		if schedule.model.Healthy() && !r.latencyGetter(schedule.model).WarmedUp() {
+		if acceptModel(schedule.model, filters) && !r.latencyGetter(schedule.model).WarmedUp() {
 			coldModels = append(coldModels, schedule)
 		}
 	}
@@ -24,18 +24,20 @@ func NewPriority(models []providers.Model) *PriorityRouting {
 	}
 }
 
-func (r *PriorityRouting) Iterator() LangModelIterator {
+func (r *PriorityRouting) Iterator(filters ...ModelFilter) LangModelIterator {
 	iterator := PriorityIterator{
-		idx:    &atomic.Uint64{},
-		models: r.models,
+		idx:     &atomic.Uint64{},
+		models:  r.models,
+		filters: filters,
 	}
 
 	return iterator
 }
 
 type PriorityIterator struct {
-	idx    *atomic.Uint64
-	models []providers.Model
+	idx     *atomic.Uint64
+	models  []providers.Model
+	filters []ModelFilter
 }
 
 func (r PriorityIterator) Next() (providers.Model, error) {
@@ -44,7 +46,7 @@ func (r PriorityIterator) Next() (providers.Model, error) {
 	for idx := int(r.idx.Load()); idx < len(models); idx = int(r.idx.Add(1)) {
 		model := models[idx]
 
-		if !model.Healthy() {
+		if !acceptModel(model, r.filters) {
 			continue
 		}
 
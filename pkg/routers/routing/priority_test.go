@@ -61,3 +61,20 @@ func TestPriorityRouting_NoHealthyModels(t *testing.T) {
 	_, err := iterator.Next()
 	require.Error(t, err)
 }
+
+func TestPriorityRouting_SkipFilteredModels(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewPriority(models)
+	iterator := routing.Iterator(func(model providers.Model) bool {
+		return model.ID() != "first"
+	})
+
+	model, err := iterator.Next()
+	require.NoError(t, err)
+	require.Equal(t, "second", model.ID())
+}
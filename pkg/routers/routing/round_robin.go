@@ -22,11 +22,19 @@ func NewRoundRobinRouting(models []providers.Model) *RoundRobinRouting {
 	}
 }
 
-func (r *RoundRobinRouting) Iterator() LangModelIterator {
-	return r
+func (r *RoundRobinRouting) Iterator(filters ...ModelFilter) LangModelIterator {
+	if len(filters) == 0 {
+		return r
+	}
+
+	return FilteredIterator{next: r.next, filters: filters}
 }
 
 func (r *RoundRobinRouting) Next() (providers.Model, error) {
+	return r.next(nil)
+}
+
+func (r *RoundRobinRouting) next(filters []ModelFilter) (providers.Model, error) {
 	modelLen := len(r.models)
 
 	// in order to avoid infinite loop in case of no healthy model is available,
@@ -35,7 +43,7 @@ func (r *RoundRobinRouting) Next() (providers.Model, error) {
 		idx := r.idx.Add(1) - 1
 		model := r.models[idx%uint64(modelLen)]
 
-		if !model.Healthy() {
+		if !acceptModel(model, filters) {
 			continue
 		}
 
@@ -64,3 +64,22 @@ func TestRoundRobinRouting_NoHealthyModels(t *testing.T) {
 	_, err := iterator.Next()
 	require.Error(t, err)
 }
+
+func TestRoundRobinRouting_SkipFilteredModels(t *testing.T) {
+	models := []providers.Model{
+		ptesting.NewLangModelMock("first", true, 0, 1),
+		ptesting.NewLangModelMock("second", true, 0, 1),
+		ptesting.NewLangModelMock("third", true, 0, 1),
+	}
+
+	routing := NewRoundRobinRouting(models)
+	iterator := routing.Iterator(func(model providers.Model) bool {
+		return model.ID() != "second"
+	})
+
+	for _, modelID := range []string{"first", "third", "first"} {
+		model, err := iterator.Next()
+		require.NoError(t, err)
+		require.Equal(t, modelID, model.ID())
+	}
+}
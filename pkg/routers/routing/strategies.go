@@ -12,9 +12,37 @@ var ErrNoHealthyModels = errors.New("no healthy models found")
 type Strategy string
 
 type LangModelRouting interface {
-	Iterator() LangModelIterator
+	// Iterator picks healthy models that pass all given filters
+	Iterator(filters ...ModelFilter) LangModelIterator
 }
 
 type LangModelIterator interface {
 	Next() (providers.Model, error)
 }
+
+// ModelFilter checks if the model can serve the request (e.g. supports its content modalities)
+type ModelFilter = func(model providers.Model) bool
+
+func acceptModel(model providers.Model, filters []ModelFilter) bool {
+	if !model.Healthy() {
+		return false
+	}
+
+	for _, filter := range filters {
+		if !filter(model) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilteredIterator picks models that pass filters according to the routing strategy
+type FilteredIterator struct {
+	next    func(filters []ModelFilter) (providers.Model, error)
+	filters []ModelFilter
+}
+
+func (i FilteredIterator) Next() (providers.Model, error) {
+	return i.next(i.filters)
+}
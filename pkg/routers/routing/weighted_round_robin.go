@@ -51,11 +51,19 @@ func NewWeightedRoundRobin(models []providers.Model) *WRoundRobinRouting {
 	}
 }
 
-func (r *WRoundRobinRouting) Iterator() LangModelIterator {
-	return r
+func (r *WRoundRobinRouting) Iterator(filters ...ModelFilter) LangModelIterator {
+	if len(filters) == 0 {
+		return r
+	}
+
+	return FilteredIterator{next: r.next, filters: filters}
 }
 
 func (r *WRoundRobinRouting) Next() (providers.Model, error) {
+	return r.next(nil)
+}
+
+func (r *WRoundRobinRouting) next(filters []ModelFilter) (providers.Model, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -64,7 +72,7 @@ func (r *WRoundRobinRouting) Next() (providers.Model, error) {
 	var maxWeighter *Weighter
 
 	for _, weighter := range r.weights {
-		if !weighter.model.Healthy() {
+		if !acceptModel(weighter.model, filters) {
 			continue
 		}
 
@@ -0,0 +1,136 @@
+package routers
+
+import (
+	"strings"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+)
+
+// StreamRecoveryMode defines how the streaming chat is served by the fallback model
+// when the current one fails in the middle of the stream
+type StreamRecoveryMode = string
+
+const (
+	// RestartRecovery restarts the answer on the fallback model.
+	//  Clients receive the model error and then the stream restart message before the new answer
+	RestartRecovery StreamRecoveryMode = "restart"
+	// BufferRecovery holds the first chunks back until the model has streamed enough of them,
+	//  so failures at the beginning of the stream are not visible to clients. Later failures are recovered by restarting
+	BufferRecovery StreamRecoveryMode = "buffer"
+	// ContinueRecovery asks the fallback model to continue the answer streamed this far,
+	//  so clients receive one seamless answer
+	ContinueRecovery StreamRecoveryMode = "continue"
+	// NoRecovery ends the stream with the model error once the client has received any chunks.
+	//  Failures before the first chunk are still served by the fallback model
+	NoRecovery StreamRecoveryMode = "none"
+)
+
+// streamRecovery tracks what the client has received this far to recover the stream after model failures
+type streamRecovery struct {
+	config         *StreamRecoveryConfig
+	sentChunks     []*schemas.ChatStreamChunk // chunks of the current answer the client has received
+	bufferedChunks []*schemas.ChatStreamChunk // chunks of the current model held back in the buffer mode
+	restarted      bool                       // the client has to be told about the restart before the next chunk
+	restartReason  string
+	stopped        bool // the stream can't be recovered, so it's over after the model error
+}
+
+func newStreamRecovery(config *StreamRecoveryConfig) *streamRecovery {
+	if config == nil {
+		config = DefaultStreamRecoveryConfig()
+	}
+
+	return &streamRecovery{
+		config: config,
+	}
+}
+
+// params adjusts the chat params of the next model. In the continue mode, the streamed answer is passed as the assistant prefix
+func (s *streamRecovery) params(chatParams *schemas.ChatParams) *schemas.ChatParams {
+	if s.config.Mode != ContinueRecovery || len(s.sentChunks) == 0 {
+		return chatParams
+	}
+
+	var prefix strings.Builder
+
+	for _, chunk := range s.sentChunks {
+		prefix.WriteString(chunk.ModelResponse.Message.Content)
+	}
+
+	if prefix.Len() == 0 {
+		return chatParams
+	}
+
+	chatParams.Messages = append(chatParams.Messages, schemas.ChatMessage{
+		Role:    "assistant",
+		Content: prefix.String(),
+	})
+
+	return chatParams
+}
+
+// push returns chunks that are ready to be sent to the client
+func (s *streamRecovery) push(chunk *schemas.ChatStreamChunk) []*schemas.ChatStreamChunk {
+	if s.config.Mode == BufferRecovery && len(s.sentChunks) == 0 {
+		s.bufferedChunks = append(s.bufferedChunks, chunk)
+
+		if len(s.bufferedChunks) < s.config.BufferChunks {
+			return nil
+		}
+
+		return s.flush()
+	}
+
+	s.sentChunks = append(s.sentChunks, chunk)
+
+	return []*schemas.ChatStreamChunk{chunk}
+}
+
+// flush returns chunks held back when the model stream is over
+func (s *streamRecovery) flush() []*schemas.ChatStreamChunk {
+	chunks := s.bufferedChunks
+
+	s.sentChunks = append(s.sentChunks, chunks...)
+	s.bufferedChunks = nil
+
+	return chunks
+}
+
+// fail handles the model failure in the middle of the stream. It's true if the client should receive the error
+func (s *streamRecovery) fail(err error) bool {
+	s.bufferedChunks = nil
+
+	switch {
+	case s.config.Mode == ContinueRecovery:
+		return false
+	case s.config.Mode == BufferRecovery && len(s.sentChunks) == 0:
+		return false
+	}
+
+	if len(s.sentChunks) > 0 && s.config.Mode == NoRecovery {
+		s.stopped = true
+
+		return true
+	}
+
+	if len(s.sentChunks) > 0 {
+		s.sentChunks = nil
+		s.restarted = true
+		s.restartReason = err.Error()
+	}
+
+	return true
+}
+
+// takeRestart returns the reason of the stream restart the client has not been told about yet
+func (s *streamRecovery) takeRestart() (string, bool) {
+	restarted := s.restarted
+	s.restarted = false
+
+	return s.restartReason, restarted
+}
+
+// isStopped checks if the stream is over after the model failure
+func (s *streamRecovery) isStopped() bool {
+	return s.stopped
+}
@@ -0,0 +1,155 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/providers"
+	"github.com/EinStack/glide/pkg/providers/clients"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+// newFailoverStreamRouter creates a router where the first model fails after streaming "Knock" and the second one serves the stream
+func newFailoverStreamRouter(recoveryConfig *StreamRecoveryConfig) (*LangRouter, *ptesting.ProviderMock) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+
+	fallbackProvider := ptesting.NewStreamProviderMock(nil, []ptesting.RespStreamMock{
+		ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: " knock"}, {Msg: " joke"}}),
+	})
+
+	langModels := []*providers.LanguageModel{
+		providers.NewLangModel(
+			"first",
+			ptesting.NewStreamProviderMock(nil, []ptesting.RespStreamMock{
+				ptesting.NewRespStreamMock(&[]ptesting.RespMock{{Msg: "Knock"}, {Err: clients.ErrProviderUnavailable}}),
+			}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel("second", fallbackProvider, budget, nil, *latConfig, 1),
+	}
+
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	router := &LangRouter{
+		routerID:          "test_stream_router",
+		Config:            &LangRouterConfig{StreamRecovery: recoveryConfig},
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewPriority(models),
+		chatModels:        langModels,
+		chatStreamRouting: routing.NewPriority(models),
+		chatStreamModels:  langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+
+	return router, fallbackProvider
+}
+
+func streamChat(router *LangRouter) []*schemas.ChatStreamMessage {
+	respC := make(chan *schemas.ChatStreamMessage)
+
+	go func() {
+		defer close(respC)
+
+		router.ChatStream(context.Background(), schemas.NewChatStreamFromStr("tell me a dad joke"), respC)
+	}()
+
+	messages := make([]*schemas.ChatStreamMessage, 0, 5)
+
+	for message := range respC {
+		messages = append(messages, message)
+	}
+
+	return messages
+}
+
+func TestLangRouter_ChatStream_RestartRecovery(t *testing.T) {
+	router, _ := newFailoverStreamRouter(&StreamRecoveryConfig{Mode: RestartRecovery})
+
+	messages := streamChat(router)
+	require.Len(t, messages, 5)
+
+	require.Equal(t, "Knock", messages[0].Chunk.ModelResponse.Message.Content)
+	require.Equal(t, schemas.ModelUnavailable, messages[1].Error.Name)
+	require.NotNil(t, messages[2].Restart)
+	require.Equal(t, " knock", messages[3].Chunk.ModelResponse.Message.Content)
+	require.Equal(t, "second", messages[3].Chunk.ModelID)
+	require.Equal(t, " joke", messages[4].Chunk.ModelResponse.Message.Content)
+}
+
+func TestLangRouter_ChatStream_BufferRecovery(t *testing.T) {
+	router, _ := newFailoverStreamRouter(&StreamRecoveryConfig{Mode: BufferRecovery, BufferChunks: 2})
+
+	messages := streamChat(router)
+	require.Len(t, messages, 2)
+
+	// the failure of the first model was hidden as it has not streamed enough chunks to be committed
+	for _, message := range messages {
+		require.Nil(t, message.Error)
+		require.Nil(t, message.Restart)
+		require.Equal(t, "second", message.Chunk.ModelID)
+	}
+}
+
+func TestLangRouter_ChatStream_ContinueRecovery(t *testing.T) {
+	router, fallbackProvider := newFailoverStreamRouter(&StreamRecoveryConfig{Mode: ContinueRecovery})
+
+	messages := streamChat(router)
+	require.Len(t, messages, 3)
+
+	chunks := make([]string, 0, len(messages))
+
+	for _, message := range messages {
+		require.Nil(t, message.Error)
+		require.Nil(t, message.Restart)
+
+		chunks = append(chunks, message.Chunk.ModelResponse.Message.Content)
+	}
+
+	require.Equal(t, []string{"Knock", " knock", " joke"}, chunks)
+
+	// the fallback model continues the streamed answer
+	require.Len(t, fallbackProvider.ChatStreamParams, 1)
+
+	fallbackMessages := fallbackProvider.ChatStreamParams[0].Messages
+	require.Len(t, fallbackMessages, 2)
+	require.Equal(t, schemas.ChatMessage{Role: "assistant", Content: "Knock"}, fallbackMessages[1])
+}
+
+func TestLangRouter_ChatStream_NoRecovery(t *testing.T) {
+	router, fallbackProvider := newFailoverStreamRouter(&StreamRecoveryConfig{Mode: NoRecovery})
+
+	messages := streamChat(router)
+	require.Len(t, messages, 2)
+
+	require.Equal(t, "Knock", messages[0].Chunk.ModelResponse.Message.Content)
+	require.Equal(t, schemas.ModelUnavailable, messages[1].Error.Name)
+	require.Equal(t, &schemas.ReasonError, messages[1].Error.FinishReason)
+
+	// the answer is not spliced with the one of the fallback model
+	require.Empty(t, fallbackProvider.ChatStreamParams)
+}
+
+func TestLangRouter_WithStreamRecovery(t *testing.T) {
+	router, _ := newFailoverStreamRouter(&StreamRecoveryConfig{Mode: RestartRecovery})
+
+	view := router.WithStreamRecovery(&StreamRecoveryConfig{Mode: NoRecovery})
+
+	require.Equal(t, NoRecovery, view.Config.StreamRecovery.Mode)
+	require.Equal(t, RestartRecovery, router.Config.StreamRecovery.Mode)
+}
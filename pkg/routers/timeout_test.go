@@ -0,0 +1,104 @@
+package routers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EinStack/glide/pkg/api/schemas"
+	"github.com/EinStack/glide/pkg/config/fields"
+	"github.com/EinStack/glide/pkg/providers"
+	ptesting "github.com/EinStack/glide/pkg/providers/testing"
+	"github.com/EinStack/glide/pkg/routers/health"
+	"github.com/EinStack/glide/pkg/routers/latency"
+	"github.com/EinStack/glide/pkg/routers/retry"
+	"github.com/EinStack/glide/pkg/routers/routing"
+	"github.com/EinStack/glide/pkg/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newTimeoutLangRouter(langModels []*providers.LanguageModel, cfg *LangRouterConfig) *LangRouter {
+	models := make([]providers.Model, 0, len(langModels))
+	for _, model := range langModels {
+		models = append(models, model)
+	}
+
+	return &LangRouter{
+		routerID:          "test_router",
+		Config:            cfg,
+		retry:             retry.NewExpRetry(3, 2, 1*time.Second, nil),
+		chatRouting:       routing.NewPriority(models),
+		chatModels:        langModels,
+		chatStreamRouting: routing.NewPriority(models),
+		chatStreamModels:  langModels,
+		tel:               telemetry.NewTelemetryMock(),
+		logger:            telemetry.NewLoggerMock(),
+	}
+}
+
+func TestLangRouter_Chat_AttemptTimeout(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	attemptTimeout := fields.Duration(20 * time.Millisecond)
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"slow",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "slow", Delay: 10 * time.Second}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"fast",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "fast"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, &LangRouterConfig{AttemptTimeout: &attemptTimeout})
+
+	resp, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.NoError(t, err)
+	require.Equal(t, "fast", resp.ModelID)
+
+	require.Len(t, resp.Attempts, 2)
+	require.Equal(t, "slow", resp.Attempts[0].ModelID)
+	require.True(t, resp.Attempts[0].TimedOut)
+	require.NotEmpty(t, resp.Attempts[0].Error)
+	require.GreaterOrEqual(t, resp.Attempts[0].DurationMs, int64(20))
+	require.Equal(t, "fast", resp.Attempts[1].ModelID)
+	require.False(t, resp.Attempts[1].TimedOut)
+	require.Empty(t, resp.Attempts[1].Error)
+}
+
+func TestLangRouter_Chat_TotalTimeout(t *testing.T) {
+	budget := health.NewErrorBudget(3, health.SEC)
+	latConfig := latency.DefaultConfig()
+	totalTimeout := fields.Duration(30 * time.Millisecond)
+
+	router := newTimeoutLangRouter([]*providers.LanguageModel{
+		providers.NewLangModel(
+			"slow",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "slow", Delay: 10 * time.Second}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+		providers.NewLangModel(
+			"fast",
+			ptesting.NewProviderMock(nil, []ptesting.RespMock{{Msg: "fast"}}),
+			budget,
+			nil,
+			*latConfig,
+			1,
+		),
+	}, &LangRouterConfig{TotalTimeout: &totalTimeout})
+
+	// the slow model has used up the whole request time, so fallbacks are not tried
+	_, err := router.Chat(context.Background(), schemas.NewChatFromStr("tell me a dad joke"))
+	require.ErrorIs(t, err, &schemas.ErrRequestTimeout)
+}